@@ -0,0 +1,552 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/cache"
+	"github.com/altinukshini/gha-tui/internal/config"
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ops"
+	"github.com/altinukshini/gha-tui/internal/search"
+	"github.com/altinukshini/gha-tui/internal/tui/dashboard"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprint(w, `Usage:
+  gha-tui [flags]                        interactive UI
+  gha-tui [flags] runs list [flags]      list workflow runs
+  gha-tui [flags] jobs <run> [flags]     list the jobs of a run
+  gha-tui [flags] logs <run> [flags]     print the logs of a run or one job
+  gha-tui [flags] search <run> <pattern> [flags]
+                                         search the logs of a run
+  gha-tui [flags] metrics [flags]        print a report of the Metrics tab
+
+Run a command with -h for its flags.
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+// listConcurrency is how many pages of a long list are fetched at once.
+const listConcurrency = 3
+
+// errSilent is returned by commands that have already reported why they
+// failed, such as a search without matches.
+var errSilent = errors.New("silent failure")
+
+// cli runs the headless subcommands, which print to stdout for scripts.
+type cli struct {
+	sources *config.Sources
+	repo    string // from -R before the command
+	host    string
+	stdout  io.Writer
+}
+
+// run runs the command in args and returns the exit status.
+func (c *cli) run(args []string) int {
+	commands := map[string]func(context.Context, []string) error{
+		"runs":    c.runs,
+		"jobs":    c.jobs,
+		"logs":    c.logs,
+		"search":  c.search,
+		"metrics": c.metrics,
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n", args[0])
+		usage()
+		return 2
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	err := cmd(ctx, args[1:])
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errSilent):
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	return 1
+}
+
+// output holds the -json and -format flags shared by the commands.
+type output struct {
+	json   bool
+	format string
+}
+
+// flagSet returns the flag set of a command, with -R and -hostname.
+func (c *cli) flagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: gha-tui %s %s\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&c.repo, "R", c.repo, "Repository in owner/repo format (default: the git remote of the current directory)")
+	fs.StringVar(&c.host, "hostname", c.host, "GitHub hostname")
+	return fs
+}
+
+// outputFlags adds -json and -format to the flag set of a command that
+// prints items.
+func outputFlags(fs *flag.FlagSet) *output {
+	out := &output{}
+	fs.BoolVar(&out.json, "json", false, "Print JSON")
+	fs.StringVar(&out.format, "format", "", "Print each item with a Go template, e.g. '{{.ID}} {{.Status}}'")
+	return out
+}
+
+// parseArgs parses flags given before, between or after the positional
+// arguments, and checks that there are exactly n of the latter.
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != n {
+		fs.Usage()
+		return nil, errSilent
+	}
+	return positional, nil
+}
+
+// client returns a client of the repository the command is for.
+func (c *cli) client() (*api.Client, config.Config, error) {
+	cfg, err := repoConfig(c.sources, c.repo, c.host)
+	if err != nil {
+		return nil, cfg, err
+	}
+	retry := api.DefaultRetryPolicy()
+	retry.MaxRetries = cfg.MaxRetries
+	client, err := api.NewClient(cfg.Owner, cfg.Repo, api.Options{Host: cfg.Host, Retry: &retry})
+	return client, cfg, err
+}
+
+var templateFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	"ago": ui.RelativeTime,
+}
+
+// printItems prints items as JSON, through the -format template, one item
+// per line, or with table.
+func printItems[T any](w io.Writer, out *output, items []T, table func(io.Writer, []T) error) error {
+	switch {
+	case out.json && out.format != "":
+		return errors.New("-json and -format cannot be used together")
+	case out.json:
+		if items == nil {
+			items = []T{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	case out.format != "":
+		tmpl, err := template.New("format").Funcs(templateFuncs).Parse(out.format)
+		if err != nil {
+			return fmt.Errorf("-format: %w", err)
+		}
+		for _, item := range items {
+			if err := tmpl.Execute(w, item); err != nil {
+				return fmt.Errorf("-format: %w", err)
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+	return table(w, items)
+}
+
+// state is the conclusion of a completed run or job, else its status.
+func state(status model.RunStatus, conclusion model.RunConclusion) string {
+	if status == model.RunStatusCompleted && conclusion != "" {
+		return string(conclusion)
+	}
+	return string(status)
+}
+
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+func parseRunID(s string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(s, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid run ID %q", s)
+	}
+	return id, nil
+}
+
+// runs lists workflow runs: gha-tui runs list.
+func (c *cli) runs(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "list" {
+		return errors.New("usage: gha-tui runs list [flags]")
+	}
+	fs := c.flagSet("runs list", "[flags]")
+	out := outputFlags(fs)
+	var filter api.RunsFilter
+	workflow := fs.String("workflow", "", "Workflow file name or ID")
+	fs.StringVar(&filter.Branch, "branch", "", "Branch")
+	fs.StringVar(&filter.Actor, "actor", "", "User who triggered the run")
+	fs.StringVar(&filter.Event, "event", "", "Triggering event, e.g. push")
+	fs.StringVar(&filter.Status, "status", "", "Status or conclusion, e.g. in_progress or failure")
+	fs.StringVar(&filter.Created, "created", "", "Creation date range, e.g. >=2025-01-01")
+	limit := fs.Int("limit", 30, "Maximum number of runs")
+	if _, err := parseArgs(fs, args[1:], 0); err != nil {
+		return err
+	}
+	if *limit < 1 {
+		return errors.New("-limit must be at least 1")
+	}
+	if id, err := strconv.ParseInt(*workflow, 10, 64); err == nil {
+		filter.WorkflowID = id
+	} else {
+		filter.WorkflowFile = *workflow
+	}
+
+	client, _, err := c.client()
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListAllRuns(ctx, filter, api.ListOptions{MaxItems: *limit})
+	if err != nil {
+		return err
+	}
+	return printItems(c.stdout, out, resp.Runs, func(w io.Writer, runs []model.Run) error {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "STATUS\tID\tWORKFLOW\tBRANCH\tEVENT\tCREATED\tTITLE")
+		for _, r := range runs {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", state(r.Status, r.Conclusion), r.ID,
+				r.Name, r.HeadBranch, r.Event, ui.RelativeTime(r.CreatedAt), r.DisplayTitle)
+		}
+		return tw.Flush()
+	})
+}
+
+// metrics prints a report of the metrics the Metrics tab shows for a time
+// window: gha-tui metrics.
+func (c *cli) metrics(ctx context.Context, args []string) error {
+	fs := c.flagSet("metrics", "[flags]")
+	window := fs.String("window", "7d", "Time window, e.g. 24h, 7d or 30d")
+	format := fs.String("format", "md", "Report format: "+strings.Join(dashboard.ExportFormats, ", "))
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	w, err := dashboard.ParseWindow(*window)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(dashboard.ExportFormats, *format) {
+		return fmt.Errorf("unknown format %q, want %s", *format, strings.Join(dashboard.ExportFormats, ", "))
+	}
+
+	client, cfg, err := c.client()
+	if err != nil {
+		return err
+	}
+	dir, err := cache.RunStoreDir(cfg.Host, cfg.Owner, cfg.Repo)
+	if err != nil {
+		return err
+	}
+	store, err := cache.OpenRunStore(dir)
+	if err != nil {
+		return err
+	}
+	// Filling the store can take a while; show how far it got on a
+	// terminal.
+	var onProgress func(ops.MetricsProgress)
+	if fi, err := os.Stderr.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		onProgress = func(p ops.MetricsProgress) {
+			fmt.Fprintf(os.Stderr, "\r\033[KLoading metrics: %s", p)
+		}
+	}
+	now := time.Now()
+	data, err := ops.LoadMetricsData(ctx, client, store, w.Since(now), listConcurrency, onProgress)
+	if onProgress != nil {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	if err != nil {
+		return err
+	}
+	if data.MissingJobs > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: the jobs of %d runs could not be fetched\n", data.MissingJobs)
+	}
+	metrics := dashboard.ComputeMetrics(data.Runs, data.Jobs)
+	return dashboard.NewReport(cfg.RepoNWO(), w, metrics, now).Write(c.stdout, *format)
+}
+
+// listJobs lists the jobs of the latest attempt of a run, or of attempt.
+func listJobs(ctx context.Context, client *api.Client, runID int64, attempt int) ([]model.Job, error) {
+	var resp *model.JobsResponse
+	var err error
+	if attempt > 0 {
+		resp, err = client.ListJobsForAttempt(ctx, runID, attempt, api.JobsFilter{PerPage: 100})
+	} else {
+		resp, err = client.ListJobs(ctx, runID, api.JobsFilter{Filter: "latest", PerPage: 100})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Jobs, nil
+}
+
+// jobs lists the jobs of a run: gha-tui jobs <run>.
+func (c *cli) jobs(ctx context.Context, args []string) error {
+	fs := c.flagSet("jobs", "<run> [flags]")
+	out := outputFlags(fs)
+	attempt := fs.Int("attempt", 0, "Run attempt (default: the latest)")
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	runID, err := parseRunID(pos[0])
+	if err != nil {
+		return err
+	}
+
+	client, _, err := c.client()
+	if err != nil {
+		return err
+	}
+	jobs, err := listJobs(ctx, client, runID, *attempt)
+	if err != nil {
+		return err
+	}
+	return printItems(c.stdout, out, jobs, func(w io.Writer, jobs []model.Job) error {
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "STATUS\tID\tNAME\tDURATION\tRUNNER")
+		for _, j := range jobs {
+			fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\n", state(j.Status, j.Conclusion), j.ID,
+				j.Name, formatDuration(j.Duration()), j.RunnerName)
+		}
+		return tw.Flush()
+	})
+}
+
+// runLogs returns the job logs of a run from the log cache, downloading
+// them when needed. Every attempt is merged unless attempt is set.
+func runLogs(ctx context.Context, client *api.Client, logCache *cache.LogCache, runID int64, attempt int) (map[string]string, error) {
+	run, err := client.GetRun(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt > run.RunAttempt {
+		return nil, fmt.Errorf("run %d has %d attempts", runID, run.RunAttempt)
+	}
+	if attempt > 0 {
+		return ops.LoadAttemptLogs(ctx, client, logCache, run, attempt)
+	}
+	return ops.LoadRunLogs(ctx, client, logCache, run)
+}
+
+// jobLog is one job's log, as printed by the logs command.
+type jobLog struct {
+	Job string `json:"job"`
+	Log string `json:"log"`
+}
+
+// logs prints the logs of a run: gha-tui logs <run>.
+func (c *cli) logs(ctx context.Context, args []string) error {
+	fs := c.flagSet("logs", "<run> [flags]")
+	out := outputFlags(fs)
+	jobName := fs.String("job", "", "Print only this job's log; partial names match")
+	attempt := fs.Int("attempt", 0, "Run attempt (default: every attempt, merged)")
+	pos, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	runID, err := parseRunID(pos[0])
+	if err != nil {
+		return err
+	}
+
+	client, cfg, err := c.client()
+	if err != nil {
+		return err
+	}
+	logCache, err := openLogCache(cfg)
+	if err != nil {
+		return err
+	}
+	logs, err := runLogs(ctx, client, logCache, runID, *attempt)
+	if err != nil {
+		return err
+	}
+
+	var items []jobLog
+	if *jobName != "" {
+		content, err := findJobLog(ctx, client, logs, runID, *attempt, *jobName)
+		if err != nil {
+			return err
+		}
+		items = []jobLog{{Job: *jobName, Log: content}}
+	} else {
+		for name, content := range logs {
+			items = append(items, jobLog{Job: name, Log: content})
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Job < items[j].Job })
+	}
+
+	return printItems(c.stdout, out, items, func(w io.Writer, items []jobLog) error {
+		for i, item := range items {
+			if len(items) > 1 {
+				if i > 0 {
+					fmt.Fprintln(w)
+				}
+				fmt.Fprintf(w, "==> %s <==\n", item.Job)
+			}
+			io.WriteString(w, item.Log)
+			if !strings.HasSuffix(item.Log, "\n") {
+				fmt.Fprintln(w)
+			}
+		}
+		return nil
+	})
+}
+
+// findJobLog returns a job's log from the run's logs, or from the job log
+// endpoint when the archive only has a stub for it, like the log view does.
+func findJobLog(ctx context.Context, client *api.Client, logs map[string]string, runID int64, attempt int, name string) (string, error) {
+	if content, ok := ops.LookupJobLog(logs, name); ok && !ops.IsSystemStub(content) {
+		return content, nil
+	}
+	jobs, err := listJobs(ctx, client, runID, attempt)
+	if err != nil {
+		return "", err
+	}
+	var job *model.Job
+	for i := range jobs {
+		if jobs[i].Name == name {
+			job = &jobs[i]
+			break
+		}
+		if job == nil && strings.Contains(jobs[i].Name, name) {
+			job = &jobs[i]
+		}
+	}
+	if job == nil {
+		names := make([]string, len(jobs))
+		for i, j := range jobs {
+			names[i] = j.Name
+		}
+		return "", fmt.Errorf("no job %q in run %d; jobs: %s", name, runID, strings.Join(names, ", "))
+	}
+	body, err := client.DownloadJobLog(ctx, job.ID)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	return string(data), err
+}
+
+// search searches the logs of a run: gha-tui search <run> <pattern>. Like
+// grep, it fails when nothing matches.
+func (c *cli) search(ctx context.Context, args []string) error {
+	fs := c.flagSet("search", "<run> <pattern> [flags]")
+	out := outputFlags(fs)
+	var query model.SearchQuery
+	fs.BoolVar(&query.IsRegex, "regex", false, "Treat the pattern as a regular expression")
+	fs.BoolVar(&query.CaseSensitive, "case-sensitive", false, "Match case")
+	fs.StringVar(&query.JobPattern, "job", "", "Search only jobs whose name matches this regular expression")
+	fs.BoolVar(&query.FailedOnly, "failed", false, "Search only failed jobs")
+	attempt := fs.Int("attempt", 0, "Run attempt (default: every attempt, merged)")
+	pos, err := parseArgs(fs, args, 2)
+	if err != nil {
+		return err
+	}
+	runID, err := parseRunID(pos[0])
+	if err != nil {
+		return err
+	}
+	query.Pattern = pos[1]
+	if query.IsRegex {
+		if _, err := regexp.Compile(query.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	}
+	if query.JobPattern != "" {
+		if _, err := regexp.Compile(query.JobPattern); err != nil {
+			return fmt.Errorf("-job: %w", err)
+		}
+	}
+
+	client, cfg, err := c.client()
+	if err != nil {
+		return err
+	}
+	logCache, err := openLogCache(cfg)
+	if err != nil {
+		return err
+	}
+	logs, err := runLogs(ctx, client, logCache, runID, *attempt)
+	if err != nil {
+		return err
+	}
+	var failed map[string]bool
+	if query.FailedOnly {
+		jobs, err := listJobs(ctx, client, runID, *attempt)
+		if err != nil {
+			return err
+		}
+		failed = make(map[string]bool)
+		for _, j := range jobs {
+			if j.Failed() {
+				failed[j.Name] = true
+			}
+		}
+	}
+
+	results := search.New().SearchWithFilter(logs, query, runID, *attempt, failed)
+	matches := results.Matches
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].JobName != matches[j].JobName {
+			return matches[i].JobName < matches[j].JobName
+		}
+		return matches[i].Line < matches[j].Line
+	})
+	err = printItems(c.stdout, out, matches, func(w io.Writer, matches []model.SearchResult) error {
+		for _, m := range matches {
+			fmt.Fprintf(w, "%s:%d:%s\n", m.JobName, m.Line, m.Content)
+		}
+		return nil
+	})
+	if err == nil && len(matches) == 0 {
+		return errSilent
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func TestPrintItems(t *testing.T) {
+	runs := []model.Run{
+		{ID: 1, Name: "CI", Status: model.RunStatusCompleted, Conclusion: model.ConclusionFailure},
+		{ID: 2, Name: "Deploy", Status: model.RunStatusInProgress},
+	}
+	table := func(w io.Writer, runs []model.Run) error {
+		for _, r := range runs {
+			io.WriteString(w, r.Name+" "+state(r.Status, r.Conclusion)+"\n")
+		}
+		return nil
+	}
+	tests := []struct {
+		name    string
+		out     output
+		runs    []model.Run
+		want    string
+		wantErr string
+	}{
+		{"table", output{}, runs, "CI failure\nDeploy in_progress\n", ""},
+		{"format", output{format: "{{.ID}}:{{.Name}}"}, runs, "1:CI\n2:Deploy\n", ""},
+		{"format json func", output{format: "{{json .Actor}}"}, runs[:1], `{"login":"","avatar_url":""}` + "\n", ""},
+		{"json", output{json: true}, runs[:1], `"conclusion": "failure"`, ""},
+		{"empty json", output{json: true}, nil, "[]\n", ""},
+		{"bad template", output{format: "{{.Nope"}, runs, "", "-format"},
+		{"both", output{json: true, format: "{{.ID}}"}, runs, "", "cannot be used together"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := printItems(&buf, &tt.out, tt.runs, table)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("printItems() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("printItems() error = %v", err)
+			}
+			if got := buf.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("printItems() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	job := fs.String("job", "", "")
+	regex := fs.Bool("regex", false, "")
+
+	got, err := parseArgs(fs, []string{"-regex", "123", "error:.*", "-job", "build"}, 2)
+	if err != nil {
+		t.Fatalf("parseArgs() error = %v", err)
+	}
+	if want := []string{"123", "error:.*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseArgs() = %q, want %q", got, want)
+	}
+	if *job != "build" || !*regex {
+		t.Errorf("flags after the arguments not parsed: job = %q, regex = %v", *job, *regex)
+	}
+
+	if _, err := parseArgs(fs, []string{"123"}, 2); err == nil {
+		t.Error("parseArgs() accepted a missing argument")
+	}
+}
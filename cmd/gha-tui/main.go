@@ -1,20 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime/debug"
 	"strings"
-	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/altinukshini/gha-tui/internal/api"
 	"github.com/altinukshini/gha-tui/internal/cache"
 	"github.com/altinukshini/gha-tui/internal/config"
+	"github.com/altinukshini/gha-tui/internal/git"
 	"github.com/altinukshini/gha-tui/internal/tui"
+	"github.com/altinukshini/gha-tui/internal/ui"
 )
 
 var version = "dev"
@@ -28,11 +31,30 @@ func init() {
 	}
 }
 
+// repoList collects repeated -R flags.
+type repoList []string
+
+func (r *repoList) String() string { return strings.Join(*r, ",") }
+
+func (r *repoList) Set(v string) error {
+	*r = append(*r, v)
+	return nil
+}
+
 func main() {
-	repo := flag.String("R", "", "Repository in owner/repo format (required)")
-	cacheSizeMB := flag.Int("cache-size", 500, "Max log cache size in MB")
-	cacheTTL := flag.Duration("cache-ttl", 24*time.Hour, "Log cache TTL")
+	defaults := config.DefaultSettings()
+	var repos repoList
+	flag.Var(&repos, "R", "Repository in owner/repo format; repeat to watch several (default: the git remote of the current directory)")
+	org := flag.String("org", "", "Show an overview of an organization's repositories instead of a single repo")
+	configPath := flag.String("config", "", "Config file (default: $GHA_TUI_CONFIG or ~/.config/gha-tui/config.yaml)")
+	hostname := flag.String("hostname", os.Getenv("GH_HOST"), "GitHub hostname, e.g. a GitHub Enterprise Server host (default: GH_HOST, the config file or github.com)")
+	cacheSizeMB := flag.Int("cache-size", defaults.CacheSizeMB, "Max log cache size in MB")
+	cacheTTL := flag.Duration("cache-ttl", defaults.CacheTTL, "Log cache TTL")
+	downloadDir := flag.String("download-dir", defaults.DownloadDir, "Directory for downloaded artifacts")
+	maxRetries := flag.Int("max-retries", defaults.MaxRetries, "Retries for failed idempotent API requests (0 disables)")
+	theme := flag.String("theme", os.Getenv("GHA_TUI_THEME"), "Colour theme: auto, dark, light, high-contrast, or a theme file (default: GHA_TUI_THEME, the config file or auto)")
 	showVersion := flag.Bool("version", false, "Print version and exit")
+	flag.Usage = usage
 	flag.Parse()
 
 	if *showVersion {
@@ -40,47 +62,276 @@ func main() {
 		os.Exit(0)
 	}
 
-	if *repo == "" {
-		fmt.Fprintln(os.Stderr, "Error: -R owner/repo is required")
-		flag.Usage()
+	if *org != "" && len(repos) > 0 {
+		fmt.Fprintln(os.Stderr, "Error: -org and -R cannot be used together")
+		os.Exit(1)
+	}
+
+	// Only flags given on the command line override the config file and
+	// the environment.
+	var flags config.Overrides
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "cache-size":
+			flags.CacheSizeMB = cacheSizeMB
+		case "cache-ttl":
+			flags.CacheTTL = cacheTTL
+		case "download-dir":
+			flags.DownloadDir = downloadDir
+		case "max-retries":
+			flags.MaxRetries = maxRetries
+		}
+	})
+	if len(repos) > 1 {
+		flags.Watch = repos[1:]
+	}
+	sources, err := loadSources(*configPath, flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Config error: %v\n", err)
 		os.Exit(1)
 	}
+	host := *hostname
+	if host == "" {
+		host = sources.File.Hostname
+	}
 
-	parts := strings.SplitN(*repo, "/", 2)
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		fmt.Fprintln(os.Stderr, "Error: repo must be in owner/repo format")
+	if flag.NArg() > 0 {
+		if *org != "" {
+			fmt.Fprintln(os.Stderr, "Error: -org cannot be used with a command")
+			os.Exit(1)
+		}
+		c := &cli{sources: sources, host: host, stdout: os.Stdout}
+		if len(repos) > 0 {
+			c.repo = repos[0]
+		}
+		os.Exit(c.run(flag.Args()))
+	}
+
+	if err := loadKeys(); err != nil {
+		fmt.Fprintf(os.Stderr, "Key bindings error: %v\n", err)
+		os.Exit(1)
+	}
+	themeName := *theme
+	if themeName == "" {
+		themeName = sources.File.Theme
+	}
+	if err := loadTheme(themeName); err != nil {
+		fmt.Fprintf(os.Stderr, "Theme error: %v\n", err)
 		os.Exit(1)
 	}
 
-	cfg := config.Config{Owner: parts[0], Repo: parts[1]}
-	if err := cfg.Validate(); err != nil {
+	if *org != "" {
+		cfg := sources.Config(*org, "")
+		cfg.Repo, cfg.Host, cfg.Watch = "", host, nil
+		runOrg(cfg)
+		return
+	}
+
+	var nwo string
+	if len(repos) > 0 {
+		nwo = repos[0]
+	}
+	cfg, err := repoConfig(sources, nwo, host)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	client, err := api.NewClient(cfg.Owner, cfg.Repo)
+	// All clients share one token, so they share one rate-limit budget.
+	retry := api.DefaultRetryPolicy()
+	retry.MaxRetries = cfg.MaxRetries
+	opts := api.Options{Host: cfg.Host, Retry: &retry, RateTracker: api.NewRateTracker()}
+	client, err := api.NewClient(cfg.Owner, cfg.Repo, opts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Auth error: %v\n", err)
-		fmt.Fprintln(os.Stderr, "Make sure you are authenticated with: gh auth login")
+		if cfg.Host != "" {
+			fmt.Fprintf(os.Stderr, "Make sure you are authenticated with: gh auth login --hostname %s\n", cfg.Host)
+		} else {
+			fmt.Fprintln(os.Stderr, "Make sure you are authenticated with: gh auth login")
+		}
 		os.Exit(1)
 	}
 
-	if err := client.CheckRepo(); err != nil {
+	if err := client.CheckRepo(context.Background()); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	cacheDir := filepath.Join(os.TempDir(), "gha-tui", "logs")
-	logCache, err := cache.NewLogCache(cacheDir, *cacheSizeMB, *cacheTTL)
+	var watched []*api.Client
+	for _, nwo := range cfg.Watch {
+		owner, repo, _ := config.ParseRepo(nwo)
+		c, err := api.NewClient(owner, repo, opts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := c.CheckRepo(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		watched = append(watched, c)
+	}
+
+	logCache, err := openLogCache(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Cache error: %v\n", err)
 		os.Exit(1)
 	}
 
-	app := tui.NewApp(cfg, client, logCache)
+	app := tui.NewApp(cfg, client, logCache, watched...)
 	p := tea.NewProgram(app, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// loadSources reads the config file and the environment. The default config
+// file may be missing; one named with -config may not.
+func loadSources(path string, flags config.Overrides) (*config.Sources, error) {
+	required := path != ""
+	if !required {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	file, err := config.LoadFile(path, required)
+	if err != nil {
+		return nil, err
+	}
+	env, err := config.EnvOverrides(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	if err := flags.Validate(); err != nil {
+		return nil, fmt.Errorf("flags: %w", err)
+	}
+	return &config.Sources{File: file, Env: env, Flags: flags}, nil
+}
+
+// loadKeys applies keys.yaml from the config directory. Two actions bound
+// to the same key where both are active is an error, reported for every
+// such key at once.
+func loadKeys() error {
+	path, err := config.KeysPath()
+	if err != nil {
+		return err
+	}
+	overrides, err := config.LoadKeys(path)
+	if err != nil {
+		return err
+	}
+	if err := ui.Keys.Override(overrides); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if conflicts := ui.Keys.Conflicts(); len(conflicts) > 0 {
+		return fmt.Errorf("%s: conflicting bindings:\n  %s", path, strings.Join(conflicts, "\n  "))
+	}
+	return nil
+}
+
+// loadTheme applies a built-in theme or a theme file, which sets colours on
+// top of a built-in base theme.
+func loadTheme(name string) error {
+	t, ok := ui.BuiltinTheme(name)
+	if ok {
+		ui.ApplyTheme(t)
+		return nil
+	}
+	path, err := config.ThemePath(name)
+	if err != nil {
+		return err
+	}
+	f, err := config.LoadTheme(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unknown theme %q: want one of %s, or a theme file such as %s", name, strings.Join(ui.ThemeNames, ", "), path)
+	}
+	if err != nil {
+		return err
+	}
+	if t, ok = ui.BuiltinTheme(f.Base); !ok {
+		return fmt.Errorf("%s: unknown base theme %q, want one of %s", path, f.Base, strings.Join(ui.ThemeNames, ", "))
+	}
+	t.Name = name
+	if err := t.Override(f.Colors); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	ui.ApplyTheme(t)
+	return nil
+}
+
+// repoConfig resolves the config of the repository nwo, or of the git
+// checkout in the working directory when nwo is empty.
+func repoConfig(sources *config.Sources, nwo, host string) (config.Config, error) {
+	var cfg config.Config
+	if nwo == "" {
+		remote, branch, err := detectRepo(host)
+		if err != nil {
+			return cfg, fmt.Errorf("%w\nRun gha-tui inside a clone of a GitHub repository or pass -R owner/repo", err)
+		}
+		cfg = sources.Config(remote.Owner, remote.Repo)
+		cfg.Host, cfg.Branch = host, branch
+		if cfg.Host == "" && remote.Host != "github.com" {
+			cfg.Host = remote.Host
+		}
+	} else {
+		owner, repo, err := config.ParseRepo(nwo)
+		if err != nil {
+			return cfg, err
+		}
+		cfg = sources.Config(owner, repo)
+		cfg.Host = host
+	}
+	return cfg, cfg.Validate()
+}
+
+// openLogCache opens the log cache shared by every gha-tui process.
+func openLogCache(cfg config.Config) (*cache.LogCache, error) {
+	return cache.NewLogCache(filepath.Join(os.TempDir(), "gha-tui", "logs"), cfg.CacheSizeMB, cfg.CacheTTL)
+}
+
+// detectRepo reads the repository from the git remotes of the working
+// directory, preferring upstream over origin like gh does, along with the
+// branch checked out, which pre-filters the Runs tab. With a hostname, only
+// remotes on that host are considered.
+func detectRepo(hostname string) (git.Remote, string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return git.Remote{}, "", err
+	}
+	remotes, err := git.Remotes(dir)
+	if err != nil {
+		return git.Remote{}, "", err
+	}
+	remote, err := git.Pick(remotes, hostname)
+	if err != nil {
+		return git.Remote{}, "", err
+	}
+	// A detached HEAD or a git error just leaves the Runs tab unfiltered.
+	branch, _ := git.CurrentBranch(dir)
+	return remote, branch, nil
+}
+
+// runOrg runs the organization overview.
+func runOrg(cfg config.Config) {
+	retry := api.DefaultRetryPolicy()
+	retry.MaxRetries = cfg.MaxRetries
+	client, err := api.NewClient(cfg.Owner, "", api.Options{Host: cfg.Host, Retry: &retry})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Auth error: %v\n", err)
+		os.Exit(1)
+	}
+
+	logCache, err := openLogCache(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cache error: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := tea.NewProgram(tui.NewOrgApp(cfg, client, logCache), tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
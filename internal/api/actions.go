@@ -1,34 +1,37 @@
 package api
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 type RerunConfig struct {
 	EnableDebugLogging bool `json:"enable_debug_logging,omitempty"`
 }
 
-func (c *Client) RerunWorkflow(runID int64, debug bool) error {
+func (c *Client) RerunWorkflow(ctx context.Context, runID int64, debug bool) error {
 	body := RerunConfig{EnableDebugLogging: debug}
-	return c.Post(fmt.Sprintf("actions/runs/%d/rerun", runID), body, nil)
+	return c.Post(ctx, fmt.Sprintf("actions/runs/%d/rerun", runID), body, nil)
 }
 
-func (c *Client) RerunFailedJobs(runID int64, debug bool) error {
+func (c *Client) RerunFailedJobs(ctx context.Context, runID int64, debug bool) error {
 	body := RerunConfig{EnableDebugLogging: debug}
-	return c.Post(fmt.Sprintf("actions/runs/%d/rerun-failed-jobs", runID), body, nil)
+	return c.Post(ctx, fmt.Sprintf("actions/runs/%d/rerun-failed-jobs", runID), body, nil)
 }
 
-func (c *Client) RerunJob(jobID int64, debug bool) error {
+func (c *Client) RerunJob(ctx context.Context, jobID int64, debug bool) error {
 	body := RerunConfig{EnableDebugLogging: debug}
-	return c.Post(fmt.Sprintf("actions/jobs/%d/rerun", jobID), body, nil)
+	return c.Post(ctx, fmt.Sprintf("actions/jobs/%d/rerun", jobID), body, nil)
 }
 
-func (c *Client) CancelRun(runID int64) error {
-	return c.Post(fmt.Sprintf("actions/runs/%d/cancel", runID), nil, nil)
+func (c *Client) CancelRun(ctx context.Context, runID int64) error {
+	return c.Post(ctx, fmt.Sprintf("actions/runs/%d/cancel", runID), nil, nil)
 }
 
-func (c *Client) ForceCancelRun(runID int64) error {
-	return c.Post(fmt.Sprintf("actions/runs/%d/force-cancel", runID), nil, nil)
+func (c *Client) ForceCancelRun(ctx context.Context, runID int64) error {
+	return c.Post(ctx, fmt.Sprintf("actions/runs/%d/force-cancel", runID), nil, nil)
 }
 
-func (c *Client) DeleteRun(runID int64) error {
-	return c.Delete(fmt.Sprintf("actions/runs/%d", runID))
+func (c *Client) DeleteRun(ctx context.Context, runID int64) error {
+	return c.Delete(ctx, fmt.Sprintf("actions/runs/%d", runID))
 }
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// ListArtifacts returns artifacts for the repository, newest first, up to
+// opts.MaxItems. An empty name lists all artifacts.
+func (c *Client) ListArtifacts(ctx context.Context, name string, opts ListOptions) (*model.ArtifactsResponse, error) {
+	endpoint := "actions/artifacts"
+	if name != "" {
+		endpoint += "?name=" + url.QueryEscape(name)
+	}
+	res, err := paginate[model.Artifact](ctx, c, c.repoPath(endpoint), "artifacts", opts)
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts: %w", err)
+	}
+	return &model.ArtifactsResponse{TotalCount: res.TotalCount, Artifacts: res.Items}, nil
+}
+
+// ListRunArtifacts returns the artifacts uploaded by a workflow run.
+func (c *Client) ListRunArtifacts(ctx context.Context, runID int64) (*model.ArtifactsResponse, error) {
+	endpoint := c.repoPath(fmt.Sprintf("actions/runs/%d/artifacts", runID))
+	res, err := paginate[model.Artifact](ctx, c, endpoint, "artifacts", ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts for run %d: %w", runID, err)
+	}
+	return &model.ArtifactsResponse{TotalCount: res.TotalCount, Artifacts: res.Items}, nil
+}
+
+// DownloadArtifact downloads the zip archive for an artifact.
+// Like log downloads, GitHub answers with a 302 to a short-lived URL.
+func (c *Client) DownloadArtifact(ctx context.Context, artifactID int64) (io.ReadCloser, error) {
+	return c.downloadArchive(ctx, fmt.Sprintf("repos/%s/%s/actions/artifacts/%d/zip", c.owner, c.repo, artifactID))
+}
+
+// DeleteArtifact deletes an artifact by ID.
+func (c *Client) DeleteArtifact(ctx context.Context, artifactID int64) error {
+	return c.Delete(ctx, fmt.Sprintf("actions/artifacts/%d", artifactID))
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestListArtifacts(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("name"); got != "coverage report" {
+			t.Errorf("name = %q, want %q", got, "coverage report")
+		}
+		io.WriteString(w, `{"total_count":2,"artifacts":[
+			{"id":1,"name":"coverage report","size_in_bytes":2048,"workflow_run":{"id":7,"head_branch":"main"}},
+			{"id":2,"name":"coverage report","expired":true}
+		]}`)
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	resp, err := client.ListArtifacts(context.Background(), "coverage report", ListOptions{})
+	if err != nil {
+		t.Fatalf("ListArtifacts() error = %v", err)
+	}
+	if resp.TotalCount != 2 || len(resp.Artifacts) != 2 {
+		t.Fatalf("ListArtifacts() = %d of %d artifacts, want 2 of 2", len(resp.Artifacts), resp.TotalCount)
+	}
+	if a := resp.Artifacts[0]; a.ID != 1 || a.SizeInBytes != 2048 || a.WorkflowRun.HeadBranch != "main" {
+		t.Errorf("first artifact = %+v", a)
+	}
+	if !resp.Artifacts[1].Expired {
+		t.Errorf("second artifact not expired")
+	}
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/artifacts/5/zip", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/storage/artifact-5.zip?sig=abc", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/artifact-5.zip", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("storage request must not carry the API token")
+		}
+		io.WriteString(w, "PK\x03\x04zip")
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	body, err := client.DownloadArtifact(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("DownloadArtifact() error = %v", err)
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	if string(data) != "PK\x03\x04zip" {
+		t.Errorf("DownloadArtifact() = %q", data)
+	}
+}
+
+func TestDeleteArtifact(t *testing.T) {
+	var deleted bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/artifacts/5", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %s, want DELETE", r.Method)
+		}
+		deleted = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	if err := client.DeleteArtifact(context.Background(), 5); err != nil {
+		t.Fatalf("DeleteArtifact() error = %v", err)
+	}
+	if !deleted {
+		t.Errorf("DeleteArtifact() sent no request")
+	}
+}
@@ -1,34 +1,33 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/url"
-	"strconv"
 
 	"github.com/altinukshini/gha-tui/internal/model"
 )
 
-// ListActionsCaches returns GitHub Actions caches for the repository.
-func (c *Client) ListActionsCaches(perPage, page int, sort, direction string) (*model.ActionsCacheList, error) {
+// ListActionsCaches returns GitHub Actions caches for the repository across
+// all pages, up to opts.MaxItems.
+func (c *Client) ListActionsCaches(ctx context.Context, sort, direction string, opts ListOptions) (*model.ActionsCacheList, error) {
 	v := url.Values{}
-	v.Set("per_page", strconv.Itoa(perPage))
-	v.Set("page", strconv.Itoa(page))
 	if sort != "" {
 		v.Set("sort", sort)
 	}
 	if direction != "" {
 		v.Set("direction", direction)
 	}
-	endpoint := fmt.Sprintf("actions/caches?%s", v.Encode())
-	var resp model.ActionsCacheList
-	if err := c.Get(endpoint, &resp); err != nil {
+	endpoint := c.repoPath("actions/caches?" + v.Encode())
+	res, err := paginate[model.ActionsCache](ctx, c, endpoint, "actions_caches", opts)
+	if err != nil {
 		return nil, fmt.Errorf("list actions caches: %w", err)
 	}
-	return &resp, nil
+	return &model.ActionsCacheList{TotalCount: res.TotalCount, ActionsCaches: res.Items}, nil
 }
 
 // DeleteActionsCache deletes a GitHub Actions cache by ID.
-func (c *Client) DeleteActionsCache(cacheID int64) error {
+func (c *Client) DeleteActionsCache(ctx context.Context, cacheID int64) error {
 	endpoint := fmt.Sprintf("actions/caches/%d", cacheID)
-	return c.Delete(endpoint)
+	return c.Delete(ctx, endpoint)
 }
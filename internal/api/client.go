@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,12 +11,33 @@ import (
 	"strconv"
 
 	ghAPI "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
 )
 
 type Client struct {
-	rest  *ghAPI.RESTClient
-	owner string
-	repo  string
+	rest    *ghAPI.RESTClient
+	http    *http.Client // same auth and transport as rest, used for downloads
+	storage *http.Client // unauthenticated, for following download redirects
+	rate    *RateTracker
+	host    string
+	owner   string
+	repo    string
+}
+
+// Options configures a Client. Zero values fall back to the gh CLI's
+// configuration (GH_HOST, gh auth token).
+type Options struct {
+	// Host is the GitHub hostname: github.com or a GitHub Enterprise Server host.
+	Host string
+	// AuthToken overrides the token stored by gh auth.
+	AuthToken string
+	// Transport replaces the underlying HTTP transport, e.g. for tests.
+	Transport http.RoundTripper
+	// Retry overrides DefaultRetryPolicy.
+	Retry *RetryPolicy
+	// RateTracker is shared with other clients using the same token so they
+	// pace themselves against one budget. Nil gives the client its own.
+	RateTracker *RateTracker
 }
 
 type RateLimit struct {
@@ -24,48 +46,123 @@ type RateLimit struct {
 	Reset     int64
 }
 
-func NewClient(owner, repo string) (*Client, error) {
-	rest, err := ghAPI.DefaultRESTClient()
+func NewClient(owner, repo string, opts Options) (*Client, error) {
+	host := opts.Host
+	if host == "" {
+		host, _ = auth.DefaultHost()
+	}
+	base := opts.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	policy := DefaultRetryPolicy()
+	if opts.Retry != nil {
+		policy = *opts.Retry
+	}
+
+	tracker := opts.RateTracker
+	if tracker == nil {
+		tracker = NewRateTracker()
+	}
+	ghOpts := ghAPI.ClientOptions{
+		Host:      host,
+		AuthToken: opts.AuthToken,
+		Transport: &etagTransport{
+			base:  newRetryTransport(&rateTransport{base: base, tracker: tracker}, policy),
+			cache: newETagCache(etagCacheBytes),
+		},
+	}
+	rest, err := ghAPI.NewRESTClient(ghOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitHub client for %s (is gh authenticated?): %w", host, err)
+	}
+	httpClient, err := ghAPI.NewHTTPClient(ghOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GitHub client (is gh authenticated?): %w", err)
+		return nil, fmt.Errorf("failed to create GitHub client for %s (is gh authenticated?): %w", host, err)
+	}
+	return &Client{
+		rest:    rest,
+		http:    httpClient,
+		storage: &http.Client{Transport: newRetryTransport(base, policy)},
+		rate:    tracker,
+		host:    host,
+		owner:   owner,
+		repo:    repo,
+	}, nil
+}
+
+// Host returns the GitHub hostname the client talks to.
+func (c *Client) Host() string {
+	return c.host
+}
+
+// RepoNWO returns the client's repository as owner/repo.
+func (c *Client) RepoNWO() string {
+	return c.owner + "/" + c.repo
+}
+
+// apiURL returns the absolute REST API URL for a path, using the same rules
+// as gh: api.github.com for github.com and /api/v3 on Enterprise Server.
+func (c *Client) apiURL(path string) string {
+	host := auth.NormalizeHostname(c.host)
+	if auth.IsEnterprise(host) {
+		return fmt.Sprintf("https://%s/api/v3/%s", c.host, path)
 	}
-	return &Client{rest: rest, owner: owner, repo: repo}, nil
+	return fmt.Sprintf("https://api.%s/%s", host, path)
+}
+
+// WebURL returns the browser URL for a path relative to the repository,
+// e.g. WebURL("actions") or WebURL("compare/a...b").
+func (c *Client) WebURL(path string) string {
+	host := c.host
+	if host == "" {
+		host = "github.com"
+	}
+	u := fmt.Sprintf("https://%s/%s/%s", host, c.owner, c.repo)
+	if path != "" {
+		u += "/" + path
+	}
+	return u
+}
+
+// RateLimit returns the tracker fed by every response this client receives.
+func (c *Client) RateLimit() *RateTracker {
+	return c.rate
 }
 
 // CheckRepo verifies the repository exists and is accessible.
-func (c *Client) CheckRepo() error {
+func (c *Client) CheckRepo(ctx context.Context) error {
 	var result struct {
 		FullName string `json:"full_name"`
 	}
-	err := c.rest.Get(fmt.Sprintf("repos/%s/%s", c.owner, c.repo), &result)
-	if err != nil {
-		var httpErr *ghAPI.HTTPError
-		if errors.As(err, &httpErr) {
-			switch httpErr.StatusCode {
-			case 403:
-				return fmt.Errorf("access denied for %s/%s — API rate limit may be exceeded or token lacks permissions (run: gh auth status)", c.owner, c.repo)
-			case 404:
-				return fmt.Errorf("repository %s/%s not found — check the name or token permissions (run: gh auth status)", c.owner, c.repo)
-			case 401:
-				return fmt.Errorf("authentication failed — run: gh auth login")
-			default:
-				return fmt.Errorf("repository %s/%s: HTTP %d — %s", c.owner, c.repo, httpErr.StatusCode, httpErr.Message)
-			}
-		}
+	err := wrapError(c.rest.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("repos/%s/%s", c.owner, c.repo), nil, &result))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrUnauthorized):
+		return fmt.Errorf("authentication failed — run: gh auth login: %w", err)
+	case errors.Is(err, ErrRateLimited):
+		reset, _ := RateLimitReset(err)
+		return fmt.Errorf("API rate limit exceeded, resets at %s: %w", reset.Local().Format("15:04"), err)
+	case errors.Is(err, ErrForbidden):
+		return fmt.Errorf("access denied for %s/%s — token lacks permissions (run: gh auth status): %w", c.owner, c.repo, err)
+	case errors.Is(err, ErrNotFound):
+		return fmt.Errorf("repository %s/%s not found — check the name or token permissions (run: gh auth status): %w", c.owner, c.repo, err)
+	default:
 		return fmt.Errorf("repository %s/%s not accessible: %w", c.owner, c.repo, err)
 	}
-	return nil
 }
 
 func (c *Client) repoPath(path string) string {
 	return fmt.Sprintf("repos/%s/%s/%s", c.owner, c.repo, path)
 }
 
-func (c *Client) Get(path string, result interface{}) error {
-	return c.rest.Get(c.repoPath(path), result)
+func (c *Client) Get(ctx context.Context, path string, result interface{}) error {
+	return wrapError(c.rest.DoWithContext(ctx, http.MethodGet, c.repoPath(path), nil, result))
 }
 
-func (c *Client) Post(path string, body interface{}, result interface{}) error {
+func (c *Client) Post(ctx context.Context, path string, body interface{}, result interface{}) error {
 	var reader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -74,14 +171,14 @@ func (c *Client) Post(path string, body interface{}, result interface{}) error {
 		}
 		reader = bytes.NewReader(data)
 	}
-	return c.rest.Post(c.repoPath(path), reader, result)
+	return wrapError(c.rest.DoWithContext(ctx, http.MethodPost, c.repoPath(path), reader, result))
 }
 
-func (c *Client) Delete(path string) error {
-	return c.rest.Delete(c.repoPath(path), nil)
+func (c *Client) Delete(ctx context.Context, path string) error {
+	return wrapError(c.rest.DoWithContext(ctx, http.MethodDelete, c.repoPath(path), nil, nil))
 }
 
-func (c *Client) Put(path string, body interface{}, result interface{}) error {
+func (c *Client) Put(ctx context.Context, path string, body interface{}, result interface{}) error {
 	var reader io.Reader
 	if body != nil {
 		data, err := json.Marshal(body)
@@ -90,14 +187,14 @@ func (c *Client) Put(path string, body interface{}, result interface{}) error {
 		}
 		reader = bytes.NewReader(data)
 	}
-	return c.rest.Put(c.repoPath(path), reader, result)
+	return wrapError(c.rest.DoWithContext(ctx, http.MethodPut, c.repoPath(path), reader, result))
 }
 
 // RawRequest issues a raw HTTP request and returns the response without
 // error handling for non-2xx status codes. Used for log downloads where
 // GitHub returns 302 redirects.
-func (c *Client) RawRequest(method, path string, body io.Reader) (*http.Response, error) {
-	return c.rest.Request(method, c.repoPath(path), body)
+func (c *Client) RawRequest(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	return c.rest.RequestWithContext(ctx, method, c.repoPath(path), body)
 }
 
 func ParseRateLimit(resp *http.Response) RateLimit {
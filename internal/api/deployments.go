@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// ListPendingDeployments returns the environments a waiting run is blocked
+// on, with their reviewers and whether the current user may approve them.
+func (c *Client) ListPendingDeployments(ctx context.Context, runID int64) ([]model.PendingDeployment, error) {
+	var deployments []model.PendingDeployment
+	if err := c.Get(ctx, fmt.Sprintf("actions/runs/%d/pending_deployments", runID), &deployments); err != nil {
+		return nil, fmt.Errorf("list pending deployments for run %d: %w", runID, err)
+	}
+	return deployments, nil
+}
+
+type reviewRequest struct {
+	EnvironmentIDs []int64                     `json:"environment_ids"`
+	State          model.DeploymentReviewState `json:"state"`
+	Comment        string                      `json:"comment"`
+}
+
+// ReviewPendingDeployments approves or rejects a waiting run's deployments
+// to the given environments.
+func (c *Client) ReviewPendingDeployments(ctx context.Context, runID int64, environmentIDs []int64, state model.DeploymentReviewState, comment string) error {
+	body := reviewRequest{EnvironmentIDs: environmentIDs, State: state, Comment: comment}
+	return c.Post(ctx, fmt.Sprintf("actions/runs/%d/pending_deployments", runID), body, nil)
+}
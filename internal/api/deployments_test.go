@@ -0,0 +1,64 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func TestPendingDeployments(t *testing.T) {
+	var review reviewRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/runs/5/pending_deployments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `[{
+				"environment": {"id": 11, "name": "production"},
+				"wait_timer": 30,
+				"current_user_can_approve": true,
+				"reviewers": [
+					{"type": "User", "reviewer": {"login": "alice"}},
+					{"type": "Team", "reviewer": {"name": "Ops", "slug": "ops"}}
+				]
+			}]`)
+		case http.MethodPost:
+			if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+				t.Errorf("decode review: %v", err)
+			}
+			io.WriteString(w, `[]`)
+		}
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	deployments, err := client.ListPendingDeployments(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("ListPendingDeployments() error = %v", err)
+	}
+	if len(deployments) != 1 {
+		t.Fatalf("got %d deployments, want 1", len(deployments))
+	}
+	d := deployments[0]
+	if d.Environment.Name != "production" || d.WaitTimer != 30 || !d.CurrentUserCanApprove {
+		t.Errorf("deployment = %+v", d)
+	}
+	var names []string
+	for _, r := range d.Reviewers {
+		names = append(names, r.DisplayName())
+	}
+	if want := []string{"alice", "@ops"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("reviewers = %v, want %v", names, want)
+	}
+
+	if err := client.ReviewPendingDeployments(context.Background(), 5, []int64{11}, model.DeploymentRejected, "not today"); err != nil {
+		t.Fatalf("ReviewPendingDeployments() error = %v", err)
+	}
+	want := reviewRequest{EnvironmentIDs: []int64{11}, State: model.DeploymentRejected, Comment: "not today"}
+	if !reflect.DeepEqual(review, want) {
+		t.Errorf("review body = %+v, want %+v", review, want)
+	}
+}
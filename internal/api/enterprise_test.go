@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newEnterpriseTestClient starts a TLS server that behaves like a GitHub
+// Enterprise Server instance and returns a client pointed at it.
+func newEnterpriseTestClient(t *testing.T, handler http.Handler) (*Client, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewTLSServer(handler)
+	t.Cleanup(srv.Close)
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+	client, err := NewClient("octo", "app", Options{
+		Host:      host,
+		AuthToken: "test-token",
+		Transport: srv.Client().Transport,
+		Retry:     &RetryPolicy{}, // keep error tests fast
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client, srv
+}
+
+func TestEnterpriseHost(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"total_count":1,"workflow_runs":[{"id":7}]}`)
+	})
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/jobs/9/logs", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/storage/job-9.txt?sig=abc", http.StatusFound)
+	})
+	mux.HandleFunc("/storage/job-9.txt", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("storage request must not carry the API token")
+		}
+		io.WriteString(w, "job log")
+	})
+	client, srv := newEnterpriseTestClient(t, mux)
+
+	resp, err := client.ListRuns(context.Background(), RunsFilter{})
+	if err != nil {
+		t.Fatalf("ListRuns() error = %v", err)
+	}
+	if len(resp.Runs) != 1 || resp.Runs[0].ID != 7 {
+		t.Errorf("ListRuns() = %+v", resp.Runs)
+	}
+
+	body, err := client.DownloadJobLog(context.Background(), 9)
+	if err != nil {
+		t.Fatalf("DownloadJobLog() error = %v", err)
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	if string(data) != "job log" {
+		t.Errorf("DownloadJobLog() = %q", data)
+	}
+
+	host := strings.TrimPrefix(srv.URL, "https://")
+	if got, want := client.WebURL("actions"), fmt.Sprintf("https://%s/octo/app/actions", host); got != want {
+		t.Errorf("WebURL() = %q, want %q", got, want)
+	}
+}
+
+func TestAPIURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{"github.com", "https://api.github.com/repos/o/r"},
+		{"ghes.example.com", "https://ghes.example.com/api/v3/repos/o/r"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			c := &Client{host: tt.host}
+			if got := c.apiURL("repos/o/r"); got != tt.want {
+				t.Errorf("apiURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
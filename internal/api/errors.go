@@ -0,0 +1,132 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ghAPI "github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Error kinds returned by Client methods. Test for them with errors.Is; use
+// errors.As with *Error for the status code, message and rate-limit reset.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrForbidden    = errors.New("forbidden")
+	ErrRateLimited  = errors.New("rate limit exceeded")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrServer       = errors.New("server error")
+)
+
+// secondaryRateLimitWait is the back-off GitHub recommends when a secondary
+// rate limit response carries neither Retry-After nor a reset time.
+const secondaryRateLimitWait = time.Minute
+
+// Error is a non-2xx response from the GitHub API.
+type Error struct {
+	StatusCode int
+	Message    string
+	URL        string
+	// Reset is when requests may resume. Only set for ErrRateLimited.
+	Reset time.Time
+
+	kind error // one of the Err* sentinels, or nil for other statuses
+	err  error // the underlying go-gh error, if any
+}
+
+func (e *Error) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("HTTP %d: %s (%s)", e.StatusCode, e.Message, e.URL)
+	}
+	return fmt.Sprintf("HTTP %d (%s)", e.StatusCode, e.URL)
+}
+
+// Unwrap exposes both the error kind and the original go-gh error.
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if e.kind != nil {
+		errs = append(errs, e.kind)
+	}
+	if e.err != nil {
+		errs = append(errs, e.err)
+	}
+	return errs
+}
+
+// RateLimitReset reports when a rate-limited request may be retried.
+func RateLimitReset(err error) (time.Time, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) && errors.Is(apiErr.kind, ErrRateLimited) {
+		return apiErr.Reset, true
+	}
+	return time.Time{}, false
+}
+
+// wrapError converts go-gh HTTP errors into *Error. Other errors (network
+// failures, JSON decoding) are returned unchanged.
+func wrapError(err error) error {
+	var httpErr *ghAPI.HTTPError
+	if err == nil || !errors.As(err, &httpErr) {
+		return err
+	}
+	e := newError(httpErr.StatusCode, httpErr.Headers, httpErr.Message, time.Now())
+	if httpErr.RequestURL != nil {
+		e.URL = httpErr.RequestURL.String()
+	}
+	e.err = err
+	return e
+}
+
+// responseError builds an *Error from a response whose body is not decoded
+// by go-gh, such as log and artifact downloads. It closes the body.
+func responseError(resp *http.Response) error {
+	defer resp.Body.Close()
+	return wrapError(ghAPI.HandleHTTPError(resp))
+}
+
+func newError(status int, h http.Header, message string, now time.Time) *Error {
+	e := &Error{StatusCode: status, Message: message}
+	switch {
+	case status == http.StatusUnauthorized:
+		e.kind = ErrUnauthorized
+	case status == http.StatusTooManyRequests, status == http.StatusForbidden && isRateLimited(h, message):
+		e.kind = ErrRateLimited
+		e.Reset = rateLimitReset(h, now)
+	case status == http.StatusForbidden:
+		e.kind = ErrForbidden
+	case status == http.StatusNotFound:
+		e.kind = ErrNotFound
+	case status >= 500:
+		e.kind = ErrServer
+	}
+	return e
+}
+
+// isRateLimited tells a 403 caused by the primary or a secondary rate limit
+// apart from a permissions problem.
+func isRateLimited(h http.Header, message string) bool {
+	if h.Get("X-RateLimit-Remaining") == "0" || h.Get("Retry-After") != "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(message), "rate limit")
+}
+
+// rateLimitReset prefers Retry-After, then X-RateLimit-Reset, then the
+// documented one-minute back-off.
+func rateLimitReset(h http.Header, now time.Time) time.Time {
+	if secs, err := strconv.Atoi(h.Get("Retry-After")); err == nil {
+		return now.Add(time.Duration(secs) * time.Second)
+	}
+	if h.Get("X-RateLimit-Remaining") == "0" {
+		if unix, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return time.Unix(unix, 0)
+		}
+	}
+	return now.Add(secondaryRateLimitWait)
+}
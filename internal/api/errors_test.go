@@ -0,0 +1,121 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewErrorKind(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	reset := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name      string
+		status    int
+		header    http.Header
+		message   string
+		want      error
+		wantReset time.Time
+	}{
+		{name: "unauthorized", status: 401, want: ErrUnauthorized},
+		{name: "forbidden", status: 403, header: http.Header{"X-Ratelimit-Remaining": {"4000"}}, message: "Resource not accessible by integration", want: ErrForbidden},
+		{
+			name:      "primary rate limit",
+			status:    403,
+			header:    http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {strconv.FormatInt(reset.Unix(), 10)}},
+			message:   "API rate limit exceeded for user ID 1.",
+			want:      ErrRateLimited,
+			wantReset: reset,
+		},
+		{
+			name:      "secondary rate limit with retry-after",
+			status:    403,
+			header:    http.Header{"Retry-After": {"30"}},
+			message:   "You have exceeded a secondary rate limit.",
+			want:      ErrRateLimited,
+			wantReset: now.Add(30 * time.Second),
+		},
+		{
+			name:      "secondary rate limit without headers",
+			status:    403,
+			message:   "You have exceeded a secondary rate limit.",
+			want:      ErrRateLimited,
+			wantReset: now.Add(time.Minute),
+		},
+		{name: "too many requests", status: 429, header: http.Header{"Retry-After": {"5"}}, want: ErrRateLimited, wantReset: now.Add(5 * time.Second)},
+		{name: "not found", status: 404, want: ErrNotFound},
+		{name: "bad gateway", status: 502, want: ErrServer},
+		{name: "unprocessable", status: 422, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := tt.header
+			if h == nil {
+				h = http.Header{}
+			}
+			e := newError(tt.status, h, tt.message, now)
+			if e.kind != tt.want {
+				t.Errorf("kind = %v, want %v", e.kind, tt.want)
+			}
+			if !e.Reset.Equal(tt.wantReset) {
+				t.Errorf("Reset = %v, want %v", e.Reset, tt.wantReset)
+			}
+		})
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"message":"Not Found"}`)
+	})
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/runs/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1700000600")
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, `{"message":"API rate limit exceeded"}`)
+	})
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/jobs/2/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	t.Run("list runs treats 404 as empty", func(t *testing.T) {
+		resp, err := client.ListRuns(t.Context(), RunsFilter{})
+		if err != nil {
+			t.Fatalf("ListRuns() error = %v", err)
+		}
+		if len(resp.Runs) != 0 {
+			t.Errorf("got %d runs, want 0", len(resp.Runs))
+		}
+	})
+
+	t.Run("rate limited 403", func(t *testing.T) {
+		_, err := client.GetRun(t.Context(), 1)
+		if !errors.Is(err, ErrRateLimited) || errors.Is(err, ErrForbidden) {
+			t.Fatalf("GetRun() error = %v, want ErrRateLimited", err)
+		}
+		reset, ok := RateLimitReset(err)
+		if !ok || reset.Unix() != 1700000600 {
+			t.Errorf("RateLimitReset() = %v, %v", reset, ok)
+		}
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != 403 {
+			t.Errorf("errors.As(*Error) = %+v", apiErr)
+		}
+	})
+
+	t.Run("download server error", func(t *testing.T) {
+		_, err := client.DownloadJobLog(t.Context(), 2)
+		if !errors.Is(err, ErrServer) {
+			t.Errorf("DownloadJobLog() error = %v, want ErrServer", err)
+		}
+	})
+}
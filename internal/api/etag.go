@@ -0,0 +1,154 @@
+package api
+
+import (
+	"bytes"
+	"container/list"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const (
+	// etagCacheBytes bounds the memory held by remembered responses.
+	etagCacheBytes = 64 << 20
+	// etagMaxBody skips caching unusually large responses.
+	etagMaxBody = 4 << 20
+)
+
+type etagEntry struct {
+	key    string
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// size approximates the memory an entry holds.
+func (e *etagEntry) size() int {
+	n := len(e.key) + len(e.etag) + len(e.body)
+	for k, vs := range e.header {
+		n += len(k)
+		for _, v := range vs {
+			n += len(v)
+		}
+	}
+	return n
+}
+
+// etagCache is an LRU of GET responses keyed by URL and Accept header,
+// holding at most maxBytes of them.
+type etagCache struct {
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	order    *list.List // front = most recently used
+	bytes    int
+	maxBytes int
+}
+
+func newETagCache(maxBytes int) *etagCache {
+	return &etagCache{entries: make(map[string]*list.Element), order: list.New(), maxBytes: maxBytes}
+}
+
+func (c *etagCache) get(key string) (*etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*etagEntry), true
+}
+
+func (c *etagCache) put(e *etagEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[e.key]; ok {
+		c.bytes -= el.Value.(*etagEntry).size()
+		el.Value = e
+		c.order.MoveToFront(el)
+	} else {
+		c.entries[e.key] = c.order.PushFront(e)
+	}
+	c.bytes += e.size()
+	for c.bytes > c.maxBytes {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*etagEntry).key)
+		c.bytes -= oldest.Value.(*etagEntry).size()
+	}
+}
+
+// etagTransport makes GETs conditional. Responses carrying an ETag are
+// remembered; later requests for the same endpoint send If-None-Match and a
+// 304 Not Modified is answered from the stored body. GitHub does not count
+// 304s against the rate limit, which makes the polling loops nearly free.
+type etagTransport struct {
+	base  http.RoundTripper
+	cache *etagCache
+}
+
+func (t *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("Range") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String() + "|" + req.Header.Get("Accept")
+	cached, ok := t.cache.get(key)
+	if ok {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		header := cached.header.Clone()
+		// Keep the fresh rate-limit and date headers from the 304.
+		for k, v := range resp.Header {
+			if k != "Content-Length" {
+				header[k] = v
+			}
+		}
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(cached.body)),
+			ContentLength: int64(len(cached.body)),
+			Request:       req,
+		}, nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+	if resp.ContentLength > etagMaxBody {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, etagMaxBody+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if len(body) > etagMaxBody {
+		// Too large to cache; hand back what was read plus the remainder.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+		return resp, nil
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	t.cache.put(&etagEntry{key: key, etag: etag, header: resp.Header.Clone(), body: body})
+	return resp, nil
+}
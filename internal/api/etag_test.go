@@ -0,0 +1,95 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestETagTransport(t *testing.T) {
+	var full, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4000")
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"total_count":1}`)
+	}))
+	defer srv.Close()
+
+	tracker := NewRateTracker()
+	client := &http.Client{Transport: &etagTransport{
+		base:  &rateTransport{base: http.DefaultTransport, tracker: tracker},
+		cache: newETagCache(1 << 20),
+	}}
+
+	get := func() (int, string) {
+		t.Helper()
+		resp, err := client.Get(srv.URL + "/runs?page=1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	for i := 0; i < 3; i++ {
+		status, body := get()
+		if status != http.StatusOK || body != `{"total_count":1}` {
+			t.Fatalf("request %d: got %d %q", i, status, body)
+		}
+	}
+	if full.Load() != 1 || notModified.Load() != 2 {
+		t.Errorf("full = %d, not modified = %d; want 1 and 2", full.Load(), notModified.Load())
+	}
+	if tracker.Snapshot().Remaining != 4000 {
+		t.Errorf("rate tracker did not see the 304 responses")
+	}
+
+	// Non-GET requests are never conditional.
+	resp, err := client.Post(srv.URL+"/runs?page=1", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if full.Load() != 2 {
+		t.Errorf("POST should bypass the cache")
+	}
+}
+
+func TestETagCacheEviction(t *testing.T) {
+	body := make([]byte, 99) // 100 bytes an entry with its key
+	c := newETagCache(250)
+	c.put(&etagEntry{key: "a", body: body})
+	c.put(&etagEntry{key: "b", body: body})
+	c.get("a") // a is now most recently used
+	c.put(&etagEntry{key: "c", body: body})
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected least recently used entry to be evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.get(k); !ok {
+			t.Errorf("expected %q to be cached", k)
+		}
+	}
+
+	// Replacing an entry accounts for its new size.
+	c.put(&etagEntry{key: "a", body: make([]byte, 199)})
+	if _, ok := c.get("c"); ok {
+		t.Error("expected c to be evicted once a grew")
+	}
+	if c.bytes != 200 {
+		t.Errorf("cache holds %d bytes, want 200", c.bytes)
+	}
+}
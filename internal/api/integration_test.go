@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"os"
 	"testing"
 )
@@ -10,12 +11,12 @@ func TestIntegrationListRuns(t *testing.T) {
 		t.Skip("Set GHA_TUI_INTEGRATION=1 to run integration tests")
 	}
 
-	client, err := NewClient("cli", "cli")
+	client, err := NewClient("cli", "cli", Options{})
 	if err != nil {
 		t.Fatalf("NewClient: %v", err)
 	}
 
-	resp, err := client.ListRuns(RunsFilter{PerPage: 5})
+	resp, err := client.ListRuns(context.Background(), RunsFilter{PerPage: 5})
 	if err != nil {
 		t.Fatalf("ListRuns: %v", err)
 	}
@@ -38,12 +39,12 @@ func TestIntegrationListWorkflows(t *testing.T) {
 		t.Skip("Set GHA_TUI_INTEGRATION=1 to run integration tests")
 	}
 
-	client, err := NewClient("cli", "cli")
+	client, err := NewClient("cli", "cli", Options{})
 	if err != nil {
 		t.Fatalf("NewClient: %v", err)
 	}
 
-	resp, err := client.ListWorkflows(10, 1)
+	resp, err := client.ListWorkflows(context.Background(), ListOptions{MaxItems: 10})
 	if err != nil {
 		t.Fatalf("ListWorkflows: %v", err)
 	}
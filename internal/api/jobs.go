@@ -1,10 +1,11 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
-	"strings"
 
 	"github.com/altinukshini/gha-tui/internal/model"
 )
@@ -34,35 +35,52 @@ func (f JobsFilter) QueryString() string {
 	return ""
 }
 
-func (c *Client) ListJobs(runID int64, filter JobsFilter) (*model.JobsResponse, error) {
-	var resp model.JobsResponse
-	path := fmt.Sprintf("actions/runs/%d/jobs%s", runID, filter.QueryString())
-	err := c.Get(path, &resp)
+// ListJobs returns every job of a run; large matrices span several pages.
+// filter.PerPage sets the page size and filter.Page is ignored.
+func (c *Client) ListJobs(ctx context.Context, runID int64, filter JobsFilter) (*model.JobsResponse, error) {
+	path := c.repoPath(fmt.Sprintf("actions/runs/%d/jobs%s", runID, filter.QueryString()))
+	res, err := paginate[model.Job](ctx, c, path, "jobs", ListOptions{PerPage: filter.PerPage})
 	if err != nil {
 		// Run may have been deleted — treat 404 as empty
-		if strings.Contains(err.Error(), "404") {
+		if errors.Is(err, ErrNotFound) {
 			return &model.JobsResponse{}, nil
 		}
 		return nil, fmt.Errorf("list jobs for run %d: %w", runID, err)
 	}
-	return &resp, nil
+	return &model.JobsResponse{TotalCount: res.TotalCount, Jobs: res.Items}, nil
 }
 
-func (c *Client) ListJobsForAttempt(runID int64, attempt int, filter JobsFilter) (*model.JobsResponse, error) {
-	var resp model.JobsResponse
-	path := fmt.Sprintf("actions/runs/%d/attempts/%d/jobs%s", runID, attempt, filter.QueryString())
-	err := c.Get(path, &resp)
+// ListJobsForAttempt is ListJobs for a specific run attempt.
+func (c *Client) ListJobsForAttempt(ctx context.Context, runID int64, attempt int, filter JobsFilter) (*model.JobsResponse, error) {
+	path := c.repoPath(fmt.Sprintf("actions/runs/%d/attempts/%d/jobs%s", runID, attempt, filter.QueryString()))
+	res, err := paginate[model.Job](ctx, c, path, "jobs", ListOptions{PerPage: filter.PerPage})
 	if err != nil {
+		// Run may have been deleted — treat 404 as empty
+		if errors.Is(err, ErrNotFound) {
+			return &model.JobsResponse{}, nil
+		}
 		return nil, fmt.Errorf("list jobs for run %d attempt %d: %w", runID, attempt, err)
 	}
-	return &resp, nil
+	return &model.JobsResponse{TotalCount: res.TotalCount, Jobs: res.Items}, nil
 }
 
-func (c *Client) GetJob(jobID int64) (*model.Job, error) {
+func (c *Client) GetJob(ctx context.Context, jobID int64) (*model.Job, error) {
 	var job model.Job
-	err := c.Get(fmt.Sprintf("actions/jobs/%d", jobID), &job)
+	err := c.Get(ctx, fmt.Sprintf("actions/jobs/%d", jobID), &job)
 	if err != nil {
 		return nil, fmt.Errorf("get job %d: %w", jobID, err)
 	}
 	return &job, nil
 }
+
+// ListJobAnnotations returns the annotations (errors and warnings raised
+// with ::error and friends) recorded on a job's check run, which shares the
+// job's ID.
+func (c *Client) ListJobAnnotations(ctx context.Context, jobID int64) ([]model.Annotation, error) {
+	path := c.repoPath(fmt.Sprintf("check-runs/%d/annotations", jobID))
+	res, err := paginate[model.Annotation](ctx, c, path, "", ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("list annotations for job %d: %w", jobID, err)
+	}
+	return res.Items, nil
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestListJobAnnotations(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/check-runs/42/annotations", func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[
+			{"path": "main.go", "start_line": 12, "annotation_level": "failure", "title": "lint", "message": "unused variable x\nsecond line"},
+			{"path": ".github", "start_line": 1, "annotation_level": "failure", "message": "Process completed with exit code 1."}
+		]`)
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	annotations, err := client.ListJobAnnotations(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("ListJobAnnotations() error = %v", err)
+	}
+	if len(annotations) != 2 {
+		t.Fatalf("got %d annotations, want 2", len(annotations))
+	}
+	if got := annotations[0].Location(); got != "main.go:12" {
+		t.Errorf("Location() = %q, want main.go:12", got)
+	}
+	if got := annotations[0].Summary(); got != "unused variable x" {
+		t.Errorf("Summary() = %q", got)
+	}
+	if got := annotations[1].Location(); got != "" {
+		t.Errorf("job-level Location() = %q, want empty", got)
+	}
+}
+
+func TestListJobsForAttemptOfDeletedRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/runs/7/attempts/2/jobs", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	resp, err := client.ListJobsForAttempt(context.Background(), 7, 2, JobsFilter{})
+	if err != nil {
+		t.Fatalf("ListJobsForAttempt() error = %v", err)
+	}
+	if len(resp.Jobs) != 0 {
+		t.Errorf("ListJobsForAttempt() = %+v, want no jobs", resp.Jobs)
+	}
+}
+
+func TestListJobAnnotationsFollowsPages(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/check-runs/42/annotations", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			io.WriteString(w, `[{"path": "c.go", "start_line": 3, "annotation_level": "warning", "message": "c"}]`)
+			return
+		}
+		next := "https://" + r.Host + r.URL.Path + "?per_page=100&page=2"
+		w.Header().Set("Link", `<`+next+`>; rel="next", <`+next+`>; rel="last"`)
+		io.WriteString(w, `[
+			{"path": "a.go", "start_line": 1, "annotation_level": "failure", "message": "a"},
+			{"path": "b.go", "start_line": 2, "annotation_level": "failure", "message": "b"}
+		]`)
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	annotations, err := client.ListJobAnnotations(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("ListJobAnnotations() error = %v", err)
+	}
+	if len(annotations) != 3 || annotations[2].Location() != "c.go:3" {
+		t.Errorf("ListJobAnnotations() = %+v, want both pages", annotations)
+	}
+}
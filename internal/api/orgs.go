@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// ForRepo returns a client for another repository that shares this
+// client's connection, ETag cache and rate-limit budget.
+func (c *Client) ForRepo(owner, repo string) *Client {
+	cp := *c
+	cp.owner, cp.repo = owner, repo
+	return &cp
+}
+
+// ListOrgRepos returns the repositories of the client's owner, which must be
+// an organization, sorted by name.
+func (c *Client) ListOrgRepos(ctx context.Context, opts ListOptions) (*model.ReposResponse, error) {
+	endpoint := fmt.Sprintf("orgs/%s/repos?sort=full_name", c.owner)
+	res, err := paginate[model.Repository](ctx, c, endpoint, "", opts)
+	if err != nil {
+		return nil, fmt.Errorf("list org repos: %w", err)
+	}
+	return &model.ReposResponse{TotalCount: res.TotalCount, Repositories: res.Items, Truncated: res.Truncated}, nil
+}
+
+// ListOrgCacheUsage returns the Actions cache usage of each repository in
+// the client's organization. Repositories without caches are left out.
+func (c *Client) ListOrgCacheUsage(ctx context.Context, opts ListOptions) (*model.RepoCacheUsageResponse, error) {
+	endpoint := fmt.Sprintf("orgs/%s/actions/cache/usage-by-repository", c.owner)
+	res, err := paginate[model.RepoCacheUsage](ctx, c, endpoint, "repository_cache_usages", opts)
+	if err != nil {
+		return nil, fmt.Errorf("list org cache usage: %w", err)
+	}
+	return &model.RepoCacheUsageResponse{TotalCount: res.TotalCount, Usages: res.Items}, nil
+}
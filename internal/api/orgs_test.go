@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestListOrgReposFollowsArrayPages(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/orgs/octo/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"name":"web","full_name":"octo/web","default_branch":"main","archived":true}]`)
+			return
+		}
+		next := fmt.Sprintf("https://%s%s?per_page=2&page=2", r.Host, r.URL.Path)
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, next, next))
+		fmt.Fprint(w, `[{"name":"api","full_name":"octo/api","default_branch":"main"},
+			{"name":"app","full_name":"octo/app","default_branch":"trunk"}]`)
+	})
+	client, _ := newEnterpriseTestClient(t, mux)
+
+	for _, concurrency := range []int{0, 3} {
+		resp, err := client.ListOrgRepos(context.Background(), ListOptions{PerPage: 2, Concurrency: concurrency})
+		if err != nil {
+			t.Fatalf("ListOrgRepos() error = %v", err)
+		}
+		if resp.TotalCount != 3 || len(resp.Repositories) != 3 {
+			t.Fatalf("got %d repos (total %d), want 3", len(resp.Repositories), resp.TotalCount)
+		}
+		if r := resp.Repositories[1]; r.Name != "app" || r.DefaultBranch != "trunk" {
+			t.Errorf("second repo = %+v", r)
+		}
+		if !resp.Repositories[2].Archived {
+			t.Error("archived flag not decoded")
+		}
+	}
+}
+
+func TestForRepoSharesBudget(t *testing.T) {
+	client, _ := newEnterpriseTestClient(t, http.NotFoundHandler())
+	other := client.ForRepo("octo", "lib")
+	if other.RepoNWO() != "octo/lib" || client.RepoNWO() != "octo/app" {
+		t.Errorf("RepoNWO() = %q and %q", other.RepoNWO(), client.RepoNWO())
+	}
+	if other.RateLimit() != client.RateLimit() {
+		t.Error("ForRepo() client has its own rate tracker")
+	}
+}
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxPerPage is the largest page size GitHub accepts.
+const maxPerPage = 100
+
+// ListOptions bounds a paginated list call. The zero value fetches every
+// page, 100 items at a time, one page after another.
+type ListOptions struct {
+	// PerPage is the page size, at most 100.
+	PerPage int
+	// MaxItems stops pagination once this many items are collected. Zero
+	// means no limit. Compare the result's length with TotalCount to tell
+	// whether it was cut short.
+	MaxItems int
+	// Concurrency fetches up to this many pages at once when the Link
+	// header reveals the last page. Values below 2 fetch sequentially.
+	Concurrency int
+	// OnProgress is called after every page with the number of items
+	// collected so far and the total reported by the API. Calls are
+	// serialized but may come from different goroutines.
+	OnProgress func(fetched, total int)
+}
+
+func (o ListOptions) pageSize() int {
+	n := o.PerPage
+	if n <= 0 || n > maxPerPage {
+		n = maxPerPage
+	}
+	if o.MaxItems > 0 && o.MaxItems < n {
+		n = o.MaxItems
+	}
+	return n
+}
+
+// listResult is the outcome of a paginated list call.
+type listResult[T any] struct {
+	Items      []T
+	TotalCount int
+	// Truncated is set when MaxItems stopped pagination before the end.
+	Truncated bool
+}
+
+// paginate collects the items under key (e.g. "workflow_runs") from a list
+// endpoint, or the whole body for endpoints that return a bare array when
+// key is empty, following the rel="next" Link header from page to page. When
+// opts.Concurrency > 1 and the first page links to the last one, the
+// remaining pages are fetched in parallel and reassembled in order.
+func paginate[T any](ctx context.Context, c *Client, path, key string, opts ListOptions) (*listResult[T], error) {
+	first, err := withPageSize(path, opts.pageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := fetchPage[T](ctx, c, first, key)
+	if err != nil {
+		return nil, err
+	}
+	res := &listResult[T]{TotalCount: p.total}
+	res.add(p.items, opts.MaxItems)
+	opts.progress(len(res.Items), res.TotalCount)
+
+	if opts.Concurrency > 1 && p.last != "" {
+		err = res.fetchConcurrently(ctx, c, p, key, opts)
+	} else {
+		for p.next != "" && !res.full(opts.MaxItems) {
+			if p, err = fetchPage[T](ctx, c, p.next, key); err != nil {
+				break
+			}
+			res.add(p.items, opts.MaxItems)
+			opts.progress(len(res.Items), res.TotalCount)
+		}
+		if err == nil && p.next != "" {
+			res.Truncated = true
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if key == "" {
+		// Bare arrays carry no total_count.
+		res.TotalCount = len(res.Items)
+	}
+	return res, nil
+}
+
+// fetchConcurrently fetches pages 2..last, stopping early at MaxItems.
+func (res *listResult[T]) fetchConcurrently(ctx context.Context, c *Client, first *page[T], key string, opts ListOptions) error {
+	lastURL, err := url.Parse(first.last)
+	if err != nil {
+		return fmt.Errorf("parse Link header: %w", err)
+	}
+	lastPage, _ := strconv.Atoi(lastURL.Query().Get("page"))
+	pages := lastPage
+	if opts.MaxItems > 0 {
+		if limit := (opts.MaxItems + opts.pageSize() - 1) / opts.pageSize(); limit < pages {
+			pages = limit
+			res.Truncated = true
+		}
+	}
+	if pages < 2 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]T, pages+1)
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		fetched  = len(res.Items)
+	)
+	sem := make(chan struct{}, opts.Concurrency)
+	for n := 2; n <= pages; n++ {
+		u := *lastURL
+		q := u.Query()
+		q.Set("page", strconv.Itoa(n))
+		u.RawQuery = q.Encode()
+
+		wg.Add(1)
+		go func(n int, pageURL string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			p, err := fetchPage[T](ctx, c, pageURL, key)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			results[n] = p.items
+			fetched += len(p.items)
+			opts.progress(fetched, res.TotalCount)
+		}(n, u.String())
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	for _, items := range results[2:] {
+		res.add(items, opts.MaxItems)
+	}
+	return nil
+}
+
+func (res *listResult[T]) add(items []T, max int) {
+	if max > 0 && len(res.Items)+len(items) > max {
+		items = items[:max-len(res.Items)]
+		res.Truncated = true
+	}
+	res.Items = append(res.Items, items...)
+}
+
+func (res *listResult[T]) full(max int) bool {
+	return max > 0 && len(res.Items) >= max
+}
+
+func (o ListOptions) progress(fetched, total int) {
+	if o.OnProgress != nil {
+		o.OnProgress(fetched, total)
+	}
+}
+
+// page is one decoded page of a list response.
+type page[T any] struct {
+	items      []T
+	total      int
+	next, last string
+}
+
+func fetchPage[T any](ctx context.Context, c *Client, pathOrURL, key string) (*page[T], error) {
+	resp, err := c.rest.RequestWithContext(ctx, http.MethodGet, pathOrURL, nil)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	defer resp.Body.Close()
+
+	p := &page[T]{}
+	p.next, p.last = parseLink(resp.Header.Get("Link"))
+	if key == "" {
+		if err := json.NewDecoder(resp.Body).Decode(&p.items); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", pathOrURL, err)
+		}
+		return p, nil
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", pathOrURL, err)
+	}
+	if raw, ok := body[key]; ok {
+		if err := json.Unmarshal(raw, &p.items); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", key, err)
+		}
+	}
+	if raw, ok := body["total_count"]; ok {
+		json.Unmarshal(raw, &p.total)
+	}
+	return p, nil
+}
+
+// parseLink extracts the next and last URLs from a Link header such as
+// `<https://api.github.com/...&page=2>; rel="next", <...&page=9>; rel="last"`.
+func parseLink(header string) (next, last string) {
+	for _, part := range strings.Split(header, ",") {
+		segs := strings.Split(part, ";")
+		if len(segs) < 2 {
+			continue
+		}
+		u := strings.Trim(strings.TrimSpace(segs[0]), "<>")
+		for _, attr := range segs[1:] {
+			switch strings.TrimSpace(attr) {
+			case `rel="next"`:
+				next = u
+			case `rel="last"`:
+				last = u
+			}
+		}
+	}
+	return next, last
+}
+
+// withPageSize sets per_page on an API path and drops any page parameter so
+// pagination starts at the first page.
+func withPageSize(path string, perPage int) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("parse path %q: %w", path, err)
+	}
+	q := u.Query()
+	q.Set("per_page", strconv.Itoa(perPage))
+	q.Del("page")
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseLink(t *testing.T) {
+	tests := []struct {
+		name               string
+		header             string
+		wantNext, wantLast string
+	}{
+		{"empty", "", "", ""},
+		{
+			"next and last",
+			`<https://api.github.com/r?page=2>; rel="next", <https://api.github.com/r?page=5>; rel="last"`,
+			"https://api.github.com/r?page=2", "https://api.github.com/r?page=5",
+		},
+		{
+			"last page",
+			`<https://api.github.com/r?page=1>; rel="first", <https://api.github.com/r?page=4>; rel="prev"`,
+			"", "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, last := parseLink(tt.header)
+			if next != tt.wantNext || last != tt.wantLast {
+				t.Errorf("parseLink() = %q, %q, want %q, %q", next, last, tt.wantNext, tt.wantLast)
+			}
+		})
+	}
+}
+
+// pagedWorkflows serves total workflows with IDs 1..total, perPage at a time,
+// linking pages the way GitHub does.
+func pagedWorkflows(total int) (http.Handler, func() []int) {
+	var (
+		mu    sync.Mutex
+		pages []int
+	)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		perPage, _ := strconv.Atoi(q.Get("per_page"))
+		p, _ := strconv.Atoi(q.Get("page"))
+		if p == 0 {
+			p = 1
+		}
+		mu.Lock()
+		pages = append(pages, p)
+		mu.Unlock()
+
+		last := (total + perPage - 1) / perPage
+		link := func(n int) string {
+			return fmt.Sprintf("https://%s%s?per_page=%d&page=%d", r.Host, r.URL.Path, perPage, n)
+		}
+		if p < last {
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, link(p+1), link(last)))
+		}
+		var items []string
+		for id := (p-1)*perPage + 1; id <= p*perPage && id <= total; id++ {
+			items = append(items, fmt.Sprintf(`{"id":%d}`, id))
+		}
+		fmt.Fprintf(w, `{"total_count":%d,"workflows":[%s]}`, total, strings.Join(items, ","))
+	})
+	return h, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), pages...)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		opts          ListOptions
+		wantItems     int
+		wantPages     int
+		wantTruncated bool
+	}{
+		{"single page", 3, ListOptions{PerPage: 10}, 3, 1, false},
+		{"follows next links", 25, ListOptions{PerPage: 10}, 25, 3, false},
+		{"stops at MaxItems", 25, ListOptions{PerPage: 10, MaxItems: 15}, 15, 2, true},
+		{"concurrent", 45, ListOptions{PerPage: 10, Concurrency: 3}, 45, 5, false},
+		{"concurrent with MaxItems", 45, ListOptions{PerPage: 10, MaxItems: 22, Concurrency: 3}, 22, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, pages := pagedWorkflows(tt.total)
+			client, _ := newEnterpriseTestClient(t, handler)
+
+			var progress []int
+			tt.opts.OnProgress = func(fetched, total int) {
+				if total != tt.total {
+					t.Errorf("progress total = %d, want %d", total, tt.total)
+				}
+				progress = append(progress, fetched)
+			}
+			res, err := paginate[struct{ ID int }](context.Background(), client, client.repoPath("actions/workflows"), "workflows", tt.opts)
+			if err != nil {
+				t.Fatalf("paginate() error = %v", err)
+			}
+			if len(res.Items) != tt.wantItems {
+				t.Errorf("items = %d, want %d", len(res.Items), tt.wantItems)
+			}
+			for i, it := range res.Items {
+				if it.ID != i+1 {
+					t.Fatalf("item %d has ID %d; pages out of order", i, it.ID)
+				}
+			}
+			if res.TotalCount != tt.total {
+				t.Errorf("TotalCount = %d, want %d", res.TotalCount, tt.total)
+			}
+			if res.Truncated != tt.wantTruncated {
+				t.Errorf("Truncated = %v, want %v", res.Truncated, tt.wantTruncated)
+			}
+			if got := len(pages()); got != tt.wantPages {
+				t.Errorf("pages fetched = %d (%v), want %d", got, pages(), tt.wantPages)
+			}
+			if len(progress) != tt.wantPages {
+				t.Errorf("progress calls = %v, want %d", progress, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestPaginateError(t *testing.T) {
+	handler, _ := pagedWorkflows(30)
+	client, _ := newEnterpriseTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, `{"message":"Server Error"}`, http.StatusBadGateway)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+
+	for _, concurrency := range []int{0, 3} {
+		_, err := client.ListWorkflows(context.Background(), ListOptions{PerPage: 10, Concurrency: concurrency})
+		if err == nil || !strings.Contains(err.Error(), "list workflows") {
+			t.Errorf("concurrency %d: error = %v, want list workflows failure", concurrency, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateTracker records the core rate-limit budget reported by GitHub in the
+// X-RateLimit-* headers. A tracker can be shared between clients and is safe
+// for concurrent use. A nil tracker reports an unknown budget.
+type RateTracker struct {
+	mu  sync.Mutex
+	rl  RateLimit
+	now func() time.Time
+}
+
+// NewRateTracker returns an empty tracker. Until the first response is
+// observed the budget is unknown and no throttling is applied.
+func NewRateTracker() *RateTracker {
+	return &RateTracker{now: time.Now}
+}
+
+// Observe updates the tracker from response headers. Responses without
+// rate-limit headers (e.g. storage redirects) and non-core buckets such as
+// search are ignored.
+func (t *RateTracker) Observe(h http.Header) {
+	if t == nil || h.Get("X-RateLimit-Limit") == "" {
+		return
+	}
+	if res := h.Get("X-RateLimit-Resource"); res != "" && res != "core" {
+		return
+	}
+	var rl RateLimit
+	rl.Remaining, _ = strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	rl.Limit, _ = strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	rl.Reset, _ = strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// Concurrent responses can arrive out of order. Within one window the
+	// lowest remaining count is the most recent; a later reset starts a new window.
+	if rl.Reset == t.rl.Reset && t.rl.Limit > 0 && rl.Remaining > t.rl.Remaining {
+		return
+	}
+	if rl.Reset < t.rl.Reset {
+		return
+	}
+	t.rl = rl
+}
+
+// Snapshot returns the last observed budget.
+func (t *RateTracker) Snapshot() RateLimit {
+	if t == nil {
+		return RateLimit{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rl
+}
+
+// Exhausted reports whether the budget is used up and, if so, when it resets.
+func (t *RateTracker) Exhausted() (bool, time.Time) {
+	if t == nil {
+		return false, time.Time{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.rl.Limit == 0 || t.rl.Remaining > 0 {
+		return false, time.Time{}
+	}
+	reset := time.Unix(t.rl.Reset, 0)
+	if !reset.After(t.now()) {
+		return false, time.Time{}
+	}
+	return true, reset
+}
+
+// Interval scales a polling interval by the remaining budget: unchanged above
+// half, then 2x, 4x and 8x as it shrinks, and until the reset once exhausted.
+func (t *RateTracker) Interval(base time.Duration) time.Duration {
+	if exhausted, reset := t.Exhausted(); exhausted {
+		if d := reset.Sub(t.now()) + time.Second; d > base {
+			return d
+		}
+		return base
+	}
+	switch f := t.fraction(); {
+	case f < 0.05:
+		return base * 8
+	case f < 0.20:
+		return base * 4
+	case f < 0.50:
+		return base * 2
+	}
+	return base
+}
+
+// Wait paces bulk work such as mass deletes and dashboard fan-out. It blocks
+// until the reset when the budget is exhausted and adds a short delay when it
+// runs low. It returns early with the context's error if ctx is cancelled.
+func (t *RateTracker) Wait(ctx context.Context) error {
+	var d time.Duration
+	if exhausted, reset := t.Exhausted(); exhausted {
+		d = reset.Sub(t.now()) + time.Second
+	} else {
+		switch f := t.fraction(); {
+		case f < 0.05:
+			d = 2 * time.Second
+		case f < 0.20:
+			d = 500 * time.Millisecond
+		}
+	}
+	if d <= 0 {
+		return ctx.Err()
+	}
+	return sleepContext(ctx, d)
+}
+
+// fraction returns the remaining share of the budget, or 1 when unknown.
+func (t *RateTracker) fraction() float64 {
+	if t == nil {
+		return 1
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.rl.Limit <= 0 {
+		return 1
+	}
+	return float64(t.rl.Remaining) / float64(t.rl.Limit)
+}
+
+// rateTransport feeds every response's headers into a RateTracker.
+type rateTransport struct {
+	base    http.RoundTripper
+	tracker *RateTracker
+}
+
+func (rt *rateTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.base.RoundTrip(req)
+	if resp != nil {
+		rt.tracker.Observe(resp.Header)
+	}
+	return resp, err
+}
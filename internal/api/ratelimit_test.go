@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rateHeaders(remaining, limit int, reset int64) http.Header {
+	h := http.Header{}
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+	return h
+}
+
+func TestRateTrackerObserve(t *testing.T) {
+	tr := NewRateTracker()
+	tr.Observe(rateHeaders(4000, 5000, 100))
+	tr.Observe(rateHeaders(4100, 5000, 100)) // stale response in the same window
+	if got := tr.Snapshot().Remaining; got != 4000 {
+		t.Errorf("Remaining = %d, want 4000", got)
+	}
+
+	tr.Observe(rateHeaders(4999, 5000, 200)) // new window
+	if got := tr.Snapshot(); got.Remaining != 4999 || got.Reset != 200 {
+		t.Errorf("Snapshot() = %+v, want new window", got)
+	}
+
+	search := rateHeaders(5, 30, 300)
+	search.Set("X-RateLimit-Resource", "search")
+	tr.Observe(search)
+	tr.Observe(http.Header{})
+	if got := tr.Snapshot().Limit; got != 5000 {
+		t.Errorf("Limit = %d, want core budget to be kept", got)
+	}
+}
+
+func TestRateTrackerInterval(t *testing.T) {
+	now := time.Unix(1000, 0)
+	base := 5 * time.Second
+	tests := []struct {
+		name      string
+		remaining int
+		reset     int64
+		want      time.Duration
+	}{
+		{"plenty", 4000, 2000, base},
+		{"below half", 2000, 2000, 2 * base},
+		{"below 20%", 500, 2000, 4 * base},
+		{"below 5%", 100, 2000, 8 * base},
+		{"exhausted", 0, 1060, 61 * time.Second},
+		{"exhausted but reset passed", 0, 900, 8 * base},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := NewRateTracker()
+			tr.now = func() time.Time { return now }
+			tr.Observe(rateHeaders(tt.remaining, 5000, tt.reset))
+			if got := tr.Interval(base); got != tt.want {
+				t.Errorf("Interval() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilTracker *RateTracker
+	if got := nilTracker.Interval(base); got != base {
+		t.Errorf("nil tracker Interval() = %v, want %v", got, base)
+	}
+}
+
+func TestRateTrackerWaitCancelled(t *testing.T) {
+	tr := NewRateTracker()
+	tr.Observe(rateHeaders(0, 5000, time.Now().Add(time.Hour).Unix()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := tr.Wait(ctx); err != context.Canceled {
+		t.Errorf("Wait() = %v, want context.Canceled", err)
+	}
+}
+
+func TestRateTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range rateHeaders(42, 5000, 123) {
+			w.Header()[k] = v
+		}
+	}))
+	defer srv.Close()
+
+	tr := NewRateTracker()
+	client := &http.Client{Transport: &rateTransport{base: http.DefaultTransport, tracker: tr}}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got := tr.Snapshot(); got.Remaining != 42 || got.Limit != 5000 {
+		t.Errorf("Snapshot() = %+v, want remaining 42 of 5000", got)
+	}
+}
+
+func TestClientsShareRateTracker(t *testing.T) {
+	remaining := 100
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remaining--
+		for k, v := range rateHeaders(remaining, 5000, 123) {
+			w.Header()[k] = v
+		}
+		w.Write([]byte(`{"full_name":"x"}`))
+	}))
+	defer srv.Close()
+
+	tr := NewRateTracker()
+	opts := Options{
+		Host:        strings.TrimPrefix(srv.URL, "https://"),
+		AuthToken:   "test-token",
+		Transport:   srv.Client().Transport,
+		Retry:       &RetryPolicy{},
+		RateTracker: tr,
+	}
+	a, err := NewClient("octo", "app", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewClient("octo", "lib", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := a.CheckRepo(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.CheckRepo(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.RateLimit() != tr || b.RateLimit() != tr {
+		t.Fatal("clients did not use the shared tracker")
+	}
+	if got := tr.Snapshot().Remaining; got != 98 {
+		t.Errorf("Remaining = %d after one request per client, want 98", got)
+	}
+	if b.RepoNWO() != "octo/lib" {
+		t.Errorf("RepoNWO() = %q", b.RepoNWO())
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// GetRepository returns metadata for the client's repository.
+func (c *Client) GetRepository(ctx context.Context) (*model.Repository, error) {
+	var repo model.Repository
+	if err := wrapError(c.rest.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("repos/%s/%s", c.owner, c.repo), nil, &repo)); err != nil {
+		return nil, fmt.Errorf("get repository: %w", err)
+	}
+	return &repo, nil
+}
+
+// GetFileContent returns the contents of a file at the given ref.
+// An empty ref reads from the default branch.
+func (c *Client) GetFileContent(ctx context.Context, path, ref string) ([]byte, error) {
+	endpoint := "contents/" + strings.TrimPrefix(path, "/")
+	if ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(ref)
+	}
+	var resp struct {
+		Content  string `json:"content"`
+		Encoding string `json:"encoding"`
+	}
+	if err := c.Get(ctx, endpoint, &resp); err != nil {
+		return nil, fmt.Errorf("get %s: %w", path, err)
+	}
+	if resp.Encoding != "base64" {
+		return nil, fmt.Errorf("get %s: unsupported encoding %q", path, resp.Encoding)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(resp.Content, "\n", ""))
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// ListEnvironments returns the deployment environments of the repository.
+func (c *Client) ListEnvironments(ctx context.Context) (*model.EnvironmentsResponse, error) {
+	res, err := paginate[model.Environment](ctx, c, c.repoPath("environments"), "environments", ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("list environments: %w", err)
+	}
+	return &model.EnvironmentsResponse{TotalCount: res.TotalCount, Environments: res.Items}, nil
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how transient failures are retried. Only idempotent
+// requests (GET, HEAD, PUT, DELETE) are retried; reruns, cancels and
+// dispatches are sent once.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero
+	// disables retrying.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry. It doubles on every
+	// further retry and a random jitter of up to half is subtracted.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A Retry-After longer than this is not
+	// waited out; the response is returned as ErrRateLimited instead.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy retries three times, waiting about 0.5s, 1s and 2s,
+// and honours Retry-After up to a minute.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: time.Minute}
+}
+
+// backoff returns the jittered delay before retry n (0-based).
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := p.BaseDelay << n
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if half := int64(d / 2); half > 0 {
+		d -= time.Duration(rand.Int64N(half))
+	}
+	return d
+}
+
+// retryTransport retries idempotent requests that fail with a 5xx or hit a
+// secondary rate limit (429, or 403 with Retry-After).
+type retryTransport struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(base http.RoundTripper, policy RetryPolicy) *retryTransport {
+	return &retryTransport{base: base, policy: policy, sleep: sleepContext}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isIdempotent(req.Method) || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return t.base.RoundTrip(req)
+	}
+
+	for n := 0; ; n++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || n >= t.policy.MaxRetries {
+			return resp, err
+		}
+		wait, retry := t.retryAfter(resp, n)
+		if !retry {
+			return resp, nil
+		}
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		resp.Body.Close()
+
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryAfter decides whether resp is worth retrying and how long to wait.
+func (t *retryTransport) retryAfter(resp *http.Response, n int) (time.Duration, bool) {
+	switch {
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return t.policy.backoff(n), true
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusForbidden:
+		ra := resp.Header.Get("Retry-After")
+		if ra == "" {
+			if resp.StatusCode == http.StatusForbidden {
+				// A plain 403 is a permissions problem or the primary
+				// limit, neither of which clears by retrying soon.
+				return 0, false
+			}
+			return t.policy.backoff(n), true
+		}
+		secs, err := strconv.Atoi(ra)
+		if err != nil || secs < 0 {
+			return 0, false
+		}
+		wait := time.Duration(secs) * time.Second
+		if wait > t.policy.MaxDelay {
+			return 0, false
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// sleepContext waits for d or until ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTransport replays canned responses in order and records each request.
+type fakeTransport struct {
+	responses []*http.Response
+	requests  []*http.Request
+}
+
+func (f *fakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+	if len(f.responses) == 0 {
+		return nil, errors.New("no more responses")
+	}
+	resp := f.responses[0]
+	f.responses = f.responses[1:]
+	resp.Request = req
+	return resp, nil
+}
+
+func fakeResponse(status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: status, Header: header, Body: io.NopCloser(strings.NewReader("{}"))}
+}
+
+func TestRetryTransport(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		name       string
+		method     string
+		responses  []*http.Response
+		wantStatus int
+		wantCalls  int
+		wantWaits  []time.Duration // exact waits; nil means only count them
+	}{
+		{
+			name:       "success is not retried",
+			method:     http.MethodGet,
+			responses:  []*http.Response{fakeResponse(200, nil)},
+			wantStatus: 200,
+			wantCalls:  1,
+		},
+		{
+			name:       "502 then success",
+			method:     http.MethodGet,
+			responses:  []*http.Response{fakeResponse(502, nil), fakeResponse(502, nil), fakeResponse(200, nil)},
+			wantStatus: 200,
+			wantCalls:  3,
+		},
+		{
+			name:       "gives up after max retries",
+			method:     http.MethodDelete,
+			responses:  []*http.Response{fakeResponse(503, nil), fakeResponse(503, nil), fakeResponse(503, nil), fakeResponse(503, nil)},
+			wantStatus: 503,
+			wantCalls:  4,
+		},
+		{
+			name:       "secondary rate limit honours Retry-After",
+			method:     http.MethodDelete,
+			responses:  []*http.Response{fakeResponse(403, http.Header{"Retry-After": {"2"}}), fakeResponse(204, nil)},
+			wantStatus: 204,
+			wantCalls:  2,
+			wantWaits:  []time.Duration{2 * time.Second},
+		},
+		{
+			name:       "429 without Retry-After backs off",
+			method:     http.MethodGet,
+			responses:  []*http.Response{fakeResponse(429, nil), fakeResponse(200, nil)},
+			wantStatus: 200,
+			wantCalls:  2,
+		},
+		{
+			name:       "Retry-After beyond MaxDelay is returned",
+			method:     http.MethodGet,
+			responses:  []*http.Response{fakeResponse(403, http.Header{"Retry-After": {"600"}})},
+			wantStatus: 403,
+			wantCalls:  1,
+		},
+		{
+			name:       "plain 403 is not retried",
+			method:     http.MethodGet,
+			responses:  []*http.Response{fakeResponse(403, nil)},
+			wantStatus: 403,
+			wantCalls:  1,
+		},
+		{
+			name:       "404 is not retried",
+			method:     http.MethodGet,
+			responses:  []*http.Response{fakeResponse(404, nil)},
+			wantStatus: 404,
+			wantCalls:  1,
+		},
+		{
+			name:       "POST is not retried",
+			method:     http.MethodPost,
+			responses:  []*http.Response{fakeResponse(502, nil)},
+			wantStatus: 502,
+			wantCalls:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeTransport{responses: tt.responses}
+			var waits []time.Duration
+			rt := newRetryTransport(fake, policy)
+			rt.sleep = func(ctx context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			req, _ := http.NewRequest(tt.method, "https://api.github.com/repos/o/r/actions/runs", nil)
+			resp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if len(fake.requests) != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", len(fake.requests), tt.wantCalls)
+			}
+			if len(waits) != tt.wantCalls-1 {
+				t.Errorf("waits = %v, want %d", waits, tt.wantCalls-1)
+			}
+			if tt.wantWaits != nil {
+				for i, w := range tt.wantWaits {
+					if waits[i] != w {
+						t.Errorf("wait[%d] = %v, want %v", i, waits[i], w)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRetryTransportReplaysBody(t *testing.T) {
+	fake := &fakeTransport{responses: []*http.Response{fakeResponse(502, nil), fakeResponse(204, nil)}}
+	rt := newRetryTransport(fake, RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+
+	req, _ := http.NewRequest(http.MethodPut, "https://api.github.com/repos/o/r/actions/workflows/1/enable", strings.NewReader(`{"a":1}`))
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	for i, r := range fake.requests {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"a":1}` {
+			t.Errorf("request %d body = %q", i, body)
+		}
+	}
+}
+
+func TestRetryTransportCancelled(t *testing.T) {
+	fake := &fakeTransport{responses: []*http.Response{fakeResponse(503, nil), fakeResponse(200, nil)}}
+	rt := newRetryTransport(fake, RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/", nil)
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Errorf("RoundTrip() error = %v, want context.Canceled", err)
+	}
+	if len(fake.requests) != 1 {
+		t.Errorf("calls = %d, want 1", len(fake.requests))
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 5, BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	for n, max := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		for i := 0; i < 20; i++ {
+			d := p.backoff(n)
+			if d > max || d < max/2 {
+				t.Fatalf("backoff(%d) = %v, want in [%v, %v]", n, d, max/2, max)
+			}
+		}
+	}
+}
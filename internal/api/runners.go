@@ -1,28 +1,29 @@
 package api
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/altinukshini/gha-tui/internal/model"
 )
 
 // ListRunners returns the repository's GitHub Actions runners.
-func (c *Client) ListRunners(perPage, page int) (*model.RunnersResponse, error) {
-	endpoint := fmt.Sprintf("actions/runners?per_page=%d&page=%d", perPage, page)
-	var resp model.RunnersResponse
-	if err := c.Get(endpoint, &resp); err != nil {
+func (c *Client) ListRunners(ctx context.Context, opts ListOptions) (*model.RunnersResponse, error) {
+	res, err := paginate[model.Runner](ctx, c, c.repoPath("actions/runners"), "runners", opts)
+	if err != nil {
 		return nil, fmt.Errorf("list runners: %w", err)
 	}
-	return &resp, nil
+	return &model.RunnersResponse{TotalCount: res.TotalCount, Runners: res.Items}, nil
 }
 
 // ListOrgRunners returns the organization's GitHub Actions runners.
-// Returns nil, nil if the owner is not an org or the token lacks permissions.
-func (c *Client) ListOrgRunners(perPage, page int) (*model.RunnersResponse, error) {
-	endpoint := fmt.Sprintf("orgs/%s/actions/runners?per_page=%d&page=%d", c.owner, perPage, page)
-	var resp model.RunnersResponse
-	if err := c.rest.Get(endpoint, &resp); err != nil {
-		return nil, err
+// It fails with ErrNotFound when the owner is a user rather than an org and
+// with ErrForbidden when the token lacks the admin:org scope.
+func (c *Client) ListOrgRunners(ctx context.Context, opts ListOptions) (*model.RunnersResponse, error) {
+	endpoint := fmt.Sprintf("orgs/%s/actions/runners", c.owner)
+	res, err := paginate[model.Runner](ctx, c, endpoint, "runners", opts)
+	if err != nil {
+		return nil, fmt.Errorf("list org runners: %w", err)
 	}
-	return &resp, nil
+	return &model.RunnersResponse{TotalCount: res.TotalCount, Runners: res.Items}, nil
 }
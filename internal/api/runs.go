@@ -1,10 +1,11 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
-	"strings"
 
 	"github.com/altinukshini/gha-tui/internal/model"
 )
@@ -52,21 +53,23 @@ func (f RunsFilter) QueryString() string {
 	return ""
 }
 
-func (c *Client) ListRuns(filter RunsFilter) (*model.RunsResponse, error) {
-	var basePath string
-	if filter.WorkflowID > 0 {
-		basePath = fmt.Sprintf("actions/workflows/%d/runs", filter.WorkflowID)
-	} else if filter.WorkflowFile != "" {
-		basePath = fmt.Sprintf("actions/workflows/%s/runs", url.PathEscape(filter.WorkflowFile))
-	} else {
-		basePath = "actions/runs"
+// basePath returns the runs endpoint, scoped to a workflow when one is set.
+func (f RunsFilter) basePath() string {
+	if f.WorkflowID > 0 {
+		return fmt.Sprintf("actions/workflows/%d/runs", f.WorkflowID)
+	} else if f.WorkflowFile != "" {
+		return fmt.Sprintf("actions/workflows/%s/runs", url.PathEscape(f.WorkflowFile))
 	}
+	return "actions/runs"
+}
 
+// ListRuns returns a single page of runs, as selected by filter.Page.
+func (c *Client) ListRuns(ctx context.Context, filter RunsFilter) (*model.RunsResponse, error) {
 	var resp model.RunsResponse
-	err := c.Get(basePath+filter.QueryString(), &resp)
+	err := c.Get(ctx, filter.basePath()+filter.QueryString(), &resp)
 	if err != nil {
 		// Workflow may have been deleted or have no runs — treat 404 as empty
-		if strings.Contains(err.Error(), "404") {
+		if errors.Is(err, ErrNotFound) {
 			return &model.RunsResponse{}, nil
 		}
 		return nil, fmt.Errorf("list runs: %w", err)
@@ -74,9 +77,24 @@ func (c *Client) ListRuns(filter RunsFilter) (*model.RunsResponse, error) {
 	return &resp, nil
 }
 
-func (c *Client) GetRun(runID int64) (*model.Run, error) {
+// ListAllRuns follows pagination from the first page, up to opts.MaxItems.
+// filter.PerPage and filter.Page are ignored in favour of opts. Like
+// ListRuns, a missing workflow yields an empty result.
+func (c *Client) ListAllRuns(ctx context.Context, filter RunsFilter, opts ListOptions) (*model.RunsResponse, error) {
+	filter.PerPage, filter.Page = 0, 0
+	res, err := paginate[model.Run](ctx, c, c.repoPath(filter.basePath()+filter.QueryString()), "workflow_runs", opts)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &model.RunsResponse{}, nil
+		}
+		return nil, fmt.Errorf("list runs: %w", err)
+	}
+	return &model.RunsResponse{TotalCount: res.TotalCount, Runs: res.Items}, nil
+}
+
+func (c *Client) GetRun(ctx context.Context, runID int64) (*model.Run, error) {
 	var run model.Run
-	err := c.Get(fmt.Sprintf("actions/runs/%d", runID), &run)
+	err := c.Get(ctx, fmt.Sprintf("actions/runs/%d", runID), &run)
 	if err != nil {
 		return nil, fmt.Errorf("get run %d: %w", runID, err)
 	}
@@ -1,49 +1,50 @@
 package api
 
 import (
+	"context"
 	"fmt"
-	"net/url"
-	"strconv"
 
 	"github.com/altinukshini/gha-tui/internal/model"
 )
 
-func (c *Client) ListWorkflows(perPage, page int) (*model.WorkflowsResponse, error) {
-	v := url.Values{}
-	if perPage > 0 {
-		v.Set("per_page", strconv.Itoa(perPage))
-	} else {
-		v.Set("per_page", "100")
-	}
-	if page > 0 {
-		v.Set("page", strconv.Itoa(page))
-	}
-
-	var resp model.WorkflowsResponse
-	err := c.Get("actions/workflows?"+v.Encode(), &resp)
+// ListWorkflows returns the repository's workflows across all pages, up to
+// opts.MaxItems.
+func (c *Client) ListWorkflows(ctx context.Context, opts ListOptions) (*model.WorkflowsResponse, error) {
+	res, err := paginate[model.Workflow](ctx, c, c.repoPath("actions/workflows"), "workflows", opts)
 	if err != nil {
 		return nil, fmt.Errorf("list workflows: %w", err)
 	}
-	return &resp, nil
+	return &model.WorkflowsResponse{TotalCount: res.TotalCount, Workflows: res.Items}, nil
 }
 
-func (c *Client) EnableWorkflow(workflowID int64) error {
-	return c.Put(fmt.Sprintf("actions/workflows/%d/enable", workflowID), nil, nil)
+func (c *Client) EnableWorkflow(ctx context.Context, workflowID int64) error {
+	return c.Put(ctx, fmt.Sprintf("actions/workflows/%d/enable", workflowID), nil, nil)
 }
 
-func (c *Client) DisableWorkflow(workflowID int64) error {
-	return c.Put(fmt.Sprintf("actions/workflows/%d/disable", workflowID), nil, nil)
+func (c *Client) DisableWorkflow(ctx context.Context, workflowID int64) error {
+	return c.Put(ctx, fmt.Sprintf("actions/workflows/%d/disable", workflowID), nil, nil)
 }
 
 type RetentionSettings struct {
 	ArtifactAndLogRetentionDays int `json:"artifact_and_log_retention_days"`
 }
 
-func (c *Client) GetRetentionSettings() (*RetentionSettings, error) {
+func (c *Client) GetRetentionSettings(ctx context.Context) (*RetentionSettings, error) {
 	var resp RetentionSettings
-	err := c.Get("actions/retention", &resp)
+	err := c.Get(ctx, "actions/retention", &resp)
 	if err != nil {
 		return nil, fmt.Errorf("get retention settings: %w", err)
 	}
 	return &resp, nil
 }
+
+type dispatchRequest struct {
+	Ref    string            `json:"ref"`
+	Inputs map[string]string `json:"inputs,omitempty"`
+}
+
+// DispatchWorkflow triggers a workflow_dispatch event for a workflow at ref.
+func (c *Client) DispatchWorkflow(ctx context.Context, workflowID int64, ref string, inputs map[string]string) error {
+	body := dispatchRequest{Ref: ref, Inputs: inputs}
+	return c.Post(ctx, fmt.Sprintf("actions/workflows/%d/dispatches", workflowID), body, nil)
+}
@@ -0,0 +1,261 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// RunStore keeps the completed workflow runs of one repository, with the
+// jobs of their latest attempt, so that the Metrics tab only fetches runs it
+// has not seen before. Runs are appended to runs.jsonl as they arrive;
+// coverage.json records the creation times between which every run is
+// stored.
+//
+// A completed run is taken not to change. A run re-run after it was stored
+// keeps its first outcome.
+type RunStore struct {
+	dir string
+
+	mu       sync.Mutex
+	runs     map[int64]StoredRun
+	coverage Coverage
+}
+
+// StoredRun is a completed run and the jobs of its latest attempt, without
+// their steps.
+type StoredRun struct {
+	Run  model.Run   `json:"run"`
+	Jobs []model.Job `json:"jobs"`
+}
+
+// Coverage is the range of creation times, From inclusive and Through
+// exclusive, in which every run of the repository is stored.
+type Coverage struct {
+	From    time.Time `json:"from"`
+	Through time.Time `json:"through"`
+}
+
+// Empty reports whether the coverage spans no time.
+func (c Coverage) Empty() bool {
+	return !c.From.Before(c.Through)
+}
+
+var (
+	runStoresMu sync.Mutex
+	runStores   = make(map[string]*RunStore)
+)
+
+// RunStoreDir returns the directory of the run store of owner/repo on host,
+// under the user's cache directory.
+func RunStoreDir(host, owner, repo string) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	if host == "" {
+		host = "github.com"
+	}
+	return filepath.Join(base, "gha-tui", "runs", host, owner, repo), nil
+}
+
+// OpenRunStore opens the run store in dir, creating it if needed. A store is
+// read from disk once per process; later calls return the same store.
+func OpenRunStore(dir string) (*RunStore, error) {
+	runStoresMu.Lock()
+	defer runStoresMu.Unlock()
+	if s, ok := runStores[dir]; ok {
+		return s, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create run store dir: %w", err)
+	}
+	s := &RunStore{dir: dir, runs: make(map[int64]StoredRun)}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	runStores[dir] = s
+	return s, nil
+}
+
+// load reads the store. A line cut short by an interrupted write is skipped,
+// and so is a coverage file that cannot be read, which only costs a refetch.
+func (s *RunStore) load() error {
+	if data, err := os.ReadFile(s.coveragePath()); err == nil {
+		if json.Unmarshal(data, &s.coverage) != nil {
+			s.coverage = Coverage{}
+		}
+	}
+
+	f, err := os.Open(s.runsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open run store: %w", err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		var r StoredRun
+		if json.Unmarshal(sc.Bytes(), &r) == nil && r.Run.ID != 0 {
+			s.runs[r.Run.ID] = r
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("read run store: %w", err)
+	}
+	return nil
+}
+
+func (s *RunStore) runsPath() string     { return filepath.Join(s.dir, "runs.jsonl") }
+func (s *RunStore) coveragePath() string { return filepath.Join(s.dir, "coverage.json") }
+
+// Has reports whether the run is stored.
+func (s *RunStore) Has(runID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.runs[runID]
+	return ok
+}
+
+// Add stores a completed run and the jobs of its latest attempt. Steps are
+// dropped, since metrics do not use them.
+func (s *RunStore) Add(run model.Run, jobs []model.Job) error {
+	stored := StoredRun{Run: run, Jobs: make([]model.Job, len(jobs))}
+	for i, j := range jobs {
+		j.Steps = nil
+		stored.Jobs[i] = j
+	}
+	line, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.runs[run.ID]; ok {
+		return nil
+	}
+	f, err := os.OpenFile(s.runsPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open run store: %w", err)
+	}
+	_, err = f.Write(append(line, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("write run store: %w", err)
+	}
+	s.runs[run.ID] = stored
+	return nil
+}
+
+// Coverage returns the range in which every run is stored.
+func (s *RunStore) Coverage() Coverage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.coverage
+}
+
+// SetCoverage records the range in which every run is stored.
+func (s *RunStore) SetCoverage(c Coverage) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tmp := s.coveragePath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write run store coverage: %w", err)
+	}
+	if err := os.Rename(tmp, s.coveragePath()); err != nil {
+		return fmt.Errorf("write run store coverage: %w", err)
+	}
+	s.coverage = c
+	return nil
+}
+
+// Runs returns the stored runs created at or after since, newest first.
+func (s *RunStore) Runs(since time.Time) []StoredRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var runs []StoredRun
+	for _, r := range s.runs {
+		if !r.Run.CreatedAt.Before(since) {
+			runs = append(runs, r)
+		}
+	}
+	sortStoredRuns(runs)
+	return runs
+}
+
+// Prune drops the runs created before before, which GitHub no longer keeps,
+// and rewrites the store without them.
+func (s *RunStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var kept []StoredRun
+	for _, r := range s.runs {
+		if !r.Run.CreatedAt.Before(before) {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == len(s.runs) {
+		return nil
+	}
+	sortStoredRuns(kept)
+
+	tmp := s.runsPath() + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("rewrite run store: %w", err)
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, r := range kept {
+		if err = enc.Encode(r); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.runsPath())
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("rewrite run store: %w", err)
+	}
+
+	s.runs = make(map[int64]StoredRun, len(kept))
+	for _, r := range kept {
+		s.runs[r.Run.ID] = r
+	}
+	return nil
+}
+
+func sortStoredRuns(runs []StoredRun) {
+	sort.Slice(runs, func(i, j int) bool {
+		if !runs[i].Run.CreatedAt.Equal(runs[j].Run.CreatedAt) {
+			return runs[i].Run.CreatedAt.After(runs[j].Run.CreatedAt)
+		}
+		return runs[i].Run.ID > runs[j].Run.ID
+	})
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// reopen reads the store in dir from disk, as a new process would.
+func reopen(t *testing.T, dir string) *RunStore {
+	t.Helper()
+	s := &RunStore{dir: dir, runs: make(map[int64]StoredRun)}
+	if err := s.load(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestRunStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := OpenRunStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	job := model.Job{ID: 10, Name: "build", Steps: []model.Step{{Name: "checkout"}}}
+	for i, age := range []time.Duration{time.Hour, 48 * time.Hour, 500 * 24 * time.Hour} {
+		run := model.Run{ID: int64(i + 1), CreatedAt: now.Add(-age), Status: model.RunStatusCompleted}
+		if err := s.Add(run, []model.Job{job}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cov := Coverage{From: now.Add(-72 * time.Hour), Through: now}
+	if err := s.SetCoverage(cov); err != nil {
+		t.Fatal(err)
+	}
+	// A write cut short leaves a partial last line.
+	f, err := os.OpenFile(filepath.Join(dir, "runs.jsonl"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"run":{"id":4,`)
+	f.Close()
+
+	s = reopen(t, dir)
+	if got := s.Coverage(); !got.From.Equal(cov.From) || !got.Through.Equal(cov.Through) {
+		t.Errorf("Coverage() = %+v, want %+v", got, cov)
+	}
+	runs := s.Runs(now.Add(-72 * time.Hour))
+	if len(runs) != 2 || runs[0].Run.ID != 1 || runs[1].Run.ID != 2 {
+		t.Fatalf("Runs() = %+v, want runs 1 and 2", runs)
+	}
+	if jobs := runs[0].Jobs; len(jobs) != 1 || jobs[0].Name != "build" || jobs[0].Steps != nil {
+		t.Errorf("stored jobs = %+v, want build without steps", jobs)
+	}
+
+	if err := s.Prune(now.Add(-400 * 24 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	s = reopen(t, dir)
+	if !s.Has(1) || !s.Has(2) || s.Has(3) {
+		t.Errorf("after Prune, has 1, 2, 3 = %v, %v, %v, want true, true, false", s.Has(1), s.Has(2), s.Has(3))
+	}
+}
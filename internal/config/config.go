@@ -1,19 +1,154 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type Config struct {
 	Owner string
 	Repo  string
+	// Host is the GitHub hostname; empty means gh's default (usually github.com).
+	Host string
+
+	// Watch lists further repositories, as owner/repo, whose runs are merged
+	// into the Runs tab alongside Owner/Repo's.
+	Watch []string
+
+	// Branch pre-filters the Runs tab; it is set when the repository was
+	// detected from a checkout, to the branch checked out there.
+	Branch string
+
+	Settings
+
+	// Sources are the layers Settings were resolved from, kept so the
+	// settings of another repository can be resolved (see WithRepo). Nil
+	// when the config was built directly.
+	Sources *Sources
+}
+
+// Settings are the tunables that can be set in the config file, per
+// repository, through the environment or with flags.
+type Settings struct {
+	CacheSizeMB int           // log cache size limit
+	CacheTTL    time.Duration // log cache entry lifetime
+	DownloadDir string        // where downloaded artifacts are saved
+	MaxRetries  int           // retries of failed idempotent requests
+
+	// Polling intervals while runs, jobs and job logs are in progress.
+	// They stretch as the API budget shrinks.
+	RunsRefresh time.Duration
+	JobsRefresh time.Duration
+	LogRefresh  time.Duration
+
+	RunsPerPage int // runs per page in the Runs tab (API maximum 100)
+
+	// DeleteConcurrency is the number of deletes in flight during bulk
+	// deletes of runs, caches and artifacts.
+	DeleteConcurrency int
+}
+
+// DefaultSettings returns the settings used when nothing overrides them.
+func DefaultSettings() Settings {
+	return Settings{
+		CacheSizeMB:       500,
+		CacheTTL:          24 * time.Hour,
+		DownloadDir:       ".",
+		MaxRetries:        3,
+		RunsRefresh:       5 * time.Second,
+		JobsRefresh:       3 * time.Second,
+		LogRefresh:        1500 * time.Millisecond,
+		RunsPerPage:       30,
+		DeleteConcurrency: 3,
+	}
+}
+
+// minRefresh is the shortest polling interval accepted.
+const minRefresh = 500 * time.Millisecond
+
+// Validate reports the first setting out of range, by its config file key.
+func (s Settings) Validate() error {
+	switch {
+	case s.CacheSizeMB <= 0:
+		return fmt.Errorf("cache-size must be positive, got %d", s.CacheSizeMB)
+	case s.CacheTTL <= 0:
+		return fmt.Errorf("cache-ttl must be positive, got %s", s.CacheTTL)
+	case s.DownloadDir == "":
+		return fmt.Errorf("download-dir must not be empty")
+	case s.MaxRetries < 0:
+		return fmt.Errorf("max-retries must not be negative, got %d", s.MaxRetries)
+	case s.RunsRefresh < minRefresh:
+		return fmt.Errorf("refresh.runs must be at least %s, got %s", minRefresh, s.RunsRefresh)
+	case s.JobsRefresh < minRefresh:
+		return fmt.Errorf("refresh.jobs must be at least %s, got %s", minRefresh, s.JobsRefresh)
+	case s.LogRefresh < minRefresh:
+		return fmt.Errorf("refresh.logs must be at least %s, got %s", minRefresh, s.LogRefresh)
+	case s.RunsPerPage < 1 || s.RunsPerPage > 100:
+		return fmt.Errorf("runs-per-page must be between 1 and 100, got %d", s.RunsPerPage)
+	case s.DeleteConcurrency < 1 || s.DeleteConcurrency > 20:
+		return fmt.Errorf("delete-concurrency must be between 1 and 20, got %d", s.DeleteConcurrency)
+	}
+	return nil
+}
+
+// WithRepo returns the config of another repository on the same host, with
+// its per-repository settings. Watched repositories and the branch are not
+// carried over.
+func (c Config) WithRepo(owner, repo string) Config {
+	if c.Sources == nil {
+		c.Owner, c.Repo, c.Watch, c.Branch = owner, repo, nil, ""
+		return c
+	}
+	r := c.Sources.Config(owner, repo)
+	r.Host, r.Watch = c.Host, nil
+	return r
 }
 
 func (c Config) RepoNWO() string {
 	return fmt.Sprintf("%s/%s", c.Owner, c.Repo)
 }
 
+// RepoLabel is RepoNWO prefixed with the host when it is not github.com,
+// and followed by the number of further watched repositories.
+func (c Config) RepoLabel() string {
+	label := c.RepoNWO()
+	if c.Host != "" && c.Host != "github.com" {
+		label = c.Host + "/" + label
+	}
+	if len(c.Watch) > 0 {
+		label += fmt.Sprintf(" +%d", len(c.Watch))
+	}
+	return label
+}
+
+// Repos returns every watched repository as owner/repo, Owner/Repo first.
+func (c Config) Repos() []string {
+	return append([]string{c.RepoNWO()}, c.Watch...)
+}
+
 func (c Config) Validate() error {
 	if c.Owner == "" || c.Repo == "" {
 		return fmt.Errorf("owner and repo are required (use -R owner/repo)")
 	}
+	seen := map[string]bool{strings.ToLower(c.RepoNWO()): true}
+	for _, nwo := range c.Watch {
+		if _, _, err := ParseRepo(nwo); err != nil {
+			return err
+		}
+		if seen[strings.ToLower(nwo)] {
+			return fmt.Errorf("repository %s is listed twice", nwo)
+		}
+		seen[strings.ToLower(nwo)] = true
+	}
 	return nil
 }
+
+// ParseRepo splits an owner/repo string.
+func ParseRepo(nwo string) (owner, repo string, err error) {
+	parts := strings.Split(nwo, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("repo %q must be in owner/repo format", nwo)
+	}
+	return parts[0], parts[1], nil
+}
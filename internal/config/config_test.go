@@ -0,0 +1,176 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		in          string
+		owner, repo string
+		wantErr     bool
+	}{
+		{"cli/cli", "cli", "cli", false},
+		{"octo-org/hello.world", "octo-org", "hello.world", false},
+		{"cli", "", "", true},
+		{"cli/", "", "", true},
+		{"/cli", "", "", true},
+		{"github.com/cli/cli", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			owner, repo, err := ParseRepo(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRepo(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if owner != tt.owner || repo != tt.repo {
+				t.Errorf("ParseRepo(%q) = %q, %q, want %q, %q", tt.in, owner, repo, tt.owner, tt.repo)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsDuplicateRepos(t *testing.T) {
+	c := Config{Owner: "cli", Repo: "cli", Watch: []string{"cli/go-gh", "CLI/cli"}}
+	if err := c.Validate(); err == nil {
+		t.Error("Validate() accepted a repository listed twice")
+	}
+	c.Watch = []string{"cli/go-gh"}
+	if err := c.Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	if got := c.RepoLabel(); got != "cli/cli +1" {
+		t.Errorf("RepoLabel() = %q", got)
+	}
+}
+
+const testFile = `
+cache-size: 1000
+refresh:
+  runs: 10s
+  logs: 2s
+runs-per-page: 50
+watch: [cli/cli, cli/go-gh]
+repos:
+  CLI/cli:
+    refresh:
+      runs: 30s
+    delete-concurrency: 1
+`
+
+func TestSourcesPrecedence(t *testing.T) {
+	f, err := parseFile([]byte(testFile))
+	if err != nil {
+		t.Fatalf("parseFile() = %v", err)
+	}
+	env, err := EnvOverrides(func(name string) (string, bool) {
+		v, ok := map[string]string{
+			"GHA_TUI_CACHE_SIZE":    "2000",
+			"GHA_TUI_RUNS_PER_PAGE": "40",
+		}[name]
+		return v, ok
+	})
+	if err != nil {
+		t.Fatalf("EnvOverrides() = %v", err)
+	}
+	perPage := 60
+	s := &Sources{File: f, Env: env, Flags: Overrides{RunsPerPage: &perPage}}
+
+	c := s.Config("cli", "cli")
+	want := DefaultSettings()
+	want.CacheSizeMB = 2000             // env over file
+	want.RunsRefresh = 30 * time.Second // repo section over file
+	want.LogRefresh = 2 * time.Second   // file over default
+	want.RunsPerPage = 60               // flag over env
+	want.DeleteConcurrency = 1
+	if c.Settings != want {
+		t.Errorf("Settings = %+v, want %+v", c.Settings, want)
+	}
+	if got := strings.Join(c.Watch, ","); got != "cli/go-gh" {
+		t.Errorf("Watch = %q, want the primary repo dropped", got)
+	}
+
+	other := c.WithRepo("cli", "go-gh")
+	if other.RunsRefresh != 10*time.Second || other.DeleteConcurrency != 3 {
+		t.Errorf("WithRepo() kept the overrides of cli/cli: %+v", other.Settings)
+	}
+	if other.Watch != nil {
+		t.Errorf("WithRepo() Watch = %v, want none", other.Watch)
+	}
+}
+
+func TestParseFileRejectsInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{"unknown key", "cache_size: 10", "cache_size"},
+		{"negative size", "cache-size: -1", "cache-size must be positive"},
+		{"bad duration", "cache-ttl: soon", "`soon` into time.Duration"},
+		{"fast refresh", "refresh: {jobs: 100ms}", "refresh.jobs must be at least 500ms"},
+		{"page too large", "runs-per-page: 500", "runs-per-page must be between 1 and 100"},
+		{"bad watch", "watch: [cli]", "watch: repo \"cli\""},
+		{"bad repo key", "repos: {cli: {cache-size: 1}}", "repos: repo \"cli\""},
+		{"bad repo setting", "repos: {cli/cli: {delete-concurrency: 0}}", "repos.cli/cli: delete-concurrency"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseFile([]byte(tt.file))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseFile() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+	if _, err := parseFile(nil); err != nil {
+		t.Errorf("parseFile() of an empty file = %v", err)
+	}
+}
+
+func TestEnvOverridesRejectsBadValues(t *testing.T) {
+	_, err := EnvOverrides(func(name string) (string, bool) {
+		return "fast", name == "GHA_TUI_RUNS_REFRESH"
+	})
+	if err == nil || !strings.Contains(err.Error(), "GHA_TUI_RUNS_REFRESH") {
+		t.Errorf("EnvOverrides() error = %v, want it to name the variable", err)
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	got, err := parseKeys([]byte("disable: ctrl+x\ndown: [j, down, n]\ndelete-all: []\n"))
+	if err != nil {
+		t.Fatalf("parseKeys() error = %v", err)
+	}
+	want := map[string][]string{
+		"disable":    {"ctrl+x"},
+		"down":       {"j", "down", "n"},
+		"delete-all": {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeys() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"down: {j: k}", "down: ['']", "- j"} {
+		if _, err := parseKeys([]byte(bad)); err == nil {
+			t.Errorf("parseKeys(%q) succeeded, want an error", bad)
+		}
+	}
+}
+
+func TestParseTheme(t *testing.T) {
+	got, err := parseTheme([]byte("base: light\ncolors:\n  muted: \"#555555\"\n  highlight: 254\n"))
+	if err != nil {
+		t.Fatalf("parseTheme() error = %v", err)
+	}
+	want := ThemeFile{Base: "light", Colors: map[string]string{"muted": "#555555", "highlight": "254"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseTheme() = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseTheme([]byte("base: light\nmuted: \"#555555\"\n")); err == nil {
+		t.Error("parseTheme() accepted a colour outside colors")
+	}
+}
@@ -0,0 +1,242 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overrides is a partial Settings: nil fields are left alone. It is the
+// shape of the config file, of its per-repository sections and of the
+// settings given through the environment and flags.
+type Overrides struct {
+	CacheSizeMB *int           `yaml:"cache-size"`
+	CacheTTL    *time.Duration `yaml:"cache-ttl"`
+	DownloadDir *string        `yaml:"download-dir"`
+	MaxRetries  *int           `yaml:"max-retries"`
+	Refresh     struct {
+		Runs *time.Duration `yaml:"runs"`
+		Jobs *time.Duration `yaml:"jobs"`
+		Logs *time.Duration `yaml:"logs"`
+	} `yaml:"refresh"`
+	RunsPerPage       *int `yaml:"runs-per-page"`
+	DeleteConcurrency *int `yaml:"delete-concurrency"`
+
+	// Watch replaces the further repositories merged into the Runs tab.
+	Watch []string `yaml:"watch"`
+}
+
+func (o Overrides) apply(c *Config) {
+	setIf(&c.CacheSizeMB, o.CacheSizeMB)
+	setIf(&c.CacheTTL, o.CacheTTL)
+	setIf(&c.DownloadDir, o.DownloadDir)
+	setIf(&c.MaxRetries, o.MaxRetries)
+	setIf(&c.RunsRefresh, o.Refresh.Runs)
+	setIf(&c.JobsRefresh, o.Refresh.Jobs)
+	setIf(&c.LogRefresh, o.Refresh.Logs)
+	setIf(&c.RunsPerPage, o.RunsPerPage)
+	setIf(&c.DeleteConcurrency, o.DeleteConcurrency)
+	if o.Watch != nil {
+		c.Watch = o.Watch
+	}
+}
+
+func setIf[T any](dst *T, v *T) {
+	if v != nil {
+		*dst = *v
+	}
+}
+
+// Validate checks the settings the overrides set, on top of the defaults.
+func (o Overrides) Validate() error {
+	c := Config{Settings: DefaultSettings()}
+	o.apply(&c)
+	if err := c.Settings.Validate(); err != nil {
+		return err
+	}
+	for _, nwo := range o.Watch {
+		if _, _, err := ParseRepo(nwo); err != nil {
+			return fmt.Errorf("watch: %w", err)
+		}
+	}
+	return nil
+}
+
+// File is the config file, ~/.config/gha-tui/config.yaml by default.
+type File struct {
+	// Hostname is used when neither -hostname nor GH_HOST is set.
+	Hostname string `yaml:"hostname"`
+	// Theme is used when neither -theme nor GHA_TUI_THEME is set: auto,
+	// dark, light, high-contrast, or the name or path of a theme file.
+	Theme     string `yaml:"theme"`
+	Overrides `yaml:",inline"`
+
+	// Repos overrides settings for single repositories, keyed by owner/repo.
+	Repos map[string]Overrides `yaml:"repos"`
+}
+
+// repo returns the overrides of a repository; keys match case-insensitively.
+func (f File) repo(nwo string) Overrides {
+	for key, o := range f.Repos {
+		if strings.EqualFold(key, nwo) {
+			return o
+		}
+	}
+	return Overrides{}
+}
+
+// Dir returns the gha-tui config directory: $XDG_CONFIG_HOME/gha-tui or
+// ~/.config/gha-tui.
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gha-tui"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "gha-tui"), nil
+}
+
+// DefaultPath returns the config file used when -config is not given:
+// $GHA_TUI_CONFIG, or config.yaml in Dir.
+func DefaultPath() (string, error) {
+	if path := os.Getenv("GHA_TUI_CONFIG"); path != "" {
+		return path, nil
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// LoadFile reads and validates a config file. A missing file is an empty
+// config unless required is set. Unknown keys are errors, so typos do not
+// go unnoticed.
+func LoadFile(path string, required bool) (File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return File{}, nil
+	}
+	if err != nil {
+		return File{}, err
+	}
+	f, err := parseFile(data)
+	if err != nil {
+		return File{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+func parseFile(data []byte) (File, error) {
+	var f File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return File{}, err
+	}
+	if err := f.Overrides.Validate(); err != nil {
+		return File{}, err
+	}
+	for nwo, o := range f.Repos {
+		if _, _, err := ParseRepo(nwo); err != nil {
+			return File{}, fmt.Errorf("repos: %w", err)
+		}
+		if err := o.Validate(); err != nil {
+			return File{}, fmt.Errorf("repos.%s: %w", nwo, err)
+		}
+	}
+	return f, nil
+}
+
+// envVars maps the environment variables to the settings they override.
+var envVars = []struct {
+	name string
+	set  func(o *Overrides, v string) error
+}{
+	{"GHA_TUI_CACHE_SIZE", intVar(func(o *Overrides) **int { return &o.CacheSizeMB })},
+	{"GHA_TUI_CACHE_TTL", durationVar(func(o *Overrides) **time.Duration { return &o.CacheTTL })},
+	{"GHA_TUI_DOWNLOAD_DIR", func(o *Overrides, v string) error { o.DownloadDir = &v; return nil }},
+	{"GHA_TUI_MAX_RETRIES", intVar(func(o *Overrides) **int { return &o.MaxRetries })},
+	{"GHA_TUI_RUNS_REFRESH", durationVar(func(o *Overrides) **time.Duration { return &o.Refresh.Runs })},
+	{"GHA_TUI_JOBS_REFRESH", durationVar(func(o *Overrides) **time.Duration { return &o.Refresh.Jobs })},
+	{"GHA_TUI_LOG_REFRESH", durationVar(func(o *Overrides) **time.Duration { return &o.Refresh.Logs })},
+	{"GHA_TUI_RUNS_PER_PAGE", intVar(func(o *Overrides) **int { return &o.RunsPerPage })},
+	{"GHA_TUI_DELETE_CONCURRENCY", intVar(func(o *Overrides) **int { return &o.DeleteConcurrency })},
+}
+
+func intVar(field func(*Overrides) **int) func(*Overrides, string) error {
+	return func(o *Overrides, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", v)
+		}
+		*field(o) = &n
+		return nil
+	}
+}
+
+func durationVar(field func(*Overrides) **time.Duration) func(*Overrides, string) error {
+	return func(o *Overrides, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration", v)
+		}
+		*field(o) = &d
+		return nil
+	}
+}
+
+// EnvOverrides reads the GHA_TUI_* environment variables through lookup.
+func EnvOverrides(lookup func(string) (string, bool)) (Overrides, error) {
+	var o Overrides
+	for _, ev := range envVars {
+		v, ok := lookup(ev.name)
+		if !ok || v == "" {
+			continue
+		}
+		if err := ev.set(&o, v); err != nil {
+			return Overrides{}, fmt.Errorf("%s: %w", ev.name, err)
+		}
+	}
+	if err := o.Validate(); err != nil {
+		return Overrides{}, fmt.Errorf("environment: %w", err)
+	}
+	return o, nil
+}
+
+// Sources are the layers settings are resolved from. Later layers win:
+// defaults, the config file, its section for the repository, the
+// environment, then flags.
+type Sources struct {
+	File  File
+	Env   Overrides
+	Flags Overrides
+}
+
+// Config resolves the config of owner/repo. Host is left to the caller.
+// Watched repositories equal to owner/repo are dropped, so a shared watch
+// list can name every repository of a team.
+func (s *Sources) Config(owner, repo string) Config {
+	c := Config{Owner: owner, Repo: repo, Settings: DefaultSettings(), Sources: s}
+	for _, o := range []Overrides{s.File.Overrides, s.File.repo(c.RepoNWO()), s.Env, s.Flags} {
+		o.apply(&c)
+	}
+	var watch []string
+	for _, nwo := range c.Watch {
+		if !strings.EqualFold(nwo, c.RepoNWO()) {
+			watch = append(watch, nwo)
+		}
+	}
+	c.Watch = watch
+	return c
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeysPath returns the key bindings file, keys.yaml in Dir.
+func KeysPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "keys.yaml"), nil
+}
+
+// keyList is one or more keys: a single key can be written as a scalar.
+type keyList []string
+
+func (l *keyList) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		var k string
+		if err := n.Decode(&k); err != nil {
+			return err
+		}
+		*l = keyList{k}
+		return nil
+	}
+	var keys []string
+	if err := n.Decode(&keys); err != nil {
+		return err
+	}
+	*l = keys
+	return nil
+}
+
+// LoadKeys reads key binding overrides, a map from action names to keys. A
+// missing file means no overrides. Action names are checked when the
+// overrides are applied.
+func LoadKeys(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return keys, nil
+}
+
+func parseKeys(data []byte) (map[string][]string, error) {
+	var raw map[string]keyList
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	keys := make(map[string][]string, len(raw))
+	for name, l := range raw {
+		for _, k := range l {
+			if k == "" {
+				return nil, fmt.Errorf("%s: empty key", name)
+			}
+		}
+		keys[name] = l
+	}
+	return keys, nil
+}
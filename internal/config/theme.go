@@ -0,0 +1,58 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ThemeFile is a user theme: colours set on top of a built-in theme.
+type ThemeFile struct {
+	// Base is the built-in theme the colours are applied to; empty means
+	// auto.
+	Base string `yaml:"base"`
+	// Colors maps colour names to hex values or ANSI colour numbers.
+	Colors map[string]string `yaml:"colors"`
+}
+
+// ThemePath returns the file of a user theme: name itself if it is a path,
+// otherwise themes/<name>.yaml in Dir.
+func ThemePath(name string) (string, error) {
+	if filepath.Base(name) != name || filepath.Ext(name) != "" {
+		return name, nil
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "themes", name+".yaml"), nil
+}
+
+// LoadTheme reads a theme file. Colour names and values are checked when
+// the theme is applied.
+func LoadTheme(path string) (ThemeFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ThemeFile{}, err
+	}
+	t, err := parseTheme(data)
+	if err != nil {
+		return ThemeFile{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+func parseTheme(data []byte) (ThemeFile, error) {
+	var t ThemeFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&t); err != nil && !errors.Is(err, io.EOF) {
+		return ThemeFile{}, err
+	}
+	return t, nil
+}
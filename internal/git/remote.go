@@ -0,0 +1,154 @@
+// Package git finds the GitHub repository of a local checkout from its git
+// remotes, the way gh does when run inside a clone.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// Remote is a git remote that points at a GitHub repository.
+type Remote struct {
+	Name  string
+	Host  string
+	Owner string
+	Repo  string
+}
+
+func (r Remote) NWO() string {
+	return r.Owner + "/" + r.Repo
+}
+
+// ErrNoRemote is returned when a checkout has no GitHub remote.
+var ErrNoRemote = errors.New("no git remote points to a GitHub repository")
+
+// ParseRemoteURL extracts the host, owner and repository from a remote URL:
+// https://host/owner/repo(.git), ssh://[user@]host[:port]/owner/repo(.git),
+// git://host/owner/repo.git or the scp-like [user@]host:owner/repo(.git).
+func ParseRemoteURL(raw string) (host, owner, repo string, err error) {
+	raw = strings.TrimSpace(raw)
+	var path string
+	if i := strings.Index(raw, "://"); i >= 0 {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return "", "", "", fmt.Errorf("parse remote %q: %w", raw, err)
+		}
+		switch u.Scheme {
+		case "https", "http", "ssh", "git", "git+ssh", "ssh+git":
+		default:
+			return "", "", "", fmt.Errorf("remote %q: unsupported scheme %q", raw, u.Scheme)
+		}
+		host, path = u.Hostname(), u.Path
+	} else if at := strings.Index(raw, ":"); at > 0 && !strings.Contains(raw[:at], "/") {
+		// scp-like syntax: [user@]host:path
+		host, path = raw[:at], raw[at+1:]
+		if i := strings.LastIndex(host, "@"); i >= 0 {
+			host = host[i+1:]
+		}
+	} else {
+		return "", "", "", fmt.Errorf("remote %q is not a URL", raw)
+	}
+
+	host = strings.ToLower(host)
+	if host == "ssh.github.com" || host == "www.github.com" {
+		host = "github.com"
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("remote %q is not an owner/repo URL", raw)
+	}
+	repo = strings.TrimSuffix(parts[1], ".git")
+	if repo == "" {
+		return "", "", "", fmt.Errorf("remote %q is not an owner/repo URL", raw)
+	}
+	return host, parts[0], repo, nil
+}
+
+// parseRemotes reads the output of `git remote -v`. Each remote's fetch URL
+// is used; remotes that are not owner/repo URLs are skipped.
+func parseRemotes(out string) []Remote {
+	var remotes []Remote
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || seen[fields[0]] {
+			continue
+		}
+		if len(fields) == 3 && fields[2] != "(fetch)" {
+			continue
+		}
+		host, owner, repo, err := ParseRemoteURL(fields[1])
+		if err != nil {
+			continue
+		}
+		seen[fields[0]] = true
+		remotes = append(remotes, Remote{Name: fields[0], Host: host, Owner: owner, Repo: repo})
+	}
+	return remotes
+}
+
+// Pick chooses the remote gha-tui follows: upstream, then origin, then the
+// first one listed. With a host, only remotes on that host are considered.
+func Pick(remotes []Remote, host string) (Remote, error) {
+	var candidates []Remote
+	for _, r := range remotes {
+		if host == "" || strings.EqualFold(r.Host, host) {
+			candidates = append(candidates, r)
+		}
+	}
+	for _, name := range []string{"upstream", "origin"} {
+		for _, r := range candidates {
+			if r.Name == name {
+				return r, nil
+			}
+		}
+	}
+	if len(candidates) > 0 {
+		return candidates[0], nil
+	}
+	if host != "" {
+		return Remote{}, fmt.Errorf("%w on %s", ErrNoRemote, host)
+	}
+	return Remote{}, ErrNoRemote
+}
+
+// Remotes lists the GitHub remotes of the checkout at dir.
+func Remotes(dir string) ([]Remote, error) {
+	out, err := run(dir, "remote", "-v")
+	if err != nil {
+		return nil, err
+	}
+	return parseRemotes(out), nil
+}
+
+// CurrentBranch returns the branch checked out at dir, or "" when HEAD is
+// detached.
+func CurrentBranch(dir string) (string, error) {
+	out, err := run(dir, "symbolic-ref", "--quiet", "--short", "HEAD")
+	if err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) && exit.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func run(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s: %w", args[0], msg, err)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
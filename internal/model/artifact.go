@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// Artifact represents a GitHub Actions workflow run artifact.
+type Artifact struct {
+	ID                 int64               `json:"id"`
+	Name               string              `json:"name"`
+	SizeInBytes        int64               `json:"size_in_bytes"`
+	ArchiveDownloadURL string              `json:"archive_download_url"`
+	Expired            bool                `json:"expired"`
+	Digest             string              `json:"digest"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+	ExpiresAt          time.Time           `json:"expires_at"`
+	WorkflowRun        ArtifactWorkflowRun `json:"workflow_run"`
+}
+
+// ArtifactWorkflowRun is the subset of run data embedded in an artifact.
+type ArtifactWorkflowRun struct {
+	ID         int64  `json:"id"`
+	HeadBranch string `json:"head_branch"`
+	HeadSHA    string `json:"head_sha"`
+}
+
+// ArtifactsResponse is the API response for listing artifacts.
+type ArtifactsResponse struct {
+	TotalCount int        `json:"total_count"`
+	Artifacts  []Artifact `json:"artifacts"`
+}
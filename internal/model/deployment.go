@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+// PendingDeployment is an environment a waiting run needs approval for.
+type PendingDeployment struct {
+	Environment           DeploymentEnvironment `json:"environment"`
+	WaitTimer             int                   `json:"wait_timer"` // minutes
+	WaitTimerStartedAt    *time.Time            `json:"wait_timer_started_at"`
+	CurrentUserCanApprove bool                  `json:"current_user_can_approve"`
+	Reviewers             []DeploymentReviewer  `json:"reviewers"`
+}
+
+// DeploymentEnvironment identifies the environment of a pending deployment.
+type DeploymentEnvironment struct {
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	HTMLURL string `json:"html_url"`
+}
+
+// DeploymentReviewer is a user or team allowed to approve a deployment.
+type DeploymentReviewer struct {
+	Type     string `json:"type"` // "User" or "Team"
+	Reviewer struct {
+		Login string `json:"login"` // users
+		Slug  string `json:"slug"`  // teams
+		Name  string `json:"name"`
+	} `json:"reviewer"`
+}
+
+// DisplayName returns the user login or the team name.
+func (r DeploymentReviewer) DisplayName() string {
+	switch {
+	case r.Reviewer.Login != "":
+		return r.Reviewer.Login
+	case r.Reviewer.Slug != "":
+		return "@" + r.Reviewer.Slug
+	}
+	return r.Reviewer.Name
+}
+
+// WaitRemaining returns how long the environment's wait timer still runs,
+// or 0 when there is no timer or it has elapsed.
+func (d PendingDeployment) WaitRemaining(now time.Time) time.Duration {
+	if d.WaitTimer <= 0 || d.WaitTimerStartedAt == nil {
+		return 0
+	}
+	end := d.WaitTimerStartedAt.Add(time.Duration(d.WaitTimer) * time.Minute)
+	if left := end.Sub(now); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// DeploymentReviewState is the decision submitted for pending deployments.
+type DeploymentReviewState string
+
+const (
+	DeploymentApproved DeploymentReviewState = "approved"
+	DeploymentRejected DeploymentReviewState = "rejected"
+)
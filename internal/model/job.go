@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type Job struct {
 	ID          int64         `json:"id"`
@@ -9,6 +13,7 @@ type Job struct {
 	Name        string        `json:"name"`
 	Status      RunStatus     `json:"status"`
 	Conclusion  RunConclusion `json:"conclusion"`
+	CreatedAt   time.Time     `json:"created_at"` // when the job was queued
 	StartedAt   time.Time     `json:"started_at"`
 	CompletedAt time.Time     `json:"completed_at"`
 	Steps       []Step        `json:"steps"`
@@ -37,6 +42,50 @@ func (j Job) Duration() time.Duration {
 	return j.CompletedAt.Sub(j.StartedAt)
 }
 
+func (s Step) Duration() time.Duration {
+	if s.CompletedAt.IsZero() || s.StartedAt.IsZero() {
+		return 0
+	}
+	return s.CompletedAt.Sub(s.StartedAt)
+}
+
+// QueueTime is how long the job waited for a runner.
+func (j Job) QueueTime() time.Duration {
+	if j.CreatedAt.IsZero() || j.StartedAt.IsZero() || j.StartedAt.Before(j.CreatedAt) {
+		return 0
+	}
+	return j.StartedAt.Sub(j.CreatedAt)
+}
+
 func (j Job) Failed() bool {
 	return j.Conclusion == ConclusionFailure
 }
+
+// Annotation is a message attached to a job's check run, e.g. by
+// `::error file=app.go,line=3::boom` or a failing step.
+type Annotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"` // "notice", "warning" or "failure"
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+// Location returns "path:line" for annotations that point at a source file.
+// Job-level annotations use the placeholder path ".github" and return "".
+func (a Annotation) Location() string {
+	if a.Path == "" || a.Path == ".github" {
+		return ""
+	}
+	if a.StartLine > 0 {
+		return fmt.Sprintf("%s:%d", a.Path, a.StartLine)
+	}
+	return a.Path
+}
+
+// Summary returns the first line of the message.
+func (a Annotation) Summary() string {
+	msg, _, _ := strings.Cut(strings.TrimSpace(a.Message), "\n")
+	return strings.TrimSpace(msg)
+}
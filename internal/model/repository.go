@@ -0,0 +1,62 @@
+package model
+
+// Repository is the subset of repository metadata the app uses.
+type Repository struct {
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+	HTMLURL       string `json:"html_url"`
+	Archived      bool   `json:"archived"`
+}
+
+// ReposResponse is the list of an organization's repositories.
+type ReposResponse struct {
+	TotalCount   int
+	Repositories []Repository
+	Truncated    bool // the list stopped at the item limit
+}
+
+// RepoCacheUsage is the Actions cache usage of one repository.
+type RepoCacheUsage struct {
+	FullName    string `json:"full_name"`
+	SizeInBytes int64  `json:"active_caches_size_in_bytes"`
+	Count       int    `json:"active_caches_count"`
+}
+
+// RepoCacheUsageResponse is the API response for an organization's cache
+// usage by repository.
+type RepoCacheUsageResponse struct {
+	TotalCount int              `json:"total_count"`
+	Usages     []RepoCacheUsage `json:"repository_cache_usages"`
+}
+
+// RepoSummary is the state of one repository's workflows in the
+// organization overview.
+type RepoSummary struct {
+	LatestRun  *Run // latest run on the default branch; nil when there is none
+	InProgress int
+	Queued     int
+	Completed  int // runs completed in the window
+	Failed     int // runs in the window that concluded with failure
+}
+
+// FailureRate is the share of the window's completed runs that failed,
+// between 0 and 1.
+func (s RepoSummary) FailureRate() float64 {
+	if s.Completed == 0 {
+		return 0
+	}
+	return float64(s.Failed) / float64(s.Completed)
+}
+
+// Environment is a deployment environment configured on a repository.
+type Environment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// EnvironmentsResponse is the API response for listing environments.
+type EnvironmentsResponse struct {
+	TotalCount   int           `json:"total_count"`
+	Environments []Environment `json:"environments"`
+}
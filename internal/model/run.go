@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type RunStatus string
 
@@ -31,12 +34,14 @@ type Run struct {
 	Status       RunStatus     `json:"status"`
 	Conclusion   RunConclusion `json:"conclusion"`
 	WorkflowID   int64         `json:"workflow_id"`
+	Path         string        `json:"path"`
 	RunNumber    int           `json:"run_number"`
 	RunAttempt   int           `json:"run_attempt"`
 	Event        string        `json:"event"`
 	HeadBranch   string        `json:"head_branch"`
 	HeadSHA      string        `json:"head_sha"`
 	Actor        Actor         `json:"actor"`
+	Repository   Repository    `json:"repository"`
 	CreatedAt    time.Time     `json:"created_at"`
 	UpdatedAt    time.Time     `json:"updated_at"`
 	RunStartedAt time.Time     `json:"run_started_at"`
@@ -68,3 +73,14 @@ func (r Run) ShortSHA() string {
 	}
 	return r.HeadSHA
 }
+
+// WorkflowPath returns the repository path of the run's workflow file, or ""
+// for runs without one, such as Dependabot's dynamic workflows. Runs of
+// reusable workflows report "path@ref"; the ref is dropped.
+func (r Run) WorkflowPath() string {
+	path, _, _ := strings.Cut(r.Path, "@")
+	if !strings.HasPrefix(path, ".github/workflows/") {
+		return ""
+	}
+	return path
+}
@@ -1,12 +1,12 @@
 package model
 
 type SearchResult struct {
-	RunID    int64
-	JobID    int64
-	JobName  string
-	StepName string
-	Line     int
-	Content  string
+	RunID    int64  `json:"run_id"`
+	JobID    int64  `json:"job_id,omitempty"`
+	JobName  string `json:"job_name"`
+	StepName string `json:"step_name,omitempty"`
+	Line     int    `json:"line"`
+	Content  string `json:"content"`
 }
 
 type SearchQuery struct {
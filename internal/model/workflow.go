@@ -25,3 +25,41 @@ type WorkflowsResponse struct {
 	TotalCount int        `json:"total_count"`
 	Workflows  []Workflow `json:"workflows"`
 }
+
+// DispatchInputType is the declared type of a workflow_dispatch input.
+type DispatchInputType string
+
+const (
+	DispatchInputString      DispatchInputType = "string"
+	DispatchInputChoice      DispatchInputType = "choice"
+	DispatchInputBoolean     DispatchInputType = "boolean"
+	DispatchInputEnvironment DispatchInputType = "environment"
+	DispatchInputNumber      DispatchInputType = "number"
+)
+
+// DispatchInput is one input declared under on.workflow_dispatch.inputs.
+type DispatchInput struct {
+	Name        string
+	Description string
+	Type        DispatchInputType
+	Required    bool
+	Default     string
+	Options     []string
+}
+
+// WorkflowJob is a job as declared under jobs: in a workflow file.
+type WorkflowJob struct {
+	ID    string   // key under jobs:
+	Name  string   // name:, may contain ${{ }} expressions
+	Needs []string // IDs of the jobs it depends on
+	Uses  string   // reusable workflow it calls, if any
+}
+
+// DisplayName is the name GitHub shows for the job before expressions and
+// matrix values are filled in.
+func (j WorkflowJob) DisplayName() string {
+	if j.Name != "" {
+		return j.Name
+	}
+	return j.ID
+}
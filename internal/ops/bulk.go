@@ -47,6 +47,10 @@ type BulkDeleteResult struct {
 	Completed int
 	Failed    int
 	Errors    []error
+	// Remaining holds the IDs that were not deleted, either because they
+	// failed or because ctx was cancelled first. Pass it back to
+	// BulkDeleteRuns to resume.
+	Remaining []int64
 }
 
 func BulkDeleteRuns(ctx context.Context, client *api.Client, runIDs []int64, onProgress func(completed, total int)) (*BulkDeleteResult, error) {
@@ -54,16 +58,18 @@ func BulkDeleteRuns(ctx context.Context, client *api.Client, runIDs []int64, onP
 	total := len(runIDs)
 
 	for i, id := range runIDs {
-		select {
-		case <-ctx.Done():
-			return result, ctx.Err()
-		default:
+		// Pace deletes by the remaining API budget; blocks until the
+		// reset when it is exhausted.
+		if err := client.RateLimit().Wait(ctx); err != nil {
+			result.Remaining = append(result.Remaining, runIDs[i:]...)
+			return result, err
 		}
 
-		err := client.DeleteRun(id)
+		err := client.DeleteRun(ctx, id)
 		if err != nil {
 			result.Failed++
 			result.Errors = append(result.Errors, fmt.Errorf("run %d: %w", id, err))
+			result.Remaining = append(result.Remaining, id)
 		} else {
 			result.Completed++
 		}
@@ -1,9 +1,14 @@
 package ops
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/altinukshini/gha-tui/internal/api"
 	"github.com/altinukshini/gha-tui/internal/model"
 )
 
@@ -66,3 +71,44 @@ func TestFilterRunsForDeletion(t *testing.T) {
 		})
 	}
 }
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestBulkDeleteRunsRemaining(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status := http.StatusNoContent
+		if strings.HasSuffix(req.URL.Path, "/runs/2") {
+			status = http.StatusConflict
+		}
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"message":"Cannot delete"}`)),
+			Request:    req,
+		}, nil
+	})
+	client, err := api.NewClient("o", "r", api.Options{Host: "github.com", AuthToken: "x", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := BulkDeleteRuns(context.Background(), client, []int64{1, 2, 3}, nil)
+	if err != nil {
+		t.Fatalf("BulkDeleteRuns() error = %v", err)
+	}
+	if result.Completed != 2 || result.Failed != 1 {
+		t.Errorf("completed/failed = %d/%d, want 2/1", result.Completed, result.Failed)
+	}
+	if len(result.Remaining) != 1 || result.Remaining[0] != 2 {
+		t.Errorf("Remaining = %v, want [2]", result.Remaining)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = BulkDeleteRuns(ctx, client, []int64{4, 5}, nil)
+	if err == nil || len(result.Remaining) != 2 {
+		t.Errorf("cancelled: err = %v, Remaining = %v, want both IDs", err, result.Remaining)
+	}
+}
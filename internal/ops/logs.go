@@ -0,0 +1,151 @@
+package ops
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/cache"
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// LoadAttemptLogs returns the job logs of one attempt of a run from the log
+// cache, downloading and caching the attempt's archive when needed.
+func LoadAttemptLogs(ctx context.Context, client *api.Client, logCache *cache.LogCache, run *model.Run, attempt int) (map[string]string, error) {
+	runID := run.ID
+	// Check cache first
+	if logCache.HasRun(runID, attempt) {
+		if logs, err := logCache.GetAllJobLogs(runID, attempt); err == nil && len(logs) > 0 {
+			return logs, nil
+		}
+	}
+
+	// Download from API
+	var body io.ReadCloser
+	var err error
+	if attempt == run.RunAttempt {
+		body, err = client.DownloadRunLogs(ctx, runID)
+	} else {
+		body, err = client.DownloadRunAttemptLogs(ctx, runID, attempt)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, storeErr := logCache.StoreRunLogs(runID, attempt, body)
+	body.Close()
+	if storeErr != nil {
+		return nil, storeErr
+	}
+
+	logCache.WriteMeta(runID, attempt, cache.CacheMeta{
+		RunID:        runID,
+		Attempt:      attempt,
+		WorkflowName: run.Name,
+		DisplayTitle: run.DisplayTitle,
+		Branch:       run.HeadBranch,
+		Actor:        run.Actor.Login,
+		Event:        run.Event,
+		CreatedAt:    run.CreatedAt,
+		StoredAt:     time.Now(),
+	})
+
+	return logCache.GetAllJobLogs(runID, attempt)
+}
+
+// LoadRunLogs returns the job logs of every attempt of a run, merged.
+//
+// For multi-attempt runs, all attempts are downloaded in parallel and
+// merged. Later attempts only overwrite if the new content is longer,
+// because GitHub includes stub entries (just system.txt) for jobs that
+// didn't re-run in the later attempt — those stubs must not replace real
+// logs. Attempts that fail to load are skipped, so the result may be
+// partial or empty; only cancellation of ctx is an error.
+func LoadRunLogs(ctx context.Context, client *api.Client, logCache *cache.LogCache, run *model.Run) (map[string]string, error) {
+	attempts := make([]map[string]string, run.RunAttempt)
+	var wg sync.WaitGroup
+	for att := 1; att <= run.RunAttempt; att++ {
+		wg.Add(1)
+		go func(att int) {
+			defer wg.Done()
+			if logs, err := LoadAttemptLogs(ctx, client, logCache, run, att); err == nil {
+				attempts[att-1] = logs
+			}
+		}(att)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return mergeAttemptLogs(attempts), nil
+}
+
+// mergeAttemptLogs merges the logs of attempts in order (attempt 1, 2, ...);
+// longer content wins.
+func mergeAttemptLogs(attempts []map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, logs := range attempts {
+		for k, v := range logs {
+			if existing, ok := merged[k]; !ok || len(v) > len(existing) {
+				merged[k] = v
+			}
+		}
+	}
+	return merged
+}
+
+// LookupJobLog finds a job's log in logs by exact name, then partial match.
+func LookupJobLog(logs map[string]string, name string) (string, bool) {
+	if content, ok := logs[name]; ok {
+		return content, true
+	}
+	for k, v := range logs {
+		if strings.Contains(k, name) || strings.Contains(name, k) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// IsSystemStub returns true if log content is just a system.txt stub
+// (GitHub includes these for jobs that didn't re-run in a later attempt).
+func IsSystemStub(content string) bool {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return true
+	}
+	// Subdirectory fallback format: only has "=== system.txt ===" section
+	if strings.HasPrefix(trimmed, "=== system.txt ===") {
+		// Check if there's any other === section (actual step logs)
+		rest := trimmed[len("=== system.txt ==="):]
+		return !strings.Contains(rest, "=== ")
+	}
+	// Short content without actual log lines (just runner metadata)
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) < 15 {
+		allSystem := true
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "=== ") {
+				continue
+			}
+			// System metadata lines contain evaluation info, runner info
+			if !strings.Contains(line, "Evaluating") &&
+				!strings.Contains(line, "Expanded:") &&
+				!strings.Contains(line, "Result:") &&
+				!strings.Contains(line, "Waiting for") &&
+				!strings.Contains(line, "Requested labels:") &&
+				!strings.Contains(line, "Job defined at:") &&
+				!strings.Contains(line, "Job is about to start") &&
+				!strings.Contains(line, "runner") {
+				allSystem = false
+				break
+			}
+		}
+		return allSystem
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package ops
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/cache"
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func logArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.WriteString(w, content)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoadRunLogsMergesAttempts(t *testing.T) {
+	archives := map[string][]byte{
+		// Attempt 1 ran both jobs; attempt 2 only re-ran test, leaving a
+		// stub for build.
+		"/repos/o/r/actions/runs/7/attempts/1/logs": logArchive(t, map[string]string{
+			"0_build.txt": "compiling\nlinking\ndone\n",
+			"1_test.txt":  "FAIL\n",
+		}),
+		"/repos/o/r/actions/runs/7/logs": logArchive(t, map[string]string{
+			"0_build.txt": "\n",
+			"1_test.txt":  "ok after retry\n",
+		}),
+	}
+	var requests atomic.Int32
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		body, ok := archives[req.URL.Path]
+		status := http.StatusOK
+		if !ok {
+			status, body = http.StatusNotFound, []byte(`{"message":"Not Found"}`)
+		}
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    req,
+		}, nil
+	})
+	client, err := api.NewClient("o", "r", api.Options{Host: "github.com", AuthToken: "x", Transport: transport})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logCache, err := cache.NewLogCache(t.TempDir(), 10, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := &model.Run{ID: 7, RunAttempt: 2}
+
+	logs, err := LoadRunLogs(context.Background(), client, logCache, run)
+	if err != nil {
+		t.Fatalf("LoadRunLogs() error = %v", err)
+	}
+	if got := logs["build"]; got != "compiling\nlinking\ndone\n" {
+		t.Errorf("build = %q, want attempt 1's log", got)
+	}
+	if got := logs["test"]; got != "ok after retry\n" {
+		t.Errorf("test = %q, want attempt 2's log", got)
+	}
+
+	// A second load is served from the log cache.
+	requests.Store(0)
+	if _, err := LoadRunLogs(context.Background(), client, logCache, run); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("second load made %d requests, want 0", n)
+	}
+}
+
+func TestLookupJobLog(t *testing.T) {
+	logs := map[string]string{"build (linux)": "a", "test": "b"}
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"test", "b", true},
+		{"build", "a", true},
+		{"deploy", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := LookupJobLog(logs, tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LookupJobLog(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIsSystemStub(t *testing.T) {
+	stub := "=== system.txt ===\nRequested labels: ubuntu-latest\nJob defined at: o/r/.github/workflows/ci.yml@refs/heads/main\n"
+	if !IsSystemStub(stub) {
+		t.Error("system.txt section not detected as a stub")
+	}
+	if IsSystemStub(stub + "=== 1_Run tests.txt ===\nok\n") {
+		t.Error("log with step sections detected as a stub")
+	}
+	if IsSystemStub(strings.Repeat("real output\n", 20)) {
+		t.Error("long log detected as a stub")
+	}
+}
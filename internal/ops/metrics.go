@@ -0,0 +1,233 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/cache"
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+const (
+	// maxCreatedResults is the most runs GitHub returns for a query filtered
+	// by creation time. Longer ranges are split.
+	maxCreatedResults = 1000
+	// maxRetention is the longest GitHub keeps runs. Older stored runs are
+	// pruned.
+	maxRetention = 400 * 24 * time.Hour
+	// settle keeps the latest runs out of the store's coverage for a while,
+	// since the runs list can lag behind their creation.
+	settle = 5 * time.Minute
+)
+
+// MetricsData is what the Metrics tab computes its metrics over.
+type MetricsData struct {
+	Runs []model.Run
+	Jobs []model.Job
+	// MissingJobs counts the completed runs whose jobs could not be fetched.
+	// They are fetched again next time.
+	MissingJobs int
+}
+
+// MetricsProgress reports how far LoadMetricsData has got.
+type MetricsProgress struct {
+	Stage string // "listing runs", then "fetching jobs" of the new ones
+	Done  int
+	Total int
+}
+
+func (p MetricsProgress) String() string {
+	return fmt.Sprintf("%s %d/%d", p.Stage, p.Done, p.Total)
+}
+
+// LoadMetricsData returns every run created since since, with the jobs of
+// the latest attempt of the completed ones. Completed runs are kept in
+// store, so only the runs created outside its coverage are listed, and only
+// new ones have their jobs fetched. onProgress, which may be nil, is called
+// from several goroutines, one call at a time.
+func LoadMetricsData(ctx context.Context, client *api.Client, store *cache.RunStore, since time.Time, listConcurrency int, onProgress func(MetricsProgress)) (*MetricsData, error) {
+	if onProgress == nil {
+		onProgress = func(MetricsProgress) {}
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	if oldest := now.Add(-maxRetention); since.Before(oldest) {
+		since = oldest
+	}
+	if err := store.Prune(now.Add(-maxRetention)); err != nil {
+		return nil, err
+	}
+
+	// List the window where the store's coverage does not reach. The new
+	// coverage runs from the start of the window, or of the old coverage
+	// when that started earlier.
+	type span struct{ from, to time.Time }
+	var spans []span
+	from := since
+	cov := store.Coverage()
+	if cov.Empty() || cov.Through.Before(since) {
+		spans = append(spans, span{since, now})
+	} else {
+		if since.Before(cov.From) {
+			spans = append(spans, span{since, cov.From})
+		} else {
+			from = cov.From
+		}
+		spans = append(spans, span{cov.Through, now})
+	}
+	l := &runLister{client: client, concurrency: listConcurrency, onProgress: onProgress}
+	var listed []model.Run
+	for _, s := range spans {
+		runs, err := l.list(ctx, s.from, s.to, false)
+		if err != nil {
+			return nil, err
+		}
+		listed = append(listed, runs...)
+	}
+
+	// Coverage ends at the first run still in progress: it is listed again
+	// next time, along with everything after it.
+	through := now.Add(-settle)
+	var fresh []model.Run
+	for _, r := range listed {
+		switch {
+		case r.Status != model.RunStatusCompleted:
+			if r.CreatedAt.Before(through) {
+				through = r.CreatedAt
+			}
+		case !store.Has(r.ID):
+			fresh = append(fresh, r)
+		}
+	}
+
+	jobs, missing := fetchJobs(ctx, client, store, fresh, onProgress)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if missing == 0 {
+		if err := store.SetCoverage(cache.Coverage{From: from, Through: through}); err != nil {
+			return nil, err
+		}
+	}
+
+	data := &MetricsData{MissingJobs: missing}
+	seen := make(map[int64]bool)
+	for _, r := range store.Runs(since) {
+		seen[r.Run.ID] = true
+		data.Runs = append(data.Runs, r.Run)
+		data.Jobs = append(data.Jobs, r.Jobs...)
+	}
+	for _, r := range listed {
+		if !seen[r.ID] && !r.CreatedAt.Before(since) {
+			seen[r.ID] = true
+			data.Runs = append(data.Runs, r)
+			data.Jobs = append(data.Jobs, jobs[r.ID]...)
+		}
+	}
+	return data, nil
+}
+
+// fetchJobs fetches the jobs of the latest attempt of completed runs, with
+// ten workers, and stores the runs. It returns the jobs by run and the
+// number of runs whose jobs could not be fetched or stored. Once ctx is done
+// the remaining runs are left alone; they are fetched next time.
+func fetchJobs(ctx context.Context, client *api.Client, store *cache.RunStore, runs []model.Run, onProgress func(MetricsProgress)) (map[int64][]model.Job, int) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		done    int
+		missing int
+	)
+	jobs := make(map[int64][]model.Job, len(runs))
+	queue := make(chan model.Run)
+	for range min(10, len(runs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range queue {
+				if client.RateLimit().Wait(ctx) != nil {
+					return
+				}
+				resp, err := client.ListJobs(ctx, r.ID, api.JobsFilter{Filter: "latest", PerPage: 100})
+				if err == nil {
+					err = store.Add(r, resp.Jobs)
+				}
+
+				mu.Lock()
+				if resp != nil {
+					jobs[r.ID] = resp.Jobs
+				}
+				if err != nil {
+					missing++
+				}
+				done++
+				onProgress(MetricsProgress{Stage: "fetching jobs", Done: done, Total: len(runs)})
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, r := range runs {
+		select {
+		case queue <- r:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+	return jobs, missing
+}
+
+// runLister lists runs by creation time, counting them for progress.
+type runLister struct {
+	client      *api.Client
+	concurrency int
+	onProgress  func(MetricsProgress)
+	done, total int
+}
+
+// list lists the runs created from from to to, both included, halving the
+// range until no part holds more than GitHub returns for one query. counted
+// is set for the parts of a range already added to the total.
+func (l *runLister) list(ctx context.Context, from, to time.Time, counted bool) ([]model.Run, error) {
+	created := from.Format(time.RFC3339) + ".." + to.Format(time.RFC3339)
+	probe, err := l.client.ListRuns(ctx, api.RunsFilter{Created: created, PerPage: 1})
+	if err != nil {
+		return nil, err
+	}
+	if !counted {
+		l.total += probe.TotalCount
+		l.onProgress(MetricsProgress{Stage: "listing runs", Done: l.done, Total: l.total})
+	}
+	if probe.TotalCount == 0 {
+		return nil, nil
+	}
+	if probe.TotalCount > maxCreatedResults && to.Sub(from) > time.Second {
+		mid := from.Add(to.Sub(from) / 2).Truncate(time.Second)
+		older, err := l.list(ctx, from, mid, true)
+		if err != nil {
+			return nil, err
+		}
+		newer, err := l.list(ctx, mid.Add(time.Second), to, true)
+		if err != nil {
+			return nil, err
+		}
+		return append(newer, older...), nil
+	}
+
+	done := l.done
+	resp, err := l.client.ListAllRuns(ctx, api.RunsFilter{Created: created}, api.ListOptions{
+		Concurrency: l.concurrency,
+		OnProgress: func(fetched, _ int) {
+			l.onProgress(MetricsProgress{Stage: "listing runs", Done: done + fetched, Total: l.total})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	l.done += len(resp.Runs)
+	return resp.Runs, nil
+}
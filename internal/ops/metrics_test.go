@@ -0,0 +1,190 @@
+package ops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/cache"
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// fakeRunsAPI serves the runs list, filtered by creation time and capped at
+// 1000 results like GitHub's, and one job per run.
+type fakeRunsAPI struct {
+	mu          sync.Mutex
+	runs        []model.Run
+	jobRequests map[int64]int
+}
+
+func (f *fakeRunsAPI) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var body any
+	header := http.Header{"Content-Type": {"application/json"}}
+	if id, ok := strings.CutSuffix(strings.TrimPrefix(req.URL.Path, "/repos/o/r/actions/runs/"), "/jobs"); ok {
+		runID, _ := strconv.ParseInt(id, 10, 64)
+		f.jobRequests[runID]++
+		body = model.JobsResponse{TotalCount: 1, Jobs: []model.Job{{ID: runID * 10, RunID: runID, Name: "build", Conclusion: model.ConclusionSuccess}}}
+	} else {
+		q := req.URL.Query()
+		lo, hi, _ := strings.Cut(q.Get("created"), "..")
+		from, _ := time.Parse(time.RFC3339, lo)
+		to, _ := time.Parse(time.RFC3339, hi)
+		var matched []model.Run
+		for _, r := range f.runs {
+			if !r.CreatedAt.Before(from) && !r.CreatedAt.After(to) {
+				matched = append(matched, r)
+			}
+		}
+		total := len(matched)
+		matched = matched[:min(total, maxCreatedResults)]
+		perPage, _ := strconv.Atoi(q.Get("per_page"))
+		page, _ := strconv.Atoi(q.Get("page"))
+		page = max(page, 1)
+		last := max((len(matched)+perPage-1)/perPage, 1)
+		if page < last {
+			link := func(n int) string {
+				u := *req.URL
+				q.Set("page", strconv.Itoa(n))
+				u.RawQuery = q.Encode()
+				return u.String()
+			}
+			header.Set("Link", fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, link(page+1), link(last)))
+		}
+		start := min((page-1)*perPage, len(matched))
+		body = model.RunsResponse{TotalCount: total, Runs: matched[start:min(start+perPage, len(matched))]}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewReader(data)), Request: req}, nil
+}
+
+func (f *fakeRunsAPI) jobRequestCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, c := range f.jobRequests {
+		n += c
+	}
+	return n
+}
+
+func newFakeRunsClient(t *testing.T, runs []model.Run) (*fakeRunsAPI, *api.Client, *cache.RunStore) {
+	t.Helper()
+	f := &fakeRunsAPI{runs: runs, jobRequests: make(map[int64]int)}
+	client, err := api.NewClient("o", "r", api.Options{Host: "github.com", AuthToken: "x", Transport: f})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := cache.OpenRunStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, client, store
+}
+
+func completedRun(id int64, created time.Time) model.Run {
+	return model.Run{ID: id, Name: "CI", Status: model.RunStatusCompleted, Conclusion: model.ConclusionSuccess, CreatedAt: created}
+}
+
+func TestLoadMetricsDataIsIncremental(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	running := model.Run{ID: 4, Status: model.RunStatusInProgress, CreatedAt: now.Add(-time.Hour)}
+	f, client, store := newFakeRunsClient(t, []model.Run{
+		running,
+		completedRun(3, now.Add(-2*time.Hour)),
+		completedRun(2, now.Add(-30*time.Hour)),
+		completedRun(1, now.Add(-50*time.Hour)),
+	})
+	since := now.Add(-72 * time.Hour)
+
+	data, err := LoadMetricsData(context.Background(), client, store, since, 3, nil)
+	if err != nil {
+		t.Fatalf("LoadMetricsData() error = %v", err)
+	}
+	if len(data.Runs) != 4 || len(data.Jobs) != 3 || f.jobRequestCount() != 3 {
+		t.Fatalf("first load: %d runs, %d jobs, %d job requests, want 4, 3, 3", len(data.Runs), len(data.Jobs), f.jobRequestCount())
+	}
+	if cov := store.Coverage(); !cov.From.Equal(since) || !cov.Through.Equal(running.CreatedAt) {
+		t.Errorf("coverage = %+v, want from the window to the run in progress", cov)
+	}
+
+	// The run in progress completes and a new one starts.
+	f.mu.Lock()
+	f.runs[0] = completedRun(4, running.CreatedAt)
+	f.runs = append([]model.Run{completedRun(5, now.Add(-10*time.Minute))}, f.runs...)
+	f.mu.Unlock()
+
+	data, err = LoadMetricsData(context.Background(), client, store, since, 3, nil)
+	if err != nil {
+		t.Fatalf("LoadMetricsData() error = %v", err)
+	}
+	if len(data.Runs) != 5 || len(data.Jobs) != 5 {
+		t.Errorf("second load: %d runs, %d jobs, want 5, 5", len(data.Runs), len(data.Jobs))
+	}
+	for id, n := range f.jobRequests {
+		if n != 1 {
+			t.Errorf("jobs of run %d fetched %d times", id, n)
+		}
+	}
+
+	// A shorter window comes from the store.
+	data, err = LoadMetricsData(context.Background(), client, store, now.Add(-24*time.Hour), 3, nil)
+	if err != nil {
+		t.Fatalf("LoadMetricsData() error = %v", err)
+	}
+	if len(data.Runs) != 3 || f.jobRequestCount() != 5 {
+		t.Errorf("24h window: %d runs after %d job requests, want 3 after 5", len(data.Runs), f.jobRequestCount())
+	}
+}
+
+func TestLoadMetricsDataSplitsLongRanges(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	var runs []model.Run
+	for i := range 2500 {
+		runs = append(runs, completedRun(int64(2500-i), now.Add(-time.Duration(i+1)*time.Minute)))
+	}
+	f, client, store := newFakeRunsClient(t, runs)
+
+	var last MetricsProgress
+	data, err := LoadMetricsData(context.Background(), client, store, now.Add(-72*time.Hour), 3, func(p MetricsProgress) {
+		last = p
+	})
+	if err != nil {
+		t.Fatalf("LoadMetricsData() error = %v", err)
+	}
+	if len(data.Runs) != 2500 || f.jobRequestCount() != 2500 {
+		t.Errorf("%d runs after %d job requests, want 2500 after 2500", len(data.Runs), f.jobRequestCount())
+	}
+	if want := (MetricsProgress{Stage: "fetching jobs", Done: 2500, Total: 2500}); last != want {
+		t.Errorf("last progress = %+v, want %+v", last, want)
+	}
+}
+
+func TestFetchJobsStopsWhenCancelled(t *testing.T) {
+	now := time.Now().UTC()
+	var runs []model.Run
+	for i := range 50 {
+		runs = append(runs, completedRun(int64(i+1), now.Add(-time.Duration(i+1)*time.Minute)))
+	}
+	f, client, store := newFakeRunsClient(t, runs)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, missing := fetchJobs(ctx, client, store, runs, func(MetricsProgress) {})
+	if missing != 0 || f.jobRequestCount() != 0 {
+		t.Errorf("after cancel: %d missing, %d job requests, want 0, 0", missing, f.jobRequestCount())
+	}
+}
@@ -2,12 +2,16 @@ package tui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -16,19 +20,28 @@ import (
 	"github.com/altinukshini/gha-tui/internal/cache"
 	"github.com/altinukshini/gha-tui/internal/config"
 	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ops"
 	"github.com/altinukshini/gha-tui/internal/search"
+	"github.com/altinukshini/gha-tui/internal/tui/artifactsview"
 	"github.com/altinukshini/gha-tui/internal/tui/cacheview"
+	"github.com/altinukshini/gha-tui/internal/tui/compare"
 	"github.com/altinukshini/gha-tui/internal/tui/confirm"
 	"github.com/altinukshini/gha-tui/internal/tui/dashboard"
+	"github.com/altinukshini/gha-tui/internal/tui/deployreview"
 	"github.com/altinukshini/gha-tui/internal/tui/details"
+	"github.com/altinukshini/gha-tui/internal/tui/dispatchform"
 	"github.com/altinukshini/gha-tui/internal/tui/filteroverlay"
 	"github.com/altinukshini/gha-tui/internal/tui/infoview"
+	"github.com/altinukshini/gha-tui/internal/tui/jobgraph"
 	"github.com/altinukshini/gha-tui/internal/tui/logview"
-	"github.com/altinukshini/gha-tui/internal/tui/runs"
 	"github.com/altinukshini/gha-tui/internal/tui/runnersview"
+	"github.com/altinukshini/gha-tui/internal/tui/runs"
 	"github.com/altinukshini/gha-tui/internal/tui/searchview"
+	"github.com/altinukshini/gha-tui/internal/tui/timeline"
 	"github.com/altinukshini/gha-tui/internal/tui/workflows"
+	"github.com/altinukshini/gha-tui/internal/tui/yamlview"
 	"github.com/altinukshini/gha-tui/internal/ui"
+	"github.com/altinukshini/gha-tui/internal/workflowyaml"
 )
 
 type View int
@@ -39,6 +52,7 @@ const (
 	ViewMetrics
 	ViewCache
 	ViewRunners
+	ViewArtifacts
 )
 
 type Pane int
@@ -52,6 +66,7 @@ const (
 type App struct {
 	cfg      config.Config
 	client   *api.Client
+	watched  []*api.Client // further repositories merged into the Runs tab
 	logCache *cache.LogCache
 	search   *search.Engine
 
@@ -70,9 +85,28 @@ type App struct {
 	filterOverlay filteroverlay.Model
 	workflows     []model.Workflow // cached for filter picker
 
+	// Workflow dispatch form (Workflows tab)
+	dispatchForm dispatchform.Model
+
+	// Pending deployment review overlay (Runs tab)
+	deployReview deployreview.Model
+
+	// Workflow file viewer (Runs and Workflows tabs)
+	yamlView yamlview.Model
+
+	// needs: graph of a run's jobs (Runs tab)
+	jobGraph jobgraph.Model
+
+	// Gantt timeline of a run's jobs (Runs tab)
+	timeline timeline.Model
+
+	// Side-by-side comparison of two marked runs (Runs tab)
+	compareView compare.Model
+
 	// New views
-	cacheView   cacheview.Model
-	runnersView runnersview.Model
+	cacheView     cacheview.Model
+	runnersView   runnersview.Model
+	artifactsView artifactsview.Model
 
 	// State
 	currentView   View
@@ -82,6 +116,7 @@ type App struct {
 	status        string
 	rateRemaining int
 	rateLimit     int
+	rateReset     time.Time // set while the budget is exhausted
 
 	// Cached log data for search
 	currentRunLogs map[string]string
@@ -90,6 +125,7 @@ type App struct {
 	// Pagination
 	runsPage       int
 	runsTotalCount int
+	runsPages      int // set when several repos are merged
 	runsHasMore    bool
 	runsLoading    bool
 
@@ -100,9 +136,19 @@ type App struct {
 	tailingJobID   int64
 	tailingJobName string
 
+	// Request scopes: superseded fetches are cancelled. runScope covers the
+	// selected run (jobs, logs, artifacts), tailScope the job being tailed
+	// and metricsScope the Metrics tab fan-out.
+	runScope     *requestScope
+	tailScope    *requestScope
+	metricsScope *requestScope
+
 	// Track the currently viewed job for failed-step jump
 	viewingJob *model.Job
 
+	// Check-run annotations by job ID
+	jobAnnotations map[int64][]model.Annotation
+
 	// Attempt cycling: 0 = latest/merged, 1+ = specific attempt
 	viewingAttempt int
 
@@ -110,30 +156,44 @@ type App struct {
 	helpViewport viewport.Model
 	helpReady    bool
 
-	logFullScreen bool
+	logFullScreen      bool
 	infoFullScreen     bool
 	infoStartedRefresh bool
 	cameFromSearch     bool
 }
 
-func NewApp(cfg config.Config, client *api.Client, logCache *cache.LogCache) App {
+// NewApp creates the app for client's repository. Runs of the watched
+// repositories are merged into the Runs tab. A config without settings gets
+// the defaults.
+func NewApp(cfg config.Config, client *api.Client, logCache *cache.LogCache, watched ...*api.Client) App {
+	if cfg.Settings == (config.Settings{}) {
+		cfg.Settings = config.DefaultSettings()
+	}
+	runsView := runs.New()
+	runsView.SetRepos(cfg.Repos())
 	return App{
-		cfg:            cfg,
-		client:         client,
-		logCache:       logCache,
-		search:         search.New(),
-		runsView:       runs.New(),
-		detailsView:    details.New(),
-		logView:        logview.New(),
-		infoView:       infoview.New(),
-		searchView:     searchview.New(),
-		workflowsView:  workflows.NewWithStats(),
-		dashboardView:  dashboard.New(),
-		cacheView:      cacheview.New(),
-		runnersView:    runnersview.New(),
-		currentView:    ViewRuns,
-		focusedPane:    PaneLeft,
-		status:         "Loading runs...",
+		cfg:           cfg,
+		client:        client,
+		watched:       watched,
+		logCache:      logCache,
+		search:        search.New(),
+		runsView:      runsView,
+		runsFilter:    filteroverlay.FilterResult{Branch: cfg.Branch},
+		detailsView:   details.New(),
+		logView:       logview.New(),
+		infoView:      infoview.New(),
+		searchView:    searchview.New(),
+		workflowsView: workflows.NewWithStats(),
+		dashboardView: dashboard.New(),
+		cacheView:     cacheview.New(),
+		runnersView:   runnersview.New(),
+		artifactsView: artifactsview.New(),
+		runScope:      newRequestScope(),
+		tailScope:     newRequestScope(),
+		metricsScope:  newRequestScope(),
+		currentView:   ViewRuns,
+		focusedPane:   PaneLeft,
+		status:        "Loading runs...",
 	}
 }
 
@@ -143,7 +203,7 @@ func (a App) Init() tea.Cmd {
 
 func (a App) fetchRetention() tea.Cmd {
 	return func() tea.Msg {
-		settings, err := a.client.GetRetentionSettings()
+		settings, err := a.client.GetRetentionSettings(context.Background())
 		if err != nil {
 			return ui.RetentionLoadedMsg{Err: err}
 		}
@@ -153,10 +213,28 @@ func (a App) fetchRetention() tea.Cmd {
 
 // --- Data fetching commands ---
 
-const runsPerPage = 30
+// listLimit caps how many items the list tabs load; anything beyond it is
+// reported in the status bar. listConcurrency is the number of pages fetched
+// in parallel.
+const (
+	listLimit       = 1000
+	listConcurrency = 3
+)
+
+func listOptions() api.ListOptions {
+	return api.ListOptions{MaxItems: listLimit, Concurrency: listConcurrency}
+}
+
+// limitNote is appended to a status line when a list was cut short.
+func limitNote(shown, total int) string {
+	if total > shown {
+		return fmt.Sprintf(" — showing first %d of %d", shown, total)
+	}
+	return ""
+}
 
 func (a App) fetchRuns() tea.Cmd {
-	filter := api.RunsFilter{PerPage: runsPerPage, Page: 1}
+	filter := api.RunsFilter{PerPage: a.cfg.RunsPerPage, Page: 1}
 	if a.runsFilter.WorkflowID != 0 {
 		filter.WorkflowID = a.runsFilter.WorkflowID
 	}
@@ -173,17 +251,17 @@ func (a App) fetchRuns() tea.Cmd {
 		filter.Actor = a.runsFilter.Actor
 	}
 	return func() tea.Msg {
-		resp, err := a.client.ListRuns(filter)
+		resp, pages, err := a.listRuns(context.Background(), filter)
 		if err != nil {
 			return ui.RunsLoadedMsg{Err: err}
 		}
-		return ui.RunsLoadedMsg{Runs: resp.Runs, TotalCount: resp.TotalCount}
+		return ui.RunsLoadedMsg{Runs: resp.Runs, TotalCount: resp.TotalCount, Pages: pages}
 	}
 }
 
 func (a App) refreshCurrentRuns() tea.Cmd {
 	page := a.runsPage
-	filter := api.RunsFilter{PerPage: runsPerPage, Page: page}
+	filter := api.RunsFilter{PerPage: a.cfg.RunsPerPage, Page: page}
 	if a.runsFilter.WorkflowID != 0 {
 		filter.WorkflowID = a.runsFilter.WorkflowID
 	}
@@ -200,16 +278,16 @@ func (a App) refreshCurrentRuns() tea.Cmd {
 		filter.Actor = a.runsFilter.Actor
 	}
 	return func() tea.Msg {
-		resp, err := a.client.ListRuns(filter)
+		resp, pages, err := a.listRuns(context.Background(), filter)
 		if err != nil {
 			return ui.RunsRefreshedMsg{Err: err}
 		}
-		return ui.RunsRefreshedMsg{Runs: resp.Runs, TotalCount: resp.TotalCount}
+		return ui.RunsRefreshedMsg{Runs: resp.Runs, TotalCount: resp.TotalCount, Pages: pages}
 	}
 }
 
 func (a App) fetchRunsPage(page int) tea.Cmd {
-	filter := api.RunsFilter{PerPage: runsPerPage, Page: page}
+	filter := api.RunsFilter{PerPage: a.cfg.RunsPerPage, Page: page}
 	if a.runsFilter.WorkflowID != 0 {
 		filter.WorkflowID = a.runsFilter.WorkflowID
 	}
@@ -226,17 +304,19 @@ func (a App) fetchRunsPage(page int) tea.Cmd {
 		filter.Actor = a.runsFilter.Actor
 	}
 	return func() tea.Msg {
-		resp, err := a.client.ListRuns(filter)
+		resp, pages, err := a.listRuns(context.Background(), filter)
 		if err != nil {
 			return ui.RunsPageMsg{Page: page, Err: err}
 		}
-		return ui.RunsPageMsg{Runs: resp.Runs, TotalCount: resp.TotalCount, Page: page}
+		return ui.RunsPageMsg{Runs: resp.Runs, TotalCount: resp.TotalCount, Pages: pages, Page: page}
 	}
 }
 
 func (a App) fetchJobs(runID int64) tea.Cmd {
+	ctx := a.runScope.Context()
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		resp, err := a.client.ListJobs(runID, api.JobsFilter{Filter: "latest", PerPage: 100})
+		resp, err := client.ListJobs(ctx, runID, api.JobsFilter{Filter: "latest", PerPage: 100})
 		if err != nil {
 			return ui.JobsLoadedMsg{RunID: runID, Err: err}
 		}
@@ -245,8 +325,10 @@ func (a App) fetchJobs(runID int64) tea.Cmd {
 }
 
 func (a App) fetchJobsForAttempt(runID int64, attempt int) tea.Cmd {
+	ctx := a.runScope.Context()
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		resp, err := a.client.ListJobsForAttempt(runID, attempt, api.JobsFilter{PerPage: 100})
+		resp, err := client.ListJobsForAttempt(ctx, runID, attempt, api.JobsFilter{PerPage: 100})
 		if err != nil {
 			return ui.JobsLoadedMsg{RunID: runID, Err: err}
 		}
@@ -255,155 +337,103 @@ func (a App) fetchJobsForAttempt(runID int64, attempt int) tea.Cmd {
 }
 
 func (a App) fetchLogsForAttempt(run *model.Run, attempt int) tea.Cmd {
+	ctx := a.runScope.Context()
 	return func() tea.Msg {
-		runID := run.ID
-		// Check cache first
-		if a.logCache.HasRun(runID, attempt) {
-			if logs, err := a.logCache.GetAllJobLogs(runID, attempt); err == nil && len(logs) > 0 {
-				return ui.LogsLoadedMsg{RunID: runID, Attempt: attempt, Logs: logs}
-			}
-		}
-
-		// Download from API
-		var body io.ReadCloser
-		var err error
-		if attempt == run.RunAttempt {
-			body, err = a.client.DownloadRunLogs(context.Background(), runID)
-		} else {
-			body, err = a.client.DownloadRunAttemptLogs(context.Background(), runID, attempt)
-		}
-		if err != nil {
-			return ui.LogsLoadedMsg{RunID: runID, Attempt: attempt, Err: err}
-		}
-
-		_, storeErr := a.logCache.StoreRunLogs(runID, attempt, body)
-		body.Close()
-		if storeErr != nil {
-			return ui.LogsLoadedMsg{RunID: runID, Attempt: attempt, Err: storeErr}
-		}
-
-		a.logCache.WriteMeta(runID, attempt, cache.CacheMeta{
-			RunID:        runID,
-			Attempt:      attempt,
-			WorkflowName: run.Name,
-			DisplayTitle: run.DisplayTitle,
-			Branch:       run.HeadBranch,
-			Actor:        run.Actor.Login,
-			Event:        run.Event,
-			CreatedAt:    run.CreatedAt,
-			StoredAt:     time.Now(),
-		})
-
-		logs, err := a.logCache.GetAllJobLogs(runID, attempt)
-		if err != nil {
-			return ui.LogsLoadedMsg{RunID: runID, Attempt: attempt, Err: err}
-		}
-		return ui.LogsLoadedMsg{RunID: runID, Attempt: attempt, Logs: logs}
+		logs, err := a.loadAttemptLogs(ctx, run, attempt)
+		return ui.LogsLoadedMsg{RunID: run.ID, Attempt: attempt, Logs: logs, Err: err}
 	}
 }
 
-func (a App) fetchLogs(run *model.Run) tea.Cmd {
-	return func() tea.Msg {
-		runID := run.ID
-		attempt := run.RunAttempt
-
-		// For multi-attempt runs, download all attempts in parallel and merge.
-		// Later attempts only overwrite if the new content is longer, because
-		// GitHub includes stub entries (just system.txt) for jobs that didn't
-		// re-run in the later attempt — those stubs must not replace real logs.
-
-		type attemptResult struct {
-			att  int
-			logs map[string]string
-			err  error
-		}
-
-		results := make([]attemptResult, attempt)
-		var wg sync.WaitGroup
-
-		for att := 1; att <= attempt; att++ {
-			wg.Add(1)
-			go func(att int) {
-				defer wg.Done()
-				r := attemptResult{att: att}
-
-				// Check cache first
-				if a.logCache.HasRun(runID, att) {
-					if logs, err := a.logCache.GetAllJobLogs(runID, att); err == nil && len(logs) > 0 {
-						r.logs = logs
-						results[att-1] = r
-						return
-					}
-				}
-
-				// Download from API
-				var body io.ReadCloser
-				var err error
-				if att == attempt {
-					body, err = a.client.DownloadRunLogs(context.Background(), runID)
-				} else {
-					body, err = a.client.DownloadRunAttemptLogs(context.Background(), runID, att)
-				}
-				if err != nil {
-					r.err = err
-					results[att-1] = r
-					return
-				}
-
-				_, storeErr := a.logCache.StoreRunLogs(runID, att, body)
-				body.Close()
-				if storeErr != nil {
-					r.err = storeErr
-					results[att-1] = r
-					return
-				}
+// loadAttemptLogs returns the job logs of one attempt of a run from the log
+// cache, downloading and caching the attempt's archive when needed.
+func (a App) loadAttemptLogs(ctx context.Context, run *model.Run, attempt int) (map[string]string, error) {
+	return ops.LoadAttemptLogs(ctx, a.clientFor(run), a.logCache, run, attempt)
+}
 
-				a.logCache.WriteMeta(runID, att, cache.CacheMeta{
-					RunID:        runID,
-					Attempt:      att,
-					WorkflowName: run.Name,
-					DisplayTitle: run.DisplayTitle,
-					Branch:       run.HeadBranch,
-					Actor:        run.Actor.Login,
-					Event:        run.Event,
-					CreatedAt:    run.CreatedAt,
-					StoredAt:     time.Now(),
-				})
+// logSource names one attempt of a run whose log is diffed.
+type logSource struct {
+	run     model.Run
+	attempt int
+	label   string
+}
 
-				if logs, err := a.logCache.GetAllJobLogs(runID, att); err == nil {
-					r.logs = logs
-				}
-				results[att-1] = r
-			}(att)
+// fetchLogDiff loads jobName's log from two attempts or runs for the log
+// diff. A job that did not run on one side is reported as an error.
+func (a App) fetchLogDiff(current model.Run, jobName string, base, head logSource) tea.Cmd {
+	ctx := a.runScope.Context()
+	return func() tea.Msg {
+		msg := ui.LogDiffLoadedMsg{
+			RunID:   current.ID,
+			JobName: jobName,
+			Title:   fmt.Sprintf("%s  diff %s -> %s", jobName, base.label, head.label),
+		}
+		for _, side := range []struct {
+			src logSource
+			out *string
+		}{{base, &msg.Base}, {head, &msg.Head}} {
+			logs, err := a.loadAttemptLogs(ctx, &side.src.run, side.src.attempt)
+			if err != nil {
+				msg.Err = fmt.Errorf("%s: %w", side.src.label, err)
+				return msg
+			}
+			content, ok := ops.LookupJobLog(logs, jobName)
+			if !ok || ops.IsSystemStub(content) {
+				msg.Err = fmt.Errorf("%s did not run in %s", jobName, side.src.label)
+				return msg
+			}
+			*side.out = content
 		}
-		wg.Wait()
+		return msg
+	}
+}
 
-		// Merge in order (attempt 1, 2, ...) — longer content wins
-		merged := make(map[string]string)
-		for _, r := range results {
-			if r.err != nil {
-				continue
-			}
-			for k, v := range r.logs {
-				if existing, ok := merged[k]; !ok || len(v) > len(existing) {
-					merged[k] = v
-				}
+// logDiffSources picks what the viewed job's log is diffed against: a run
+// marked for comparison if there is one, else the previous attempt.
+func (a App) logDiffSources(run model.Run) (base, head logSource, ok bool) {
+	marked := a.runsView.CompareRuns()
+	for i := len(marked) - 1; i >= 0; i-- {
+		if other := marked[i]; other.ID != run.ID {
+			base = logSource{other, other.RunAttempt, fmt.Sprintf("run #%d", other.RunNumber)}
+			head = logSource{run, run.RunAttempt, fmt.Sprintf("run #%d", run.RunNumber)}
+			if head.run.CreatedAt.Before(base.run.CreatedAt) {
+				base, head = head, base
 			}
+			return base, head, true
 		}
+	}
+	if run.RunAttempt < 2 {
+		return base, head, false
+	}
+	attempt := a.viewingAttempt
+	if attempt == 0 {
+		attempt = run.RunAttempt
+	}
+	if attempt == 1 {
+		attempt = 2
+	}
+	base = logSource{run, attempt - 1, fmt.Sprintf("attempt %d", attempt-1)}
+	head = logSource{run, attempt, fmt.Sprintf("attempt %d", attempt)}
+	return base, head, true
+}
 
+func (a App) fetchLogs(run *model.Run) tea.Cmd {
+	ctx := a.runScope.Context()
+	client := a.clientFor(run)
+	return func() tea.Msg {
 		// Return whatever we have (possibly empty). Individual job logs
 		// can still be fetched on demand via the per-job API endpoint.
-		return ui.LogsLoadedMsg{RunID: runID, Attempt: attempt, Logs: merged}
+		logs, err := ops.LoadRunLogs(ctx, client, a.logCache, run)
+		return ui.LogsLoadedMsg{RunID: run.ID, Attempt: run.RunAttempt, Logs: logs, Err: err}
 	}
 }
 
 func (a App) fetchWorkflows() tea.Cmd {
 	return func() tea.Msg {
-		resp, err := a.client.ListWorkflows(100, 1)
+		resp, err := a.client.ListWorkflows(context.Background(), listOptions())
 		if err != nil {
 			return ui.WorkflowsLoadedMsg{Err: err}
 		}
-		return ui.WorkflowsLoadedMsg{Workflows: resp.Workflows}
+		return ui.WorkflowsLoadedMsg{Workflows: resp.Workflows, TotalCount: resp.TotalCount}
 	}
 }
 
@@ -418,7 +448,7 @@ func (a App) fetchWorkflowStats(wfs []model.Workflow) tea.Cmd {
 			wg.Add(1)
 			go func(wf model.Workflow) {
 				defer wg.Done()
-				resp, err := client.ListRuns(api.RunsFilter{
+				resp, err := client.ListRuns(context.Background(), api.RunsFilter{
 					WorkflowID: wf.ID,
 					PerPage:    30,
 				})
@@ -446,68 +476,63 @@ func (a App) fetchWorkflowStats(wfs []model.Workflow) tea.Cmd {
 	}
 }
 
+// fetchDashboardData loads the window's runs through the repository's run
+// store, reporting progress while the store is filled.
 func (a App) fetchDashboardData(window dashboard.TimeWindow) tea.Cmd {
+	ctx := a.metricsScope.Context()
 	client := a.client
-	return func() tea.Msg {
-		since := time.Duration(window.Days) * 24 * time.Hour
-		createdAfter := time.Now().Add(-since).UTC().Format("2006-01-02")
-		resp, err := client.ListRuns(api.RunsFilter{
-			PerPage: 100,
-			Created: ">=" + createdAfter,
-		})
+	cfg := a.cfg
+	progress := make(chan ops.MetricsProgress, 1)
+	load := func() tea.Msg {
+		defer close(progress)
+		dir, err := cache.RunStoreDir(cfg.Host, cfg.Owner, cfg.Repo)
 		if err != nil {
 			return ui.DashboardDataMsg{Err: err}
 		}
-		allRuns := resp.Runs
-		totalCount := resp.TotalCount
-
-		// Fetch page 2 if there are more runs
-		if len(resp.Runs) >= 100 {
-			resp2, err := client.ListRuns(api.RunsFilter{
-				PerPage: 100,
-				Page:    2,
-				Created: ">=" + createdAfter,
-			})
-			if err == nil {
-				allRuns = append(allRuns, resp2.Runs...)
+		store, err := cache.OpenRunStore(dir)
+		if err != nil {
+			return ui.DashboardDataMsg{Err: err}
+		}
+		data, err := ops.LoadMetricsData(ctx, client, store, window.Since(time.Now()), listConcurrency, func(p ops.MetricsProgress) {
+			// A report the UI has not picked up yet is recent enough.
+			select {
+			case progress <- p:
+			default:
 			}
+		})
+		if err != nil {
+			return ui.DashboardDataMsg{Err: err}
 		}
+		return ui.DashboardDataMsg{Runs: data.Runs, Jobs: data.Jobs, MissingJobs: data.MissingJobs}
+	}
+	return tea.Batch(load, waitMetricsProgress(window.Label, progress))
+}
 
-		// Fetch jobs for up to 50 completed runs concurrently
-		var allJobs []model.Job
-		var mu sync.Mutex
-		sem := make(chan struct{}, 10) // 10 concurrent
-		var wg sync.WaitGroup
+// metricsProgressMsg is a progress report of the Metrics tab load of a
+// window.
+type metricsProgressMsg struct {
+	window   string
+	progress ops.MetricsProgress
+	next     <-chan ops.MetricsProgress
+}
 
-		jobCount := 0
-		for _, r := range allRuns {
-			if r.Status == model.RunStatusCompleted && jobCount < 50 {
-				jobCount++
-				wg.Add(1)
-				go func(runID int64) {
-					defer wg.Done()
-					sem <- struct{}{}
-					defer func() { <-sem }()
-					jobResp, err := client.ListJobs(runID, api.JobsFilter{Filter: "latest", PerPage: 100})
-					if err == nil {
-						mu.Lock()
-						allJobs = append(allJobs, jobResp.Jobs...)
-						mu.Unlock()
-					}
-				}(r.ID)
-			}
+// waitMetricsProgress waits for the next progress report, until the load is
+// over.
+func waitMetricsProgress(window string, ch <-chan ops.MetricsProgress) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-ch
+		if !ok {
+			return nil
 		}
-		wg.Wait()
-
-		return ui.DashboardDataMsg{Runs: allRuns, Jobs: allJobs, TotalCount: totalCount}
+		return metricsProgressMsg{window: window, progress: p, next: ch}
 	}
 }
 
 func (a App) fetchActionsCaches() tea.Cmd {
 	client := a.client
 	return func() tea.Msg {
-		// Fetch up to 100 caches sorted by last accessed
-		resp, err := client.ListActionsCaches(100, 1, "last_accessed_at", "desc")
+		// Most recently used first, up to listLimit
+		resp, err := client.ListActionsCaches(context.Background(), "last_accessed_at", "desc", listOptions())
 		if err != nil {
 			return ui.ActionsCachesLoadedMsg{Err: err}
 		}
@@ -521,110 +546,207 @@ func (a App) fetchActionsCaches() tea.Cmd {
 func (a App) deleteActionsCache(cacheID int64) tea.Cmd {
 	client := a.client
 	return func() tea.Msg {
-		err := client.DeleteActionsCache(cacheID)
+		err := client.DeleteActionsCache(context.Background(), cacheID)
 		return ui.ActionsCacheDeletedMsg{CacheID: cacheID, Err: err}
 	}
 }
 
 func (a App) deleteSelectedCaches(ids []int64) tea.Cmd {
 	client := a.client
+	concurrency := a.cfg.DeleteConcurrency
 	return func() tea.Msg {
-		var mu sync.Mutex
-		var lastErr error
-		deleted := 0
-		sem := make(chan struct{}, 3)
-		var wg sync.WaitGroup
-		for _, id := range ids {
-			wg.Add(1)
-			go func(id int64) {
-				defer wg.Done()
-				sem <- struct{}{}
-				defer func() { <-sem }()
-				if err := client.DeleteActionsCache(id); err != nil {
-					mu.Lock()
-					lastErr = err
-					mu.Unlock()
-				} else {
-					mu.Lock()
-					deleted++
-					mu.Unlock()
-				}
-			}(id)
-		}
-		wg.Wait()
-		if lastErr != nil {
-			return ui.ActionsCacheDeletedMsg{
-				Err: fmt.Errorf("deleted %d/%d caches, last error: %w", deleted, len(ids), lastErr),
-			}
+		return deleteCaches(client, ids, concurrency)
+	}
+}
+
+// deleteCaches deletes caches concurrency at a time; the ones that still
+// fail are returned in Remaining.
+func deleteCaches(client *api.Client, ids []int64, concurrency int) ui.ActionsCacheDeletedMsg {
+	out := deleteIDsConcurrently(func(int64) *api.Client { return client }, ids, concurrency, (*api.Client).DeleteActionsCache)
+	if out.err != nil {
+		return ui.ActionsCacheDeletedMsg{
+			Err:       fmt.Errorf("deleted %d/%d caches, last error: %w", out.deleted, len(ids), out.err),
+			Remaining: out.failed,
 		}
-		return ui.ActionsCacheDeletedMsg{CacheID: 0}
 	}
+	return ui.ActionsCacheDeletedMsg{}
 }
 
 func (a App) deleteAllActionsCaches() tea.Cmd {
 	client := a.client
+	concurrency := a.cfg.DeleteConcurrency
 	return func() tea.Msg {
 		// Fetch all caches across pages
+		resp, err := client.ListActionsCaches(context.Background(), "", "", api.ListOptions{Concurrency: listConcurrency})
+		if err != nil {
+			return ui.ActionsCacheDeletedMsg{Err: fmt.Errorf("fetch caches: %w", err)}
+		}
 		var allIDs []int64
-		for page := 1; ; page++ {
-			resp, err := client.ListActionsCaches(100, page, "", "")
-			if err != nil {
-				return ui.ActionsCacheDeletedMsg{Err: fmt.Errorf("fetch caches: %w", err)}
-			}
-			for _, c := range resp.ActionsCaches {
-				allIDs = append(allIDs, c.ID)
-			}
-			if len(resp.ActionsCaches) < 100 {
-				break
-			}
+		for _, c := range resp.ActionsCaches {
+			allIDs = append(allIDs, c.ID)
 		}
 		if len(allIDs) == 0 {
 			return ui.ActionsCacheDeletedMsg{Err: fmt.Errorf("no caches to delete")}
 		}
-		// Delete 3 at a time
-		var mu sync.Mutex
-		var lastErr error
-		deleted := 0
-		sem := make(chan struct{}, 3)
-		var wg sync.WaitGroup
-		for _, id := range allIDs {
-			wg.Add(1)
-			go func(id int64) {
-				defer wg.Done()
-				sem <- struct{}{}
-				defer func() { <-sem }()
-				if err := client.DeleteActionsCache(id); err != nil {
-					mu.Lock()
-					lastErr = err
-					mu.Unlock()
-				} else {
-					mu.Lock()
-					deleted++
-					mu.Unlock()
-				}
-			}(id)
+		return deleteCaches(client, allIDs, concurrency)
+	}
+}
+
+func (a App) fetchArtifacts() tea.Cmd {
+	client := a.client
+	return func() tea.Msg {
+		// Newest first, up to listLimit
+		resp, err := client.ListArtifacts(context.Background(), "", listOptions())
+		if err != nil {
+			return ui.ArtifactsLoadedMsg{Err: err}
 		}
-		wg.Wait()
-		if lastErr != nil {
-			return ui.ActionsCacheDeletedMsg{
-				Err: fmt.Errorf("deleted %d/%d caches, last error: %w", deleted, len(allIDs), lastErr),
+		return ui.ArtifactsLoadedMsg{
+			Artifacts:  resp.Artifacts,
+			TotalCount: resp.TotalCount,
+		}
+	}
+}
+
+func (a App) fetchRunArtifacts(runID int64) tea.Cmd {
+	ctx := a.runScope.Context()
+	client := a.runClient(runID)
+	return func() tea.Msg {
+		resp, err := client.ListRunArtifacts(ctx, runID)
+		if err != nil {
+			return ui.RunArtifactsLoadedMsg{RunID: runID, Err: err}
+		}
+		return ui.RunArtifactsLoadedMsg{RunID: runID, Artifacts: resp.Artifacts}
+	}
+}
+
+func (a App) deleteArtifact(client *api.Client, artifactID int64) tea.Cmd {
+	return func() tea.Msg {
+		err := client.DeleteArtifact(context.Background(), artifactID)
+		return ui.ArtifactDeletedMsg{ArtifactID: artifactID, Err: err}
+	}
+}
+
+func (a App) deleteSelectedArtifacts(ids []int64) tea.Cmd {
+	client := a.client
+	concurrency := a.cfg.DeleteConcurrency
+	return func() tea.Msg {
+		out := deleteIDsConcurrently(func(int64) *api.Client { return client }, ids, concurrency, (*api.Client).DeleteArtifact)
+		if out.err != nil {
+			return ui.ArtifactDeletedMsg{
+				Err:       fmt.Errorf("deleted %d/%d artifacts, last error: %w", out.deleted, len(ids), out.err),
+				Remaining: out.failed,
 			}
 		}
-		return ui.ActionsCacheDeletedMsg{CacheID: 0}
+		return ui.ArtifactDeletedMsg{}
+	}
+}
+
+// downloadArtifact saves an artifact's zip archive as <name>.zip in the
+// configured download directory. An existing file is kept: the archive is
+// saved as <name> (1).zip, <name> (2).zip and so on instead.
+func (a App) downloadArtifact(artifact model.Artifact) tea.Cmd {
+	client := a.runClient(artifact.WorkflowRun.ID)
+	dir := a.cfg.DownloadDir
+	return func() tea.Msg {
+		body, err := client.DownloadArtifact(context.Background(), artifact.ID)
+		if err != nil {
+			return ui.ArtifactDownloadedMsg{Name: artifact.Name, Err: err}
+		}
+		defer body.Close()
+
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return ui.ArtifactDownloadedMsg{Name: artifact.Name, Err: err}
+		}
+		f, path, renamed, err := createUnique(dir, filepath.Base(artifact.Name), ".zip")
+		if err != nil {
+			return ui.ArtifactDownloadedMsg{Name: artifact.Name, Err: err}
+		}
+		if _, err := io.Copy(f, body); err != nil {
+			f.Close()
+			os.Remove(path)
+			return ui.ArtifactDownloadedMsg{Name: artifact.Name, Err: err}
+		}
+		if err := f.Close(); err != nil {
+			return ui.ArtifactDownloadedMsg{Name: artifact.Name, Err: err}
+		}
+		return ui.ArtifactDownloadedMsg{Name: artifact.Name, Path: path, Renamed: renamed}
+	}
+}
+
+// createUnique creates <name><ext> in dir, or the first of <name> (1)<ext>,
+// <name> (2)<ext> and so on that does not exist yet. renamed reports whether
+// a number was added.
+func createUnique(dir, name, ext string) (f *os.File, path string, renamed bool, err error) {
+	for i := 0; ; i++ {
+		path = filepath.Join(dir, name+ext)
+		if i > 0 {
+			path = filepath.Join(dir, fmt.Sprintf("%s (%d)%s", name, i, ext))
+		}
+		f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if !errors.Is(err, os.ErrExist) {
+			return f, path, i > 0, err
+		}
 	}
 }
 
+// exportMetrics writes the metrics shown as a report in every export
+// format, named after the repository, window and date, in the configured
+// download directory.
+func (a App) exportMetrics(metrics dashboard.Metrics) tea.Cmd {
+	dir := a.cfg.DownloadDir
+	now := time.Now()
+	report := dashboard.NewReport(a.cfg.RepoNWO(), a.dashboardView.Window(), metrics, now)
+	base := fmt.Sprintf("gha-tui-metrics-%s-%s-%s-%s", a.cfg.Owner, a.cfg.Repo, report.Window, now.Format("20060102"))
+	return func() tea.Msg {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return ui.MetricsExportedMsg{Err: err}
+		}
+		var paths []string
+		for _, format := range dashboard.ExportFormats {
+			path := filepath.Join(dir, base+"."+format)
+			f, err := os.Create(path)
+			if err != nil {
+				return ui.MetricsExportedMsg{Paths: paths, Err: err}
+			}
+			err = report.Write(f, format)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return ui.MetricsExportedMsg{Paths: paths, Err: err}
+			}
+			paths = append(paths, path)
+		}
+		return ui.MetricsExportedMsg{Paths: paths}
+	}
+}
+
+// startArtifactDownload kicks off a download, refusing expired artifacts
+// up front since GitHub answers those with 410 Gone.
+func (a *App) startArtifactDownload(artifact *model.Artifact) tea.Cmd {
+	if artifact.Expired {
+		a.status = fmt.Sprintf("Artifact %s has expired", artifact.Name)
+		return nil
+	}
+	a.status = fmt.Sprintf("Downloading %s (%s)...", artifact.Name, ui.FormatSize(artifact.SizeInBytes))
+	return a.downloadArtifact(*artifact)
+}
+
 func (a App) fetchRunners() tea.Cmd {
 	return func() tea.Msg {
-		resp, err := a.client.ListRunners(100, 1)
+		resp, err := a.client.ListRunners(context.Background(), listOptions())
 		if err != nil {
 			return ui.RunnersLoadedMsg{Err: err}
 		}
 		runners := resp.Runners
+		truncated := resp.TotalCount > len(resp.Runners)
 
-		// Also fetch org-level runners (shared with this repo).
-		orgFailed := false
-		if orgResp, err := a.client.ListOrgRunners(100, 1); err == nil && orgResp != nil {
+		// Also fetch org-level runners (shared with this repo). A 404 means
+		// the owner is a user account, which has no org runners.
+		var orgErr error
+		if orgResp, err := a.client.ListOrgRunners(context.Background(), listOptions()); err == nil {
+			truncated = truncated || orgResp.TotalCount > len(orgResp.Runners)
 			seen := make(map[int64]bool, len(runners))
 			for _, r := range runners {
 				seen[r.ID] = true
@@ -634,36 +756,39 @@ func (a App) fetchRunners() tea.Cmd {
 					runners = append(runners, r)
 				}
 			}
-		} else if err != nil {
-			orgFailed = true
+		} else if !errors.Is(err, api.ErrNotFound) {
+			orgErr = err
 		}
 
-		return ui.RunnersLoadedMsg{Runners: runners, OrgFailed: orgFailed}
+		return ui.RunnersLoadedMsg{Runners: runners, OrgErr: orgErr, Truncated: truncated}
 	}
 }
 
+// scheduleRunsRefresh and the other polling schedulers stretch their interval
+// as the API budget shrinks and pause until the reset once it is exhausted.
 func (a App) scheduleRunsRefresh() tea.Cmd {
-	return tea.Tick(5*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(a.client.RateLimit().Interval(a.cfg.RunsRefresh), func(t time.Time) tea.Msg {
 		return ui.RunsTickMsg{}
 	})
 }
 
 func (a App) scheduleJobsRefresh(runID int64) tea.Cmd {
-	return tea.Tick(3*time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(a.client.RateLimit().Interval(a.cfg.JobsRefresh), func(t time.Time) tea.Msg {
 		return ui.JobsTickMsg{RunID: runID}
 	})
 }
 
 func (a App) scheduleLogRefresh(jobID int64, jobName string) tea.Cmd {
-	return tea.Tick(1500*time.Millisecond, func(t time.Time) tea.Msg {
+	return tea.Tick(a.client.RateLimit().Interval(a.cfg.LogRefresh), func(t time.Time) tea.Msg {
 		return ui.LogTailTickMsg{JobID: jobID, JobName: jobName}
 	})
 }
 
 func (a App) checkJobStatus(jobID int64, jobName string) tea.Cmd {
-	client := a.client
+	ctx := a.tailScope.Context()
+	client := a.detailsClient()
 	return func() tea.Msg {
-		job, err := client.GetJob(jobID)
+		job, err := client.GetJob(ctx, jobID)
 		if err != nil {
 			return ui.JobTailStatusMsg{JobID: jobID, JobName: jobName, Err: err}
 		}
@@ -676,9 +801,32 @@ func (a App) checkJobStatus(jobID int64, jobName string) tea.Cmd {
 	}
 }
 
+func (a App) fetchJobAnnotations(jobID int64) tea.Cmd {
+	ctx := a.runScope.Context()
+	client := a.detailsClient()
+	return func() tea.Msg {
+		annotations, err := client.ListJobAnnotations(ctx, jobID)
+		return ui.JobAnnotationsLoadedMsg{JobID: jobID, Annotations: annotations, Err: err}
+	}
+}
+
+// showAnnotations hands a job's annotations to the info and log views,
+// fetching them unless the job has completed and they are already known.
+func (a *App) showAnnotations(job *model.Job) tea.Cmd {
+	annotations, ok := a.jobAnnotations[job.ID]
+	a.infoView.SetAnnotations(job.ID, annotations)
+	a.logView.SetAnnotations(annotations)
+	if ok && job.Status == model.RunStatusCompleted {
+		return nil
+	}
+	return a.fetchJobAnnotations(job.ID)
+}
+
 func (a App) fetchJobLog(runID int64, jobID int64, jobName string) tea.Cmd {
+	ctx := a.runScope.Context()
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		body, err := a.client.DownloadJobLog(context.Background(), jobID)
+		body, err := client.DownloadJobLog(ctx, jobID)
 		if err != nil {
 			return ui.JobLogLoadedMsg{RunID: runID, JobID: jobID, JobName: jobName, Err: err}
 		}
@@ -694,50 +842,58 @@ func (a App) fetchJobLog(runID int64, jobID int64, jobName string) tea.Cmd {
 // --- Action commands ---
 
 func (a App) doRerunAll(runID int64) tea.Cmd {
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		err := a.client.RerunWorkflow(runID, false)
+		err := client.RerunWorkflow(context.Background(), runID, false)
 		return ui.ActionResultMsg{Action: "Rerun all", Err: err}
 	}
 }
 
 func (a App) doRerunFailed(runID int64) tea.Cmd {
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		err := a.client.RerunFailedJobs(runID, false)
+		err := client.RerunFailedJobs(context.Background(), runID, false)
 		return ui.ActionResultMsg{Action: "Rerun failed", Err: err}
 	}
 }
 
 func (a App) doRerunJob(jobID int64) tea.Cmd {
+	client := a.detailsClient()
 	return func() tea.Msg {
-		err := a.client.RerunJob(jobID, false)
+		err := client.RerunJob(context.Background(), jobID, false)
 		return ui.ActionResultMsg{Action: "Rerun job", Err: err}
 	}
 }
 
 func (a App) doDeleteRun(runID int64) tea.Cmd {
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		err := a.client.DeleteRun(runID)
+		err := client.DeleteRun(context.Background(), runID)
 		return ui.ActionResultMsg{Action: "Delete run", Err: err}
 	}
 }
 
 func (a App) doCancelRun(runID int64) tea.Cmd {
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		err := a.client.CancelRun(runID)
+		err := client.CancelRun(context.Background(), runID)
 		return ui.ActionResultMsg{Action: "Cancel run", Err: err}
 	}
 }
 
 func (a App) doForceCancelRun(runID int64) tea.Cmd {
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		err := a.client.ForceCancelRun(runID)
+		err := client.ForceCancelRun(context.Background(), runID)
 		return ui.ActionResultMsg{Action: "Force cancel run", Err: err}
 	}
 }
 
 func (a App) fetchRun(runID int64) tea.Cmd {
+	ctx := a.runScope.Context()
+	client := a.runClient(runID)
 	return func() tea.Msg {
-		run, err := a.client.GetRun(runID)
+		run, err := client.GetRun(ctx, runID)
 		if err != nil {
 			return ui.RunLoadedMsg{RunID: runID, Err: err}
 		}
@@ -747,82 +903,298 @@ func (a App) fetchRun(runID int64) tea.Cmd {
 
 func (a App) doEnableWorkflow(wfID int64) tea.Cmd {
 	return func() tea.Msg {
-		err := a.client.EnableWorkflow(wfID)
+		err := a.client.EnableWorkflow(context.Background(), wfID)
 		return ui.ActionResultMsg{Action: "Enable workflow", Err: err}
 	}
 }
 
 func (a App) doDisableWorkflow(wfID int64) tea.Cmd {
 	return func() tea.Msg {
-		err := a.client.DisableWorkflow(wfID)
+		err := a.client.DisableWorkflow(context.Background(), wfID)
 		return ui.ActionResultMsg{Action: "Disable workflow", Err: err}
 	}
 }
 
-func deleteIDsConcurrently(client *api.Client, ids []int64, action string) ui.ActionResultMsg {
-	var mu sync.Mutex
-	var lastErr error
-	deleted := 0
-	sem := make(chan struct{}, 3) // 3 concurrent
-	var wg sync.WaitGroup
-	for _, id := range ids {
+// fetchDispatchSpec reads a workflow file at ref (the default branch when ref
+// is empty) and parses its workflow_dispatch inputs.
+func (a App) fetchDispatchSpec(wf model.Workflow, ref string) tea.Cmd {
+	client := a.client
+	return func() tea.Msg {
+		if ref == "" {
+			repo, err := client.GetRepository(context.Background())
+			if err != nil {
+				return ui.DispatchSpecLoadedMsg{Workflow: wf, Err: err}
+			}
+			ref = repo.DefaultBranch
+		}
+		data, err := client.GetFileContent(context.Background(), wf.Path, ref)
+		if err != nil {
+			return ui.DispatchSpecLoadedMsg{Workflow: wf, Ref: ref, Err: err}
+		}
+		inputs, dispatchable, err := workflowyaml.ParseDispatchInputs(data)
+		if err != nil {
+			return ui.DispatchSpecLoadedMsg{Workflow: wf, Ref: ref, Err: err}
+		}
+
+		var envs []string
+		for _, in := range inputs {
+			if in.Type == model.DispatchInputEnvironment {
+				// Without environments the form falls back to free text.
+				if resp, err := client.ListEnvironments(context.Background()); err == nil {
+					for _, e := range resp.Environments {
+						envs = append(envs, e.Name)
+					}
+				}
+				break
+			}
+		}
+		return ui.DispatchSpecLoadedMsg{
+			Workflow:     wf,
+			Ref:          ref,
+			Inputs:       inputs,
+			Dispatchable: dispatchable,
+			Environments: envs,
+		}
+	}
+}
+
+// fetchWorkflowFile reads a workflow file of client's repository for the
+// YAML viewer. An empty ref reads the default branch.
+func (a App) fetchWorkflowFile(client *api.Client, path, ref string) tea.Cmd {
+	return func() tea.Msg {
+		data, err := client.GetFileContent(context.Background(), path, ref)
+		return ui.WorkflowFileLoadedMsg{Path: path, Ref: ref, Content: string(data), Err: err}
+	}
+}
+
+// openWorkflowFile shows the YAML viewer for path at ref and starts loading it.
+func (a *App) openWorkflowFile(client *api.Client, path, ref string) tea.Cmd {
+	a.yamlView.Open(path, ref)
+	a.propagateSize()
+	a.status = fmt.Sprintf("Loading %s...", path)
+	return a.fetchWorkflowFile(client, path, ref)
+}
+
+// runWorkflowPath returns the workflow file of a run, falling back to the
+// workflow list for runs whose path the API left out.
+func (a App) runWorkflowPath(run *model.Run) string {
+	if path := run.WorkflowPath(); path != "" {
+		return path
+	}
+	for _, wf := range a.workflows {
+		if wf.ID == run.WorkflowID && strings.HasPrefix(wf.Path, ".github/workflows/") {
+			return wf.Path
+		}
+	}
+	return ""
+}
+
+// fetchJobGraph reads the run's workflow file at its head commit for the
+// needs: declarations and lists the run's latest jobs.
+func (a App) fetchJobGraph(run model.Run) tea.Cmd {
+	path := a.runWorkflowPath(&run)
+	client := a.clientFor(&run)
+	return func() tea.Msg {
+		if path == "" {
+			return ui.JobGraphLoadedMsg{RunID: run.ID, Err: fmt.Errorf("run #%d has no workflow file in the repository", run.RunNumber)}
+		}
+		data, err := client.GetFileContent(context.Background(), path, run.HeadSHA)
+		if err != nil {
+			return ui.JobGraphLoadedMsg{RunID: run.ID, Err: err}
+		}
+		specs, err := workflowyaml.ParseJobs(data)
+		if err != nil {
+			return ui.JobGraphLoadedMsg{RunID: run.ID, Err: fmt.Errorf("%s: %w", path, err)}
+		}
+		resp, err := client.ListJobs(context.Background(), run.ID, api.JobsFilter{Filter: "latest", PerPage: 100})
+		if err != nil {
+			return ui.JobGraphLoadedMsg{RunID: run.ID, Err: err}
+		}
+		return ui.JobGraphLoadedMsg{RunID: run.ID, Specs: specs, Jobs: resp.Jobs}
+	}
+}
+
+// fetchCompareJobs lists the latest jobs of both runs being compared.
+func (a App) fetchCompareJobs(base, head model.Run) tea.Cmd {
+	baseClient, headClient := a.clientFor(&base), a.clientFor(&head)
+	return func() tea.Msg {
+		msg := ui.CompareJobsLoadedMsg{BaseRunID: base.ID, HeadRunID: head.ID}
+		baseResp, err := baseClient.ListJobs(context.Background(), base.ID, api.JobsFilter{Filter: "latest", PerPage: 100})
+		if err != nil {
+			msg.Err = fmt.Errorf("run #%d: %w", base.RunNumber, err)
+			return msg
+		}
+		headResp, err := headClient.ListJobs(context.Background(), head.ID, api.JobsFilter{Filter: "latest", PerPage: 100})
+		if err != nil {
+			msg.Err = fmt.Errorf("run #%d: %w", head.RunNumber, err)
+			return msg
+		}
+		msg.Base, msg.Head = baseResp.Jobs, headResp.Jobs
+		return msg
+	}
+}
+
+// openCompare shows the comparison of runs a and b and loads their jobs.
+func (a *App) openCompare(x, y model.Run) tea.Cmd {
+	url := ""
+	sameRepo := a.clientFor(&x) == a.clientFor(&y)
+	if sameRepo && x.HeadSHA != "" && y.HeadSHA != "" && x.HeadSHA != y.HeadSHA {
+		base, head := x, y
+		if head.CreatedAt.Before(base.CreatedAt) {
+			base, head = head, base
+		}
+		url = a.clientFor(&x).WebURL("compare/" + base.HeadSHA + "..." + head.HeadSHA)
+	}
+	a.compareView.Open(x, y, url)
+	a.propagateSize()
+	base, head := a.compareView.Runs()
+	a.status = fmt.Sprintf("Comparing run #%d with #%d...", base.RunNumber, head.RunNumber)
+	return a.fetchCompareJobs(base, head)
+}
+
+// fetchRunTimeline lists the run's latest jobs with their steps. The
+// workflow file's needs: are read too so the critical path can follow them;
+// without it the path is inferred from timing alone.
+func (a App) fetchRunTimeline(run model.Run) tea.Cmd {
+	path := a.runWorkflowPath(&run)
+	client := a.clientFor(&run)
+	return func() tea.Msg {
+		resp, err := client.ListJobs(context.Background(), run.ID, api.JobsFilter{Filter: "latest", PerPage: 100})
+		if err != nil {
+			return ui.TimelineLoadedMsg{RunID: run.ID, Err: err}
+		}
+		var specs []model.WorkflowJob
+		if path != "" {
+			if data, err := client.GetFileContent(context.Background(), path, run.HeadSHA); err == nil {
+				specs, _ = workflowyaml.ParseJobs(data)
+			}
+		}
+		return ui.TimelineLoadedMsg{RunID: run.ID, Jobs: resp.Jobs, Specs: specs}
+	}
+}
+
+func (a App) fetchPendingDeployments(runID int64) tea.Cmd {
+	client := a.runClient(runID)
+	return func() tea.Msg {
+		deployments, err := client.ListPendingDeployments(context.Background(), runID)
+		return ui.PendingDeploymentsLoadedMsg{RunID: runID, Deployments: deployments, Err: err}
+	}
+}
+
+func (a App) doReviewDeployments(r deployreview.ResultMsg) tea.Cmd {
+	verb := "Approve"
+	if r.State == model.DeploymentRejected {
+		verb = "Reject"
+	}
+	action := fmt.Sprintf("%s %s for run #%d", verb, strings.Join(r.Environments, ", "), r.Run.RunNumber)
+	client := a.clientFor(&r.Run)
+	return func() tea.Msg {
+		err := client.ReviewPendingDeployments(context.Background(), r.Run.ID, r.EnvironmentIDs, r.State, r.Comment)
+		return ui.ActionResultMsg{Action: action, Err: err}
+	}
+}
+
+func (a App) doDispatchWorkflow(wf model.Workflow, ref string, inputs map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		err := a.client.DispatchWorkflow(context.Background(), wf.ID, ref, inputs)
+		return ui.ActionResultMsg{Action: fmt.Sprintf("Run %s on %s", wf.Name, ref), Err: err}
+	}
+}
+
+// deleteOutcome is the result of deleteIDsConcurrently: how many IDs were
+// deleted, the ones that failed and the last error.
+type deleteOutcome struct {
+	deleted int
+	failed  []int64
+	err     error
+}
+
+// deleteIDsConcurrently deletes IDs with del, concurrency at a time, each
+// through the client of its repository. Transient failures are already
+// retried by the client; IDs that still fail are returned so the delete can
+// be resumed.
+func deleteIDsConcurrently(clientOf func(id int64) *api.Client, ids []int64, concurrency int,
+	del func(c *api.Client, ctx context.Context, id int64) error) deleteOutcome {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		out deleteOutcome
+	)
+	ctx := context.Background()
+	queue := make(chan int64)
+	for range min(concurrency, len(ids)) {
 		wg.Add(1)
-		go func(id int64) {
+		go func() {
 			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-			if err := client.DeleteRun(id); err != nil {
-				mu.Lock()
-				lastErr = err
-				mu.Unlock()
-			} else {
+			for id := range queue {
+				client := clientOf(id)
+				err := client.RateLimit().Wait(ctx)
+				if err == nil {
+					err = del(client, ctx, id)
+				}
 				mu.Lock()
-				deleted++
+				if err != nil {
+					out.err = err
+					out.failed = append(out.failed, id)
+				} else {
+					out.deleted++
+				}
 				mu.Unlock()
 			}
-		}(id)
+		}()
 	}
+	for _, id := range ids {
+		queue <- id
+	}
+	close(queue)
 	wg.Wait()
-	if lastErr != nil {
+	return out
+}
+
+// deleteRuns deletes runs through deleteIDsConcurrently and reports the
+// result as action.
+func deleteRuns(clientOf func(id int64) *api.Client, ids []int64, concurrency int, action string) ui.ActionResultMsg {
+	out := deleteIDsConcurrently(clientOf, ids, concurrency, (*api.Client).DeleteRun)
+	if out.err != nil {
 		return ui.ActionResultMsg{
-			Action: fmt.Sprintf("%s (%d/%d deleted)", action, deleted, len(ids)),
-			Err:    lastErr,
+			Action:    fmt.Sprintf("%s (%d/%d deleted)", action, out.deleted, len(ids)),
+			Err:       out.err,
+			Remaining: out.failed,
 		}
 	}
 	return ui.ActionResultMsg{
-		Action: fmt.Sprintf("%s (%d runs)", action, deleted),
+		Action: fmt.Sprintf("%s (%d runs)", action, out.deleted),
 	}
 }
 
 func (a App) doBulkDeleteRuns(wf *model.Workflow) tea.Cmd {
 	client := a.client
+	concurrency := a.cfg.DeleteConcurrency
 	return func() tea.Msg {
+		resp, err := client.ListAllRuns(context.Background(), api.RunsFilter{WorkflowID: wf.ID},
+			api.ListOptions{Concurrency: listConcurrency})
+		if err != nil {
+			return ui.ActionResultMsg{Action: "Bulk delete", Err: err}
+		}
 		var allIDs []int64
-		for page := 1; ; page++ {
-			resp, err := client.ListRuns(api.RunsFilter{
-				WorkflowID: wf.ID, PerPage: 100, Page: page,
-			})
-			if err != nil {
-				return ui.ActionResultMsg{Action: "Bulk delete", Err: err}
-			}
-			for _, r := range resp.Runs {
-				allIDs = append(allIDs, r.ID)
-			}
-			if len(resp.Runs) < 100 {
-				break
-			}
+		for _, r := range resp.Runs {
+			allIDs = append(allIDs, r.ID)
 		}
 		if len(allIDs) == 0 {
 			return ui.ActionResultMsg{Action: "Bulk delete", Err: fmt.Errorf("no runs found")}
 		}
-		return deleteIDsConcurrently(client, allIDs, "Bulk delete")
+		return deleteRuns(func(int64) *api.Client { return client }, allIDs, concurrency, "Bulk delete")
 	}
 }
 
 func (a App) doBulkDeleteByIDs(ids []int64) tea.Cmd {
-	client := a.client
+	clients := make(map[int64]*api.Client, len(ids))
+	for _, id := range ids {
+		clients[id] = a.runClient(id)
+	}
+	concurrency := a.cfg.DeleteConcurrency
 	return func() tea.Msg {
-		return deleteIDsConcurrently(client, ids, "Delete selected")
+		return deleteRuns(func(id int64) *api.Client { return clients[id] }, ids, concurrency, "Delete selected")
 	}
 }
 
@@ -831,6 +1203,22 @@ func (a App) doBulkDeleteByIDs(ids []int64) tea.Cmd {
 func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	a.syncRateLimit()
+
+	// The force-quit key works in every overlay and text input.
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && key.Matches(keyMsg, ui.Keys.ForceQuit) {
+		return &a, tea.Quit
+	}
+
+	// Results of cancelled requests are stale by definition. A cancelled
+	// poll of a run that is still being watched is simply rescheduled.
+	if isCancelled(msg) {
+		if m, ok := msg.(ui.JobsLoadedMsg); ok && a.autoRefreshRunID == m.RunID {
+			return &a, a.scheduleJobsRefresh(m.RunID)
+		}
+		return &a, nil
+	}
+
 	// Handle confirm dialog result (arrives AFTER dialog deactivates itself)
 	if result, ok := msg.(confirm.ResultMsg); ok {
 		if result.Confirmed {
@@ -875,42 +1263,244 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "clear-all-caches":
 				a.status = "Deleting all caches..."
 				cmds = append(cmds, a.deleteAllActionsCaches())
+			case "delete-artifact":
+				a.status = "Deleting artifact..."
+				client := a.client
+				if a.currentView == ViewRuns {
+					client = a.detailsClient()
+				}
+				cmds = append(cmds, a.deleteArtifact(client, result.Data.(int64)))
+			case "delete-selected-artifacts":
+				ids := result.Data.([]int64)
+				a.status = fmt.Sprintf("Deleting %d artifacts...", len(ids))
+				a.artifactsView.ClearSelection()
+				cmds = append(cmds, a.deleteSelectedArtifacts(ids))
+			}
+		}
+		return &a, tea.Batch(cmds...)
+	}
+
+	// Handle confirmation dialog input (key events while dialog is showing)
+	if a.confirmDialog.IsActive() {
+		var cmd tea.Cmd
+		a.confirmDialog, cmd = a.confirmDialog.Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		return &a, tea.Batch(cmds...)
+	}
+
+	// Handle filter overlay result
+	if result, ok := msg.(filteroverlay.ResultMsg); ok {
+		if result.Applied {
+			a.runsFilter = result.Filter
+			a.runsPage = 1
+			a.runsView = runs.New()
+			a.propagateSize()
+			a.status = "Loading runs..."
+			cmds = append(cmds, a.fetchRuns())
+		}
+		return &a, tea.Batch(cmds...)
+	}
+
+	// Handle filter overlay input (key events while overlay is showing)
+	if a.filterOverlay.IsActive() {
+		var cmd tea.Cmd
+		a.filterOverlay, cmd = a.filterOverlay.Update(msg)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+		return &a, tea.Batch(cmds...)
+	}
+
+	// Dispatch form: spec loads and ref changes arrive while the form is open
+	switch msg := msg.(type) {
+	case ui.DispatchSpecLoadedMsg:
+		if a.dispatchForm.IsActive() {
+			if a.dispatchForm.Workflow().ID != msg.Workflow.ID {
+				return &a, nil
+			}
+			if msg.Err != nil {
+				a.dispatchForm.SetError(msg.Err)
+			} else if !msg.Dispatchable {
+				a.dispatchForm.SetError(fmt.Errorf("no workflow_dispatch trigger at %s", msg.Ref))
+			} else {
+				a.dispatchForm.SetInputs(msg.Ref, msg.Inputs, msg.Environments)
+			}
+			return &a, nil
+		}
+		if a.currentView != ViewWorkflows {
+			return &a, nil
+		}
+		if msg.Err != nil {
+			a.status = fmt.Sprintf("Error: %s", ui.FormatError(msg.Err))
+		} else if !msg.Dispatchable {
+			a.status = fmt.Sprintf("%s has no workflow_dispatch trigger on %s", msg.Workflow.Name, msg.Ref)
+		} else {
+			a.dispatchForm = dispatchform.New(msg.Workflow, msg.Ref, msg.Inputs, msg.Environments)
+			a.dispatchForm.SetSize(a.width, a.height)
+			a.status = fmt.Sprintf("Run %s", msg.Workflow.Name)
+		}
+		return &a, nil
+	case dispatchform.RefChangedMsg:
+		return &a, a.fetchDispatchSpec(msg.Workflow, msg.Ref)
+	case dispatchform.ResultMsg:
+		if msg.Submitted {
+			a.status = fmt.Sprintf("Dispatching %s on %s...", msg.Workflow.Name, msg.Ref)
+			cmds = append(cmds, a.doDispatchWorkflow(msg.Workflow, msg.Ref, msg.Inputs))
+		} else {
+			a.status = "Workflows"
+		}
+		return &a, tea.Batch(cmds...)
+	}
+
+	// Handle dispatch form input (key events while the form is showing)
+	if a.dispatchForm.IsActive() {
+		if _, isKey := msg.(tea.KeyMsg); isKey {
+			var cmd tea.Cmd
+			a.dispatchForm, cmd = a.dispatchForm.Update(msg)
+			return &a, cmd
+		}
+	}
+
+	// Workflow file viewer: full screen over the Runs and Workflows tabs
+	if msg, ok := msg.(ui.WorkflowFileLoadedMsg); ok {
+		if !a.yamlView.Matches(msg.Path, msg.Ref) {
+			return &a, nil
+		}
+		if msg.Err != nil {
+			a.yamlView.SetError(msg.Err)
+			a.status = fmt.Sprintf("Error loading %s: %s", msg.Path, ui.FormatError(msg.Err))
+		} else {
+			a.yamlView.SetContent(msg.Content)
+			a.status = msg.Path
+		}
+		return &a, nil
+	}
+	if a.yamlView.IsActive() {
+		if _, isKey := msg.(tea.KeyMsg); isKey {
+			var cmd tea.Cmd
+			a.yamlView, cmd = a.yamlView.Update(msg)
+			if !a.yamlView.IsActive() {
+				if a.currentView == ViewRuns {
+					a.status = a.runsPageStatus()
+				} else {
+					a.status = fmt.Sprintf("%d workflows", len(a.workflows))
+				}
+			}
+			return &a, cmd
+		}
+	}
+
+	// Job graph: full screen over the Runs tab
+	if msg, ok := msg.(ui.JobGraphLoadedMsg); ok {
+		if !a.jobGraph.IsActive() || a.jobGraph.RunID() != msg.RunID {
+			return &a, nil
+		}
+		if msg.Err != nil {
+			a.jobGraph.SetError(msg.Err)
+			a.status = fmt.Sprintf("Error loading job graph: %s", ui.FormatError(msg.Err))
+		} else {
+			a.jobGraph.SetGraph(msg.Specs, msg.Jobs)
+			a.status = fmt.Sprintf("%d jobs declared, %d created", len(msg.Specs), len(msg.Jobs))
+		}
+		return &a, nil
+	}
+	if a.jobGraph.IsActive() {
+		if _, isKey := msg.(tea.KeyMsg); isKey {
+			var cmd tea.Cmd
+			a.jobGraph, cmd = a.jobGraph.Update(msg)
+			if !a.jobGraph.IsActive() {
+				a.status = a.runsPageStatus()
 			}
+			return &a, cmd
 		}
-		return &a, tea.Batch(cmds...)
 	}
 
-	// Handle confirmation dialog input (key events while dialog is showing)
-	if a.confirmDialog.IsActive() {
-		var cmd tea.Cmd
-		a.confirmDialog, cmd = a.confirmDialog.Update(msg)
-		if cmd != nil {
-			cmds = append(cmds, cmd)
+	// Run comparison: full screen over the Runs tab
+	if msg, ok := msg.(ui.CompareJobsLoadedMsg); ok {
+		base, head := a.compareView.Runs()
+		if !a.compareView.IsActive() || base.ID != msg.BaseRunID || head.ID != msg.HeadRunID {
+			return &a, nil
+		}
+		if msg.Err != nil {
+			a.compareView.SetError(msg.Err)
+			a.status = fmt.Sprintf("Error loading jobs: %s", ui.FormatError(msg.Err))
+		} else {
+			a.compareView.SetJobs(msg.Base, msg.Head)
+			a.status = fmt.Sprintf("Run #%d vs #%d", base.RunNumber, head.RunNumber)
+		}
+		return &a, nil
+	}
+	if a.compareView.IsActive() {
+		if keyMsg, isKey := msg.(tea.KeyMsg); isKey {
+			if key.Matches(keyMsg, ui.Keys.Open) {
+				if url := a.compareView.URL(); url != "" {
+					return &a, openBrowser(url)
+				}
+				a.status = "Both runs are on the same commit"
+				return &a, nil
+			}
+			var cmd tea.Cmd
+			a.compareView, cmd = a.compareView.Update(msg)
+			if !a.compareView.IsActive() {
+				a.status = a.runsPageStatus()
+			}
+			return &a, cmd
 		}
-		return &a, tea.Batch(cmds...)
 	}
 
-	// Handle filter overlay result
-	if result, ok := msg.(filteroverlay.ResultMsg); ok {
-		if result.Applied {
-			a.runsFilter = result.Filter
-			a.runsPage = 1
-			a.runsView = runs.New()
-			a.propagateSize()
-			a.status = "Loading runs..."
-			cmds = append(cmds, a.fetchRuns())
+	// Timeline: full screen over the Runs tab
+	if msg, ok := msg.(ui.TimelineLoadedMsg); ok {
+		if !a.timeline.IsActive() || a.timeline.RunID() != msg.RunID {
+			return &a, nil
+		}
+		if msg.Err != nil {
+			a.timeline.SetError(msg.Err)
+			a.status = fmt.Sprintf("Error loading timeline: %s", ui.FormatError(msg.Err))
+		} else {
+			a.timeline.SetData(msg.Jobs, msg.Specs)
+			a.status = fmt.Sprintf("%d jobs loaded", len(msg.Jobs))
+		}
+		return &a, nil
+	}
+	if a.timeline.IsActive() {
+		if _, isKey := msg.(tea.KeyMsg); isKey {
+			var cmd tea.Cmd
+			a.timeline, cmd = a.timeline.Update(msg)
+			if !a.timeline.IsActive() {
+				a.status = a.runsPageStatus()
+			}
+			return &a, cmd
 		}
-		return &a, tea.Batch(cmds...)
 	}
 
-	// Handle filter overlay input (key events while overlay is showing)
-	if a.filterOverlay.IsActive() {
-		var cmd tea.Cmd
-		a.filterOverlay, cmd = a.filterOverlay.Update(msg)
-		if cmd != nil {
-			cmds = append(cmds, cmd)
+	// Deployment review overlay
+	switch msg := msg.(type) {
+	case ui.PendingDeploymentsLoadedMsg:
+		if !a.deployReview.IsActive() || a.deployReview.Run().ID != msg.RunID {
+			return &a, nil
+		}
+		if msg.Err != nil {
+			a.deployReview.SetError(msg.Err)
+		} else {
+			a.deployReview.SetDeployments(msg.Deployments)
+		}
+		return &a, nil
+	case deployreview.ResultMsg:
+		if msg.Submitted {
+			a.status = fmt.Sprintf("Submitting review for run #%d...", msg.Run.RunNumber)
+			return &a, a.doReviewDeployments(msg)
+		}
+		a.status = a.runsPageStatus()
+		return &a, nil
+	}
+	if a.deployReview.IsActive() {
+		if _, isKey := msg.(tea.KeyMsg); isKey {
+			var cmd tea.Cmd
+			a.deployReview, cmd = a.deployReview.Update(msg)
+			return &a, cmd
 		}
-		return &a, tea.Batch(cmds...)
 	}
 
 	// Handle search input/results mode
@@ -920,7 +1510,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			if keyMsg.String() == "enter" {
+			if key.Matches(keyMsg, ui.Keys.Enter) {
 				if a.searchView.IsInputMode() {
 					// Input mode: dispatch search
 					query := a.searchView.Query()
@@ -957,6 +1547,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						if ok {
 							a.searchView.Deactivate()
 							a.logView.SetContent(jobName, content)
+							a.logView.SetAnnotations(nil)
 							a.logView.GotoLine(line)
 							a.logFullScreen = true
 							a.cameFromSearch = true
@@ -974,7 +1565,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Handle full-screen log search mode: keys go directly to log view,
 	// skip app-level handlers (quit, tab switching, etc.)
-	if _, isKey := msg.(tea.KeyMsg); isKey && a.logFullScreen && a.logView.IsSearching() {
+	if _, isKey := msg.(tea.KeyMsg); isKey && a.logFullScreen && (a.logView.IsSearching() || a.logView.IsPicking()) {
 		var cmd tea.Cmd
 		a.logView, cmd = a.logView.Update(msg)
 		cmds = append(cmds, cmd)
@@ -994,6 +1585,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.cacheView, cmd = a.cacheView.Update(msg)
 		case ViewRunners:
 			a.runnersView, cmd = a.runnersView.Update(msg)
+		case ViewArtifacts:
+			a.artifactsView, cmd = a.artifactsView.Update(msg)
 		}
 		cmds = append(cmds, cmd)
 		return &a, tea.Batch(cmds...)
@@ -1017,22 +1610,21 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Help overlay: scroll keys pass to viewport, close keys dismiss
 		if a.showHelp {
-			switch msg.String() {
-			case "esc", "?", "q":
+			if key.Matches(msg, ui.Keys.Back, ui.Keys.Help, ui.Keys.Quit) {
 				a.showHelp = false
 				return &a, nil
-			default:
-				var cmd tea.Cmd
-				a.helpViewport, cmd = a.helpViewport.Update(msg)
-				return &a, cmd
 			}
+			var cmd tea.Cmd
+			a.helpViewport, cmd = a.helpViewport.Update(msg)
+			return &a, cmd
 		}
 
-		switch msg.String() {
-		case "q", "ctrl+c":
+		k := &ui.Keys
+		switch b := k.Lookup(a.keyContext(), msg); b {
+		case &k.Quit:
 			return &a, tea.Quit
 
-		case "?":
+		case &k.Help:
 			contentH := a.height - 5
 			if contentH < 1 {
 				contentH = 1
@@ -1043,6 +1635,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if !a.helpReady {
 				a.helpViewport = viewport.New(a.width-4, vpH)
+				a.helpViewport.KeyMap = ui.ViewportKeyMap()
 				a.helpReady = true
 			} else {
 				a.helpViewport.Width = a.width - 4
@@ -1053,7 +1646,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.showHelp = true
 			return &a, nil
 
-		case "tab":
+		case &k.Tab:
 			if a.currentView == ViewRuns && !a.logFullScreen {
 				if a.focusedPane == PaneLeft {
 					a.focusedPane = PaneMiddle
@@ -1062,7 +1655,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.status = a.runsPageStatus()
 				}
 			}
-		case "shift+tab":
+		case &k.ShiftTab:
 			if a.currentView == ViewRuns && !a.logFullScreen {
 				if a.focusedPane == PaneMiddle {
 					a.focusedPane = PaneLeft
@@ -1072,75 +1665,91 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-		case "1", "2", "3", "4", "5":
+		case &k.TabRuns, &k.TabWorkflows, &k.TabMetrics, &k.TabCache, &k.TabRunners, &k.TabArtifacts:
 			// Stop tailing when switching tabs
 			if a.tailingJobID > 0 {
 				a.tailingJobID = 0
 				a.tailingJobName = ""
 				a.logView.SetTailing(false)
+				a.tailScope.Cancel()
+			}
+			// Leaving Metrics abandons its fan-out.
+			if a.currentView == ViewMetrics && b != &k.TabMetrics {
+				a.metricsScope.Cancel()
 			}
 			a.logFullScreen = false
 			a.infoFullScreen = false
-			switch msg.String() {
-			case "1":
+			switch b {
+			case &k.TabRuns:
 				if a.currentView != ViewRuns {
 					a.currentView = ViewRuns
 					a.focusedPane = PaneLeft
 					a.status = a.runsPageStatus()
 					a.propagateSize()
 				}
-			case "2":
+			case &k.TabWorkflows:
 				if a.currentView != ViewWorkflows {
 					a.currentView = ViewWorkflows
 					a.focusedPane = PaneLeft
 					a.status = "Workflows"
 					cmds = append(cmds, a.fetchWorkflows())
 				}
-			case "3":
+			case &k.TabMetrics:
 				if a.currentView != ViewMetrics {
 					a.currentView = ViewMetrics
 					a.focusedPane = PaneLeft
 					a.status = "Loading metrics..."
+					a.metricsScope.Renew()
 					cmds = append(cmds, a.fetchDashboardData(a.dashboardView.Window()))
 				}
-			case "4":
+			case &k.TabCache:
 				if a.currentView != ViewCache {
 					a.currentView = ViewCache
 					a.focusedPane = PaneLeft
 					a.status = "Loading caches..."
 					cmds = append(cmds, a.fetchActionsCaches())
 				}
-			case "5":
+			case &k.TabRunners:
 				if a.currentView != ViewRunners {
 					a.currentView = ViewRunners
 					a.focusedPane = PaneLeft
 					a.status = "Loading runners..."
 					cmds = append(cmds, a.fetchRunners())
 				}
+			case &k.TabArtifacts:
+				if a.currentView != ViewArtifacts {
+					a.currentView = ViewArtifacts
+					a.focusedPane = PaneLeft
+					a.status = "Loading artifacts..."
+					cmds = append(cmds, a.fetchArtifacts())
+				}
 			}
 
-		case "S":
+		case &k.ServerFilter:
 			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
-				a.filterOverlay = filteroverlay.New(a.workflows, a.runsFilter)
+				a.filterOverlay = filteroverlay.New(a.cfg.Repos(), a.workflows, a.runsFilter)
 				a.filterOverlay.SetSize(a.width, a.height)
 			}
 
-		case "/":
+		case &k.Search:
 			if a.currentView == ViewRuns && !a.logFullScreen {
 				a.searchView.Activate()
 			}
 
-		case "enter":
+		case &k.Enter:
 			if a.currentView == ViewRuns && a.focusedPane == PaneLeft {
 				if !a.runsView.IsFiltering() {
 					if run := a.runsView.SelectedRun(); run != nil {
 						a.viewingAttempt = 0
 						a.currentRunLogs = nil
 						a.currentRunID = 0
+						// Drop whatever is still loading for the previous run.
+						a.runScope.Renew()
 						a.detailsView.SetRun(run)
 						a.focusedPane = PaneMiddle
 						a.status = fmt.Sprintf("Loading jobs for #%d...", run.RunNumber)
 						cmds = append(cmds, a.fetchJobs(run.ID))
+						cmds = append(cmds, a.fetchRunArtifacts(run.ID))
 						// Download run-level log archive (contains logs for completed jobs).
 						// For in-progress runs, still-running job logs are fetched on demand.
 						cmds = append(cmds, a.fetchLogs(run))
@@ -1168,6 +1777,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			} else if a.currentView == ViewRuns && a.focusedPane == PaneMiddle {
 				if job := a.detailsView.SelectedJob(); job != nil {
+					cmds = append(cmds, a.showAnnotations(job))
 					content, ok := a.findJobLog(job.Name)
 					if job.Status != model.RunStatusCompleted {
 						// In-progress job: show step progress from what we have, start tailing
@@ -1176,11 +1786,12 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						a.propagateSize()
 						a.tailingJobID = job.ID
 						a.tailingJobName = job.Name
+						a.tailScope.Renew()
 						a.logView.SetTailing(true)
 						a.viewingJob = job
 						a.status = fmt.Sprintf("Watching %s...", job.Name)
 						cmds = append(cmds, a.checkJobStatus(job.ID, job.Name))
-					} else if ok && !isSystemStub(content) {
+					} else if ok && !ops.IsSystemStub(content) {
 						a.logView.SetContent(job.Name, content)
 						a.logFullScreen = true
 						a.propagateSize()
@@ -1203,23 +1814,33 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 
+				} else if artifact := a.detailsView.SelectedArtifact(); artifact != nil {
+					if cmd := a.startArtifactDownload(artifact); cmd != nil {
+						cmds = append(cmds, cmd)
+					}
+				}
+			} else if a.currentView == ViewArtifacts {
+				if artifact := a.artifactsView.SelectedArtifact(); artifact != nil {
+					if cmd := a.startArtifactDownload(artifact); cmd != nil {
+						cmds = append(cmds, cmd)
 					}
+				}
 			}
 
-		case "right", "l":
+		case &k.Right:
 			if a.currentView == ViewRuns && a.focusedPane == PaneLeft && a.runsHasMore && !a.runsLoading {
 				a.runsLoading = true
 				a.status = fmt.Sprintf("Loading page %d...", a.runsPage+1)
 				cmds = append(cmds, a.fetchRunsPage(a.runsPage+1))
 			}
-		case "left", "h":
+		case &k.Left:
 			if a.currentView == ViewRuns && a.focusedPane == PaneLeft && a.runsPage > 1 && !a.runsLoading {
 				a.runsLoading = true
 				a.status = fmt.Sprintf("Loading page %d...", a.runsPage-1)
 				cmds = append(cmds, a.fetchRunsPage(a.runsPage-1))
 			}
 
-		case "r":
+		case &k.Refresh:
 			if a.currentView == ViewRuns {
 				cmds = append(cmds, a.fetchRuns())
 				a.status = "Refreshing runs..."
@@ -1232,9 +1853,12 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.currentView == ViewRunners {
 				cmds = append(cmds, a.fetchRunners())
 				a.status = "Refreshing runners..."
+			} else if a.currentView == ViewArtifacts {
+				cmds = append(cmds, a.fetchArtifacts())
+				a.status = "Refreshing artifacts..."
 			}
 
-		case "R":
+		case &k.RerunAll:
 			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
 				if a.focusedPane == PaneMiddle {
 					if job := a.detailsView.SelectedJob(); job != nil {
@@ -1252,7 +1876,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					)
 				}
 			}
-		case "F":
+		case &k.RerunFailed:
 			if a.currentView == ViewRuns {
 				if run := a.runsView.SelectedRun(); run != nil {
 					a.confirmDialog = confirm.New(
@@ -1262,8 +1886,15 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					)
 				}
 			}
-		case "d":
-			if a.currentView == ViewRuns {
+		case &k.Delete:
+			if a.currentView == ViewRuns && a.focusedPane == PaneMiddle && a.detailsView.SelectedArtifact() != nil {
+				artifact := a.detailsView.SelectedArtifact()
+				a.confirmDialog = confirm.New(
+					"Delete Artifact",
+					fmt.Sprintf("Delete artifact '%s'? This cannot be undone.", artifact.Name),
+					"delete-artifact", artifact.ID,
+				)
+			} else if a.currentView == ViewRuns {
 				if count := a.runsView.SelectionCount(); count > 0 {
 					a.confirmDialog = confirm.New(
 						"Delete Selected Runs",
@@ -1303,8 +1934,22 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						"delete-cache-entry", nil,
 					)
 				}
+			} else if a.currentView == ViewArtifacts {
+				if count := a.artifactsView.SelectionCount(); count > 0 {
+					a.confirmDialog = confirm.New(
+						"Delete Selected Artifacts",
+						fmt.Sprintf("Delete %d selected artifacts? This cannot be undone.", count),
+						"delete-selected-artifacts", a.artifactsView.SelectedArtifacts(),
+					)
+				} else if artifact := a.artifactsView.SelectedArtifact(); artifact != nil {
+					a.confirmDialog = confirm.New(
+						"Delete Artifact",
+						fmt.Sprintf("Delete artifact '%s'? This cannot be undone.", artifact.Name),
+						"delete-artifact", artifact.ID,
+					)
+				}
 			}
-		case "C":
+		case &k.Cancel:
 			if a.currentView == ViewRuns {
 				if run := a.runsView.SelectedRun(); run != nil {
 					a.confirmDialog = confirm.New(
@@ -1314,7 +1959,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					)
 				}
 			}
-		case "X":
+		case &k.ForceCancel:
 			if a.currentView == ViewRuns {
 				if run := a.runsView.SelectedRun(); run != nil {
 					a.confirmDialog = confirm.New(
@@ -1324,7 +1969,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					)
 				}
 			}
-		case "a":
+		case &k.Attempt:
 			if a.currentView == ViewRuns && a.logFullScreen && !a.infoFullScreen {
 				// Attempt cycling while viewing a job log
 				if run := a.detailsView.Run(); run != nil && run.RunAttempt > 1 && a.viewingJob != nil {
@@ -1333,6 +1978,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						a.viewingAttempt = 0
 					}
 					a.detailsView.SetAttempt(a.viewingAttempt, run.RunAttempt)
+					a.runScope.Renew()
 
 					if a.viewingAttempt == 0 {
 						a.status = "Loading latest (merged) logs..."
@@ -1350,6 +1996,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						a.viewingAttempt = 0
 					}
 					a.detailsView.SetAttempt(a.viewingAttempt, run.RunAttempt)
+					a.runScope.Renew()
 
 					if a.viewingAttempt == 0 {
 						a.status = "Loading latest (merged) jobs..."
@@ -1363,13 +2010,14 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-		case "i":
+		case &k.Info:
 			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
 				if a.focusedPane == PaneMiddle {
 					if job := a.detailsView.SelectedJob(); job != nil {
 						a.infoView.SetJob(job)
 						a.infoFullScreen = true
 						a.propagateSize()
+						cmds = append(cmds, a.showAnnotations(job))
 					}
 				} else {
 					if run := a.runsView.SelectedRun(); run != nil {
@@ -1388,7 +2036,103 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-		case "e":
+		case &k.WorkflowFile:
+			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
+				run := a.runsView.SelectedRun()
+				if a.focusedPane == PaneMiddle {
+					run = a.detailsView.Run()
+				}
+				if run != nil {
+					if path := a.runWorkflowPath(run); path != "" {
+						cmds = append(cmds, a.openWorkflowFile(a.clientFor(run), path, run.HeadSHA))
+					} else {
+						a.status = fmt.Sprintf("Run #%d has no workflow file in the repository", run.RunNumber)
+					}
+				}
+			} else if a.currentView == ViewWorkflows {
+				if wf := a.workflowsView.SelectedWorkflow(); wf != nil {
+					cmds = append(cmds, a.openWorkflowFile(a.client, wf.Path, ""))
+				}
+			}
+
+		case &k.JobGraph:
+			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
+				run := a.runsView.SelectedRun()
+				if a.focusedPane == PaneMiddle {
+					run = a.detailsView.Run()
+				}
+				if run != nil {
+					a.jobGraph.Open(*run)
+					a.propagateSize()
+					a.status = fmt.Sprintf("Loading job graph for run #%d...", run.RunNumber)
+					cmds = append(cmds, a.fetchJobGraph(*run))
+				}
+			}
+
+		case &k.Timeline:
+			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
+				run := a.runsView.SelectedRun()
+				if a.focusedPane == PaneMiddle {
+					run = a.detailsView.Run()
+				}
+				if run != nil {
+					a.timeline.Open(*run)
+					a.propagateSize()
+					a.status = fmt.Sprintf("Loading timeline for run #%d...", run.RunNumber)
+					cmds = append(cmds, a.fetchRunTimeline(*run))
+				}
+			}
+
+		case &k.Mark:
+			if a.currentView == ViewRuns && a.focusedPane == PaneLeft && !a.logFullScreen && !a.infoFullScreen {
+				if n := a.runsView.ToggleCompare(); n < 2 {
+					a.status = fmt.Sprintf("%d/2 runs marked, %s: compare with the selected run", n, k.Compare.Help().Key)
+				} else {
+					a.status = fmt.Sprintf("2/2 runs marked, %s: compare", k.Compare.Help().Key)
+				}
+			}
+
+		case &k.Compare:
+			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
+				marked := a.runsView.CompareRuns()
+				if len(marked) == 1 {
+					if run := a.runsView.SelectedRun(); run != nil && run.ID != marked[0].ID {
+						marked = append(marked, *run)
+					}
+				}
+				if len(marked) < 2 {
+					a.status = fmt.Sprintf("Mark two runs with %s to compare them", k.Mark.Help().Key)
+				} else {
+					cmds = append(cmds, a.openCompare(marked[0], marked[1]))
+				}
+			}
+
+		case &k.Review:
+			if a.currentView == ViewRuns && !a.logFullScreen && !a.infoFullScreen {
+				run := a.runsView.SelectedRun()
+				if a.focusedPane == PaneMiddle {
+					run = a.detailsView.Run()
+				}
+				if run != nil {
+					if run.Status != model.RunStatusWaiting {
+						a.status = fmt.Sprintf("Run #%d is not waiting for a deployment review", run.RunNumber)
+					} else {
+						a.deployReview = deployreview.New(*run)
+						a.deployReview.SetSize(a.width, a.height)
+						a.status = fmt.Sprintf("Review deployments for run #%d", run.RunNumber)
+						cmds = append(cmds, a.fetchPendingDeployments(run.ID))
+					}
+				}
+			}
+
+		case &k.Dispatch:
+			if a.currentView == ViewWorkflows {
+				if wf := a.workflowsView.SelectedWorkflow(); wf != nil {
+					a.status = fmt.Sprintf("Loading inputs for %s...", wf.Name)
+					cmds = append(cmds, a.fetchDispatchSpec(*wf, ""))
+				}
+			}
+		case &k.Enable:
 			if a.currentView == ViewWorkflows {
 				if wf := a.workflowsView.SelectedWorkflow(); wf != nil {
 					a.confirmDialog = confirm.New(
@@ -1398,7 +2142,18 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					)
 				}
 			}
-		case "D":
+		case &k.LogDiff:
+			if a.currentView == ViewRuns && a.logFullScreen && a.viewingJob != nil && a.tailingJobID == 0 {
+				if run := a.detailsView.Run(); run != nil {
+					if base, head, ok := a.logDiffSources(*run); ok {
+						a.status = fmt.Sprintf("Diffing %s: %s -> %s...", a.viewingJob.Name, base.label, head.label)
+						cmds = append(cmds, a.fetchLogDiff(*run, a.viewingJob.Name, base, head))
+					} else {
+						a.status = "Nothing to diff against: re-run the job or mark another run with " + k.Mark.Help().Key
+					}
+				}
+			}
+		case &k.Disable:
 			if a.currentView == ViewWorkflows {
 				if wf := a.workflowsView.SelectedWorkflow(); wf != nil {
 					a.confirmDialog = confirm.New(
@@ -1408,7 +2163,14 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					)
 				}
 			}
-		case "x":
+		case &k.Export:
+			if a.currentView == ViewMetrics {
+				if metrics := a.dashboardView.Metrics(); metrics != nil {
+					a.status = "Exporting metrics..."
+					cmds = append(cmds, a.exportMetrics(*metrics))
+				}
+			}
+		case &k.DeleteAll:
 			if a.currentView == ViewWorkflows {
 				if wf := a.workflowsView.SelectedWorkflow(); wf != nil {
 					a.confirmDialog = confirm.New(
@@ -1434,8 +2196,16 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case ui.ActionResultMsg:
-		if msg.Err != nil {
-			a.status = fmt.Sprintf("Error: %v", msg.Err)
+		if len(msg.Remaining) > 0 {
+			a.status = fmt.Sprintf("%s: %s", msg.Action, ui.FormatError(msg.Err))
+			a.confirmDialog = confirm.New(
+				"Resume Delete",
+				fmt.Sprintf("%s. Retry the %d runs that failed?", msg.Action, len(msg.Remaining)),
+				"delete-selected-runs", msg.Remaining,
+			)
+			cmds = append(cmds, a.fetchRuns())
+		} else if msg.Err != nil {
+			a.status = fmt.Sprintf("Error: %s", ui.FormatError(msg.Err))
 		} else {
 			a.status = fmt.Sprintf("%s: success", msg.Action)
 			if a.currentView == ViewWorkflows {
@@ -1448,10 +2218,10 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ui.WorkflowsLoadedMsg:
 		if msg.Err == nil {
 			a.workflows = msg.Workflows
-			a.status = fmt.Sprintf("%d workflows", len(msg.Workflows))
+			a.status = fmt.Sprintf("%d workflows%s", len(msg.Workflows), limitNote(len(msg.Workflows), msg.TotalCount))
 			cmds = append(cmds, a.fetchWorkflowStats(msg.Workflows))
 		} else {
-			a.status = fmt.Sprintf("Error: %v", msg.Err)
+			a.status = fmt.Sprintf("Error: %s", ui.FormatError(msg.Err))
 		}
 
 	case ui.WorkflowStatsMsg:
@@ -1464,12 +2234,13 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Err == nil {
 			a.runsPage = 1
 			a.runsTotalCount = msg.TotalCount
-			a.runsHasMore = len(msg.Runs) >= runsPerPage
+			a.runsPages = msg.Pages
+			a.runsHasMore = a.morePages(len(msg.Runs))
 			a.runsLoading = false
 			a.status = a.runsPageStatus()
 		} else {
 			a.runsLoading = false
-			a.status = fmt.Sprintf("Error: %v", msg.Err)
+			a.status = fmt.Sprintf("Error: %s", ui.FormatError(msg.Err))
 		}
 		cmds = append(cmds, a.scheduleRunsRefresh())
 
@@ -1478,16 +2249,23 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.Err == nil {
 			a.runsPage = msg.Page
 			a.runsTotalCount = msg.TotalCount
-			a.runsHasMore = len(msg.Runs) >= runsPerPage
+			a.runsPages = msg.Pages
+			a.runsHasMore = a.morePages(len(msg.Runs))
 			a.status = a.runsPageStatus()
 		} else {
-			a.status = fmt.Sprintf("Error loading page: %v", msg.Err)
+			a.status = fmt.Sprintf("Error loading page: %s", ui.FormatError(msg.Err))
 		}
 		cmds = append(cmds, a.scheduleRunsRefresh())
 
 	case ui.JobsLoadedMsg:
 		if msg.Err == nil {
 			a.status = fmt.Sprintf("%d jobs loaded", len(msg.Jobs))
+			if a.jobGraph.IsActive() && a.jobGraph.RunID() == msg.RunID {
+				a.jobGraph.SetJobs(msg.Jobs)
+			}
+			if a.timeline.IsActive() && a.timeline.RunID() == msg.RunID {
+				a.timeline.SetJobs(msg.Jobs)
+			}
 			// Feed jobs to info view if it's showing this run
 			if a.infoFullScreen && a.infoView.Run() != nil && a.infoView.Run().ID == msg.RunID {
 				a.infoView.SetJobs(msg.Jobs)
@@ -1499,7 +2277,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if run := a.detailsView.Run(); run != nil && run.ID == msg.RunID {
 				for _, j := range msg.Jobs {
 					if j.Status == model.RunStatusCompleted {
-						if content, ok := a.findJobLog(j.Name); !ok || isSystemStub(content) {
+						if content, ok := a.findJobLog(j.Name); !ok || ops.IsSystemStub(content) {
 							cmds = append(cmds, a.fetchJobLog(msg.RunID, j.ID, j.Name))
 						}
 					}
@@ -1525,13 +2303,15 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							a.logCache.DeleteEntry(run.ID, att)
 						}
 						cmds = append(cmds, a.fetchLogs(run))
+						// Artifacts are uploaded as jobs finish; pick up the final set.
+						cmds = append(cmds, a.fetchRunArtifacts(run.ID))
 					}
 				} else {
 					cmds = append(cmds, a.scheduleJobsRefresh(msg.RunID))
 				}
 			}
 		} else {
-			a.status = fmt.Sprintf("Error loading jobs: %v", msg.Err)
+			a.status = fmt.Sprintf("Error loading jobs: %s", ui.FormatError(msg.Err))
 			// Keep auto-refresh alive on transient errors
 			if a.autoRefreshRunID == msg.RunID {
 				cmds = append(cmds, a.scheduleJobsRefresh(msg.RunID))
@@ -1549,7 +2329,8 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ui.RunsRefreshedMsg:
 		if msg.Err == nil {
 			a.runsTotalCount = msg.TotalCount
-			a.runsHasMore = len(msg.Runs) >= runsPerPage
+			a.runsPages = msg.Pages
+			a.runsHasMore = a.morePages(len(msg.Runs))
 		}
 		cmds = append(cmds, a.scheduleRunsRefresh())
 
@@ -1573,7 +2354,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ui.JobTailStatusMsg:
 		if a.tailingJobID == msg.JobID {
 			if msg.Err != nil {
-				a.logView.UpdateContent(fmt.Sprintf("\n  Error fetching job status: %v\n\n  Retrying...", msg.Err))
+				a.logView.UpdateContent(fmt.Sprintf("\n  Error fetching job status: %s\n\n  Retrying...", ui.FormatError(msg.Err)))
 				a.status = fmt.Sprintf("Error watching %s, retrying...", msg.JobName)
 				cmds = append(cmds, a.scheduleLogRefresh(msg.JobID, msg.JobName))
 			} else if msg.Completed {
@@ -1604,6 +2385,25 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case ui.JobAnnotationsLoadedMsg:
+		if msg.Err != nil {
+			if a.viewingJob != nil && a.viewingJob.ID == msg.JobID {
+				a.status = fmt.Sprintf("Error loading annotations: %s", ui.FormatError(msg.Err))
+			}
+			break
+		}
+		if a.jobAnnotations == nil {
+			a.jobAnnotations = make(map[int64][]model.Annotation)
+		}
+		a.jobAnnotations[msg.JobID] = msg.Annotations
+		a.infoView.SetAnnotations(msg.JobID, msg.Annotations)
+		if a.viewingJob != nil && a.viewingJob.ID == msg.JobID {
+			a.logView.SetAnnotations(msg.Annotations)
+			if len(msg.Annotations) > 0 {
+				a.status = fmt.Sprintf("%d annotations — press %s to jump", len(msg.Annotations), ui.Keys.Annotations.Help().Key)
+			}
+		}
+
 	case ui.JobLogLoadedMsg:
 		if msg.Err == nil {
 			// Discard logs from a different run to prevent cross-run contamination.
@@ -1633,10 +2433,21 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		} else {
 			if !a.logFullScreen {
-				a.status = fmt.Sprintf("Error loading log: %v", msg.Err)
+				a.status = fmt.Sprintf("Error loading log: %s", ui.FormatError(msg.Err))
 			}
 		}
 
+	case ui.LogDiffLoadedMsg:
+		if run := a.detailsView.Run(); !a.logFullScreen || a.viewingJob == nil || a.viewingJob.Name != msg.JobName || run == nil || run.ID != msg.RunID {
+			break
+		}
+		if msg.Err != nil {
+			a.status = fmt.Sprintf("Error loading log diff: %s", ui.FormatError(msg.Err))
+		} else {
+			a.logView.SetDiff(msg.Title, msg.Base, msg.Head)
+			a.status = msg.Title
+		}
+
 	case ui.LogsLoadedMsg:
 		if msg.Err == nil {
 			// Merge incoming logs with existing ones. Keep whichever version
@@ -1661,15 +2472,15 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Skip if we already have real content to avoid resetting search state.
 			if a.logFullScreen && a.viewingJob != nil && a.tailingJobID == 0 && msg.Attempt > 0 && a.viewingAttempt > 0 {
 				displayName := fmt.Sprintf("%s (attempt %d)", a.viewingJob.Name, a.viewingAttempt)
-				if content, ok := a.findJobLog(a.viewingJob.Name); ok && !isSystemStub(content) {
+				if content, ok := a.findJobLog(a.viewingJob.Name); ok && !ops.IsSystemStub(content) {
 					a.logView.SetContent(displayName, content)
 				} else {
-					noLogMsg := fmt.Sprintf("\n  This job did not run in attempt %d.\n  Press 'a' to switch attempts.\n", a.viewingAttempt)
+					noLogMsg := fmt.Sprintf("\n  This job did not run in attempt %d.\n  Press '%s' to switch attempts.\n", a.viewingAttempt, ui.Keys.Attempt.Help().Key)
 					a.logView.SetContent(displayName, noLogMsg)
 				}
 			}
 		} else {
-			a.status = fmt.Sprintf("Error loading logs: %v", msg.Err)
+			a.status = fmt.Sprintf("Error loading logs: %s", ui.FormatError(msg.Err))
 		}
 
 	case ui.RetentionLoadedMsg:
@@ -1680,15 +2491,34 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case dashboard.WindowChangedMsg:
 		a.status = fmt.Sprintf("Loading metrics (%s)...", msg.Window.Label)
+		a.metricsScope.Renew()
 		cmds = append(cmds, a.fetchDashboardData(msg.Window))
 
+	case metricsProgressMsg:
+		if a.currentView == ViewMetrics && msg.window == a.dashboardView.Window().Label {
+			a.status = fmt.Sprintf("Loading metrics (%s): %s", msg.window, msg.progress)
+			a.dashboardView.SetProgress(msg.progress.String())
+		}
+		cmds = append(cmds, waitMetricsProgress(msg.window, msg.next))
+
 	case ui.DashboardDataMsg:
 		if msg.Err == nil {
-			metrics := dashboard.ComputeMetrics(msg.Runs, msg.Jobs, msg.TotalCount)
+			metrics := dashboard.ComputeMetrics(msg.Runs, msg.Jobs)
 			a.dashboardView.SetMetrics(&metrics)
 			a.status = fmt.Sprintf("Metrics: %d runs (%s)", metrics.TotalRuns, a.dashboardView.Window().Label)
+			if msg.MissingJobs > 0 {
+				a.status += fmt.Sprintf(" — jobs of %d runs could not be fetched", msg.MissingJobs)
+			}
+		} else {
+			a.status = fmt.Sprintf("Error loading metrics: %s", ui.FormatError(msg.Err))
+		}
+
+	case ui.MetricsExportedMsg:
+		if msg.Err == nil {
+			base := strings.TrimSuffix(msg.Paths[0], filepath.Ext(msg.Paths[0]))
+			a.status = fmt.Sprintf("Saved metrics to %s.{%s}", base, strings.Join(dashboard.ExportFormats, ","))
 		} else {
-			a.status = fmt.Sprintf("Error loading metrics: %v", msg.Err)
+			a.status = fmt.Sprintf("Error exporting metrics: %s", ui.FormatError(msg.Err))
 		}
 
 	case ui.ActionsCachesLoadedMsg:
@@ -1697,9 +2527,9 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			for _, c := range msg.Caches {
 				total += c.SizeInBytes
 			}
-			a.status = fmt.Sprintf("%d caches (%.1f MB)", len(msg.Caches), float64(total)/(1024*1024))
+			a.status = fmt.Sprintf("%d caches (%.1f MB)%s", len(msg.Caches), float64(total)/(1024*1024), limitNote(len(msg.Caches), msg.TotalCount))
 		} else {
-			a.status = fmt.Sprintf("Error loading caches: %v", msg.Err)
+			a.status = fmt.Sprintf("Error loading caches: %s", ui.FormatError(msg.Err))
 		}
 
 	case ui.ActionsCacheDeletedMsg:
@@ -1707,14 +2537,66 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			a.status = "Cache deleted"
 			cmds = append(cmds, a.fetchActionsCaches())
 		} else {
-			a.status = fmt.Sprintf("Error deleting cache: %v", msg.Err)
+			a.status = fmt.Sprintf("Error deleting cache: %s", ui.FormatError(msg.Err))
+			if len(msg.Remaining) > 0 {
+				a.confirmDialog = confirm.New(
+					"Resume Delete",
+					fmt.Sprintf("%d caches could not be deleted. Retry them?", len(msg.Remaining)),
+					"delete-selected-caches", msg.Remaining,
+				)
+				cmds = append(cmds, a.fetchActionsCaches())
+			}
+		}
+
+	case ui.ArtifactsLoadedMsg:
+		if msg.Err == nil {
+			total := int64(0)
+			for _, art := range msg.Artifacts {
+				total += art.SizeInBytes
+			}
+			a.status = fmt.Sprintf("%d artifacts (%s)%s", len(msg.Artifacts), ui.FormatSize(total), limitNote(len(msg.Artifacts), msg.TotalCount))
+		} else {
+			a.status = fmt.Sprintf("Error loading artifacts: %s", ui.FormatError(msg.Err))
+		}
+
+	case ui.ArtifactDeletedMsg:
+		if msg.Err == nil {
+			a.status = "Artifact deleted"
+			if a.currentView == ViewArtifacts {
+				cmds = append(cmds, a.fetchArtifacts())
+			} else if run := a.detailsView.Run(); run != nil {
+				cmds = append(cmds, a.fetchRunArtifacts(run.ID))
+			}
+		} else {
+			a.status = fmt.Sprintf("Error deleting artifact: %s", ui.FormatError(msg.Err))
+			if len(msg.Remaining) > 0 {
+				a.confirmDialog = confirm.New(
+					"Resume Delete",
+					fmt.Sprintf("%d artifacts could not be deleted. Retry them?", len(msg.Remaining)),
+					"delete-selected-artifacts", msg.Remaining,
+				)
+				cmds = append(cmds, a.fetchArtifacts())
+			}
+		}
+
+	case ui.ArtifactDownloadedMsg:
+		if msg.Err == nil {
+			a.status = fmt.Sprintf("Saved %s to %s", msg.Name, msg.Path)
+			if msg.Renamed {
+				a.status += fmt.Sprintf(" — %s.zip already exists", msg.Name)
+			}
+		} else {
+			a.status = fmt.Sprintf("Error downloading %s: %s", msg.Name, ui.FormatError(msg.Err))
 		}
 
 	case ui.RunnersLoadedMsg:
 		if msg.Err == nil {
 			a.status = fmt.Sprintf("%d runners", len(msg.Runners))
+			if msg.Truncated {
+				a.status += fmt.Sprintf(" — list stopped at %d per scope", listLimit)
+			}
 		} else {
-			a.status = fmt.Sprintf("Error loading runners: %v", msg.Err)
+			a.status = fmt.Sprintf("Error loading runners: %s", ui.FormatError(msg.Err))
 		}
 
 	case ui.SearchDoneMsg:
@@ -1735,12 +2617,16 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if a.logFullScreen {
 				// Full-screen log mode: keys go to log view, esc exits
 				if keyMsg, ok := msg.(tea.KeyMsg); ok {
-					isExit := keyMsg.String() == "esc" || keyMsg.String() == "backspace" || keyMsg.String() == "delete"
-					if isExit && !a.logView.IsSearching() {
+					isExit := key.Matches(keyMsg, ui.Keys.Exit)
+					if isExit && a.logView.IsDiffing() {
+						a.logView.ExitDiff()
+						a.status = "Back to log"
+					} else if isExit && !a.logView.IsSearching() {
 						a.logFullScreen = false
 						// Stop any active tailing
 						a.tailingJobID = 0
 						a.tailingJobName = ""
+						a.tailScope.Cancel()
 						a.viewingJob = nil
 						a.logView.SetTailing(false)
 						if a.cameFromSearch {
@@ -1768,7 +2654,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.infoFullScreen {
 				// Full-screen info mode: esc exits, other keys go to info view
 				if keyMsg, ok := msg.(tea.KeyMsg); ok {
-					isExit := keyMsg.String() == "esc" || keyMsg.String() == "backspace" || keyMsg.String() == "delete"
+					isExit := key.Matches(keyMsg, ui.Keys.Exit)
 					if isExit {
 						a.infoFullScreen = false
 						if a.infoView.IsShowingJob() {
@@ -1822,7 +2708,7 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				// esc navigation: mid->left
-				if keyMsg, ok := msg.(tea.KeyMsg); ok && keyMsg.String() == "esc" {
+				if keyMsg, ok := msg.(tea.KeyMsg); ok && key.Matches(keyMsg, ui.Keys.Back) {
 					if a.focusedPane == PaneMiddle {
 						a.focusedPane = PaneLeft
 					} else if a.focusedPane == PaneLeft && !hadFilter {
@@ -1874,12 +2760,35 @@ func (a App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.runsView, cmd = a.runsView.Update(msg)
 				cmds = append(cmds, cmd)
 			}
+		case ViewArtifacts:
+			var cmd tea.Cmd
+			a.artifactsView, cmd = a.artifactsView.Update(msg)
+			cmds = append(cmds, cmd)
+			switch msg.(type) {
+			case ui.RunsLoadedMsg, ui.RunsPageMsg, ui.RunsRefreshedMsg:
+				a.runsView, cmd = a.runsView.Update(msg)
+				cmds = append(cmds, cmd)
+			}
 		}
 	}
 
 	return &a, tea.Batch(cmds...)
 }
 
+// syncRateLimit copies the client's shared rate-limit budget into the
+// fields rendered by the header.
+func (a *App) syncRateLimit() {
+	rl := a.client.RateLimit()
+	snap := rl.Snapshot()
+	a.rateRemaining = snap.Remaining
+	a.rateLimit = snap.Limit
+	if exhausted, reset := rl.Exhausted(); exhausted {
+		a.rateReset = reset
+	} else {
+		a.rateReset = time.Time{}
+	}
+}
+
 func (a App) executeSearch(pattern string) tea.Cmd {
 	logs := a.currentRunLogs
 	runID := a.currentRunID
@@ -1902,8 +2811,20 @@ func (a App) executeSearch(pattern string) tea.Cmd {
 	}
 }
 
+// morePages reports whether another page of runs follows the current one,
+// which held n runs.
+func (a App) morePages(n int) bool {
+	if a.runsPages > 0 {
+		return a.runsPage < a.runsPages
+	}
+	return n >= a.cfg.RunsPerPage
+}
+
 func (a App) runsPageStatus() string {
-	totalPages := (a.runsTotalCount + runsPerPage - 1) / runsPerPage
+	totalPages := (a.runsTotalCount + a.cfg.RunsPerPage - 1) / a.cfg.RunsPerPage
+	if a.runsPages > 0 {
+		totalPages = a.runsPages
+	}
 	if totalPages < 1 {
 		totalPages = 1
 	}
@@ -1913,16 +2834,21 @@ func (a App) runsPageStatus() string {
 		filterInfo = summary
 	}
 
+	runs := fmt.Sprintf("%d runs", a.runsTotalCount)
+	if len(a.watched) > 0 && a.runsFilter.Repo == "" {
+		runs += fmt.Sprintf(" in %d repos", len(a.watched)+1)
+	}
+
 	if totalPages <= 1 {
 		if filterInfo != "" {
-			return fmt.Sprintf("%d runs (%s)", a.runsTotalCount, filterInfo)
+			return fmt.Sprintf("%s (%s)", runs, filterInfo)
 		}
-		return fmt.Sprintf("%d runs", a.runsTotalCount)
+		return runs
 	}
 	if filterInfo != "" {
-		return fmt.Sprintf("Page %d/%d  |  %d runs  |  %s  |  <-/->: page", a.runsPage, totalPages, a.runsTotalCount, filterInfo)
+		return fmt.Sprintf("Page %d/%d  |  %s  |  %s  |  <-/->: page", a.runsPage, totalPages, runs, filterInfo)
 	}
-	return fmt.Sprintf("Page %d/%d  |  %d runs  |  <-/->: page", a.runsPage, totalPages, a.runsTotalCount)
+	return fmt.Sprintf("Page %d/%d  |  %s  |  <-/->: page", a.runsPage, totalPages, runs)
 }
 
 func (a App) isListFiltering() bool {
@@ -1935,6 +2861,8 @@ func (a App) isListFiltering() bool {
 		return a.cacheView.IsFiltering()
 	case ViewRunners:
 		return a.runnersView.IsFiltering()
+	case ViewArtifacts:
+		return a.artifactsView.IsFiltering()
 	}
 	return false
 }
@@ -1966,6 +2894,14 @@ func (a *App) propagateSize() {
 	// Log view: always full width (shown as full-screen overlay)
 	a.logView, _ = a.logView.Update(
 		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
+	a.yamlView, _ = a.yamlView.Update(
+		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
+	a.jobGraph, _ = a.jobGraph.Update(
+		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
+	a.timeline, _ = a.timeline.Update(
+		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
+	a.compareView, _ = a.compareView.Update(
+		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
 	// Info view: always full width (shown as full-screen overlay)
 	a.infoView, _ = a.infoView.Update(
 		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
@@ -1977,12 +2913,16 @@ func (a *App) propagateSize() {
 		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
 	a.runnersView, _ = a.runnersView.Update(
 		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
+	a.artifactsView, _ = a.artifactsView.Update(
+		tea.WindowSizeMsg{Width: a.width - 4, Height: contentH})
+	a.dispatchForm.SetSize(a.width, a.height)
+	a.deployReview.SetSize(a.width, a.height)
 }
 
 // --- View ---
 
 func (a App) View() string {
-	header := RenderHeader(a.cfg.RepoNWO(), a.rateRemaining, a.rateLimit, a.width)
+	header := RenderHeader(a.cfg.RepoLabel(), a.rateRemaining, a.rateLimit, a.rateReset, a.width)
 	tabs := a.renderTabs()
 
 	var content string
@@ -2017,6 +2957,13 @@ func (a App) View() string {
 		}
 		style := ui.StylePaneFocused.Width(a.width - 2).Height(contentH)
 		content = style.Render(a.runnersView.View())
+	case ViewArtifacts:
+		contentH := a.height - 5
+		if contentH < 1 {
+			contentH = 1
+		}
+		style := ui.StylePaneFocused.Width(a.width - 2).Height(contentH)
+		content = style.Render(a.artifactsView.View())
 	}
 
 	if a.showHelp {
@@ -2026,10 +2973,12 @@ func (a App) View() string {
 		}
 		pct := a.helpViewport.ScrollPercent() * 100
 		header := lipgloss.NewStyle().Bold(true).
-			Foreground(lipgloss.Color("#F9FAFB")).
+			Foreground(ui.ColorText).
 			Render(fmt.Sprintf(" Help  %3.0f%%", pct))
+		k := ui.Keys
 		hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).
-			Render("  j/k:scroll  PgUp/PgDn:page  g/G:top/bot  esc:close")
+			Render("  " + ui.Hints(ui.Hint("scroll", k.Down, k.Up), ui.Hint("page", k.PageUp, k.PageDown),
+				ui.Hint("top/bot", k.Top, k.Bottom), ui.Hint("close", k.Back)))
 		helpContent := header + hints + "\n" + a.helpViewport.View()
 		style := ui.StylePaneFocused.Width(a.width - 2).Height(contentH)
 		content = style.Render(helpContent)
@@ -2037,6 +2986,38 @@ func (a App) View() string {
 		content = a.confirmDialog.View()
 	} else if a.filterOverlay.IsActive() {
 		content = a.filterOverlay.View()
+	} else if a.dispatchForm.IsActive() {
+		content = a.dispatchForm.View()
+	} else if a.deployReview.IsActive() {
+		content = a.deployReview.View()
+	} else if a.jobGraph.IsActive() {
+		contentH := a.height - 5
+		if contentH < 1 {
+			contentH = 1
+		}
+		style := ui.StylePaneFocused.Width(a.width - 2).Height(contentH)
+		content = style.Render(a.jobGraph.View())
+	} else if a.compareView.IsActive() {
+		contentH := a.height - 5
+		if contentH < 1 {
+			contentH = 1
+		}
+		style := ui.StylePaneFocused.Width(a.width - 2).Height(contentH)
+		content = style.Render(a.compareView.View())
+	} else if a.timeline.IsActive() {
+		contentH := a.height - 5
+		if contentH < 1 {
+			contentH = 1
+		}
+		style := ui.StylePaneFocused.Width(a.width - 2).Height(contentH)
+		content = style.Render(a.timeline.View())
+	} else if a.yamlView.IsActive() {
+		contentH := a.height - 5
+		if contentH < 1 {
+			contentH = 1
+		}
+		style := ui.StylePaneFocused.Width(a.width - 2).Height(contentH)
+		content = style.Render(a.yamlView.View())
 	}
 
 	statusBar := RenderStatusBar(a.status, a.contextHints(), a.width)
@@ -2070,6 +3051,7 @@ func (a App) renderTabs() string {
 	dashTab := inactiveTab.Render("[3] Metrics")
 	cacheTab := inactiveTab.Render("[4] Cache")
 	runnersTab := inactiveTab.Render("[5] Runners")
+	artifactsTab := inactiveTab.Render("[6] Artifacts")
 
 	switch a.currentView {
 	case ViewRuns:
@@ -2082,62 +3064,16 @@ func (a App) renderTabs() string {
 		cacheTab = activeTab.Render("[4] Cache")
 	case ViewRunners:
 		runnersTab = activeTab.Render("[5] Runners")
+	case ViewArtifacts:
+		artifactsTab = activeTab.Render("[6] Artifacts")
 	}
 
-	return lipgloss.JoinHorizontal(lipgloss.Top, runsTab, wfTab, dashTab, cacheTab, runnersTab)
+	return lipgloss.JoinHorizontal(lipgloss.Top, runsTab, wfTab, dashTab, cacheTab, runnersTab, artifactsTab)
 }
 
 // findJobLog looks up a job's log in currentRunLogs by exact name, then partial match.
 func (a App) findJobLog(name string) (string, bool) {
-	if content, ok := a.currentRunLogs[name]; ok {
-		return content, true
-	}
-	for k, v := range a.currentRunLogs {
-		if strings.Contains(k, name) || strings.Contains(name, k) {
-			return v, true
-		}
-	}
-	return "", false
-}
-
-// isSystemStub returns true if log content is just a system.txt stub
-// (GitHub includes these for jobs that didn't re-run in a later attempt).
-func isSystemStub(content string) bool {
-	trimmed := strings.TrimSpace(content)
-	if trimmed == "" {
-		return true
-	}
-	// Subdirectory fallback format: only has "=== system.txt ===" section
-	if strings.HasPrefix(trimmed, "=== system.txt ===") {
-		// Check if there's any other === section (actual step logs)
-		rest := trimmed[len("=== system.txt ==="):]
-		return !strings.Contains(rest, "=== ")
-	}
-	// Short content without actual log lines (just runner metadata)
-	lines := strings.Split(trimmed, "\n")
-	if len(lines) < 15 {
-		allSystem := true
-		for _, line := range lines {
-			line = strings.TrimSpace(line)
-			if line == "" || strings.HasPrefix(line, "=== ") {
-				continue
-			}
-			// System metadata lines contain evaluation info, runner info
-			if !strings.Contains(line, "Evaluating") &&
-				!strings.Contains(line, "Expanded:") &&
-				!strings.Contains(line, "Result:") &&
-				!strings.Contains(line, "Waiting for") &&
-				!strings.Contains(line, "Requested labels:") &&
-				!strings.Contains(line, "Job defined at:") &&
-				!strings.Contains(line, "Job is about to start") &&
-				!strings.Contains(line, "runner") {
-				allSystem = false
-				break
-			}
-		}
-		return allSystem
-	}
-	return false
+	return ops.LookupJobLog(a.currentRunLogs, name)
 }
 
 func firstFailedStepName(job *model.Job) string {
@@ -2214,39 +3150,113 @@ func renderStepProgress(job *model.Job) string {
 	return b.String()
 }
 
+// keyContext is the keymap context of the focused view, for the views
+// whose keys go through the main key switch.
+func (a App) keyContext() ui.Context {
+	switch a.currentView {
+	case ViewWorkflows:
+		return ui.ContextWorkflows
+	case ViewMetrics:
+		return ui.ContextMetrics
+	case ViewCache:
+		return ui.ContextCache
+	case ViewRunners:
+		return ui.ContextRunners
+	case ViewArtifacts:
+		return ui.ContextArtifacts
+	}
+	switch {
+	case a.logFullScreen:
+		return ui.ContextLog
+	case a.infoFullScreen:
+		return ui.ContextInfo
+	case a.focusedPane == PaneMiddle:
+		return ui.ContextJobs
+	}
+	return ui.ContextRuns
+}
+
 func (a App) contextHints() string {
+	k := ui.Keys
+	hint := ui.Hint
+	scroll := hint("scroll", k.Down, k.Up)
+	page := hint("page", k.PageUp, k.PageDown)
+	topBottom := hint("top/bot", k.Top, k.Bottom)
+	match := hint("match", k.NextMatch, k.PrevMatch)
+	help := hint("help", k.Help)
 	if a.showHelp {
-		return "j/k:scroll  PgUp/PgDn:page  g/G:top/bot  esc:close"
+		return ui.Hints(scroll, page, topBottom, hint("close", k.Back))
+	}
+	if a.jobGraph.IsActive() {
+		return ui.Hints(scroll, page, topBottom, hint("back", k.Close))
+	}
+	if a.compareView.IsActive() {
+		return ui.Hints(hint("toggle steps", k.ToggleSteps), hint("open commit compare", k.Open), scroll, topBottom, hint("back", k.Close))
+	}
+	if a.timeline.IsActive() {
+		return ui.Hints(hint("job", k.Down, k.Up), hint("steps", k.Enter), hint("expand all", k.ExpandAll),
+			hint("first/last", k.Top, k.Bottom), hint("back", k.Close))
+	}
+	if a.yamlView.IsActive() {
+		if a.yamlView.IsSearching() {
+			return ui.Hints(hint("confirm", k.Enter), hint("cancel", k.Back))
+		}
+		return ui.Hints(hint("search", k.Search), match, scroll, page, topBottom, hint("back", k.Close))
 	}
 	// Full-screen overlays take priority
 	if a.currentView == ViewRuns {
 		if a.logFullScreen {
 			if a.logView.IsSearching() {
-				return "enter:confirm  esc:cancel"
+				return ui.Hints(hint("confirm", k.Enter), hint("cancel", k.Back))
+			}
+			if a.logView.IsPicking() {
+				return ui.Hints(hint("annotation", k.Down, k.Up), hint("jump to line", k.Enter), hint("close", k.Close))
 			}
-			attemptHint := ""
+			if a.logView.IsDiffing() {
+				return ui.Hints(hint("search", k.Search), match, scroll, page, topBottom, hint("back to log", k.Exit))
+			}
+			var attemptHint []string
 			if run := a.detailsView.Run(); run != nil && run.RunAttempt > 1 {
-				attemptHint = "  a:attempt"
+				attemptHint = []string{hint("attempt", k.Attempt), hint("diff", k.LogDiff)}
+			} else if len(a.runsView.CompareRuns()) > 0 {
+				attemptHint = []string{hint("diff", k.LogDiff)}
 			}
+			hints := ui.Hints(append([]string{hint("search", k.Search), match, scroll, page, topBottom},
+				append(attemptHint, hint("back", k.Exit))...)...)
 			if a.tailingJobID > 0 {
-				return "[LIVE]  /:search  n/N:match  j/k:scroll  PgUp/PgDn:page  g/G:top/bot" + attemptHint + "  esc:back"
+				return "[LIVE]  " + hints
 			}
-			return "/:search  n/N:match  j/k:scroll  PgUp/PgDn:page  g/G:top/bot" + attemptHint + "  esc:back"
+			return hints
 		}
 		if a.infoFullScreen {
-			return "j/k:scroll  PgUp/PgDn:page  esc:back"
+			return ui.Hints(scroll, page, hint("back", k.Exit))
 		}
 		if a.searchView.IsActive() {
 			if a.searchView.IsInputMode() {
-				return "enter:search  esc:close"
+				return ui.Hints(hint("search", k.Enter), hint("close", k.Back))
 			}
-			return "enter:view log  j/k:navigate  /:new search  esc:close"
+			return ui.Hints(hint("view log", k.Enter), hint("navigate", k.Down, k.Up), hint("new search", k.Search), hint("close", k.Back))
 		}
 		if a.filterOverlay.IsActive() {
-			return "tab:next field  enter:apply  esc:cancel"
+			return ui.Hints(hint("next field", k.Tab), hint("apply", k.Apply), hint("cancel", k.Back))
+		}
+		if a.deployReview.IsActive() {
+			if a.deployReview.IsEditing() {
+				return ui.Hints(hint("done", k.Enter), hint("cancel", k.Back))
+			}
+			return ui.Hints(hint("environment", k.Down, k.Up), hint("toggle", k.Select), hint("comment", k.Comment),
+				hint("approve", k.Apply), hint("reject", k.Reject), hint("cancel", k.Close))
 		}
+		pageHint := hint("page", k.Left, k.Right)
 		// Normal two-pane mode
 		if a.focusedPane == PaneLeft {
+			if run := a.runsView.SelectedRun(); run != nil && run.Status == model.RunStatusWaiting {
+				return ui.Hints(hint("review deployment", k.Review), pageHint, hint("filter", k.ServerFilter),
+					hint("refresh", k.Refresh), hint("info", k.Info), hint("search", k.Search), help)
+			}
+			if n := len(a.runsView.CompareRuns()); n > 0 {
+				return ui.Hints(fmt.Sprintf("◆=marked (%d/2)", n), hint("mark/unmark", k.Mark), hint("compare", k.Compare), pageHint, help)
+			}
 			legend := fmt.Sprintf("%s=pass %s=fail %s=cancel %s=run %s=queue %s=skip",
 				ui.StatusIcon("success"),
 				ui.StatusIcon("failure"),
@@ -2255,7 +3265,8 @@ func (a App) contextHints() string {
 				ui.StatusIcon("queued"),
 				ui.StatusIcon("skipped"),
 			)
-			return legend + "  |  h/l:page  S:filter  r:refresh  i:info  /:search  ?:help"
+			return legend + "  |  " + ui.Hints(pageHint, hint("filter", k.ServerFilter), hint("refresh", k.Refresh),
+				hint("info", k.Info), hint("search", k.Search), help)
 		}
 		legend := fmt.Sprintf("%s=pass %s=fail %s=cancel %s=run %s=queue %s=skip",
 			ui.StatusIcon("success"),
@@ -2265,21 +3276,39 @@ func (a App) contextHints() string {
 			ui.StatusIcon("queued"),
 			ui.StatusIcon("skipped"),
 		)
-		return legend + "  |  enter:view log  R:rerun  i:info  a:attempt  j/k:navigate  tab:pane  ?:help  esc:back"
+		if a.detailsView.SelectedArtifact() != nil {
+			return legend + "  |  " + ui.Hints(hint("download", k.Enter), hint("delete artifact", k.Delete),
+				hint("navigate", k.Down, k.Up), hint("pane", k.Tab), help, hint("back", k.Back))
+		}
+		return legend + "  |  " + ui.Hints(hint("view log", k.Enter), hint("rerun", k.RerunAll), hint("info", k.Info),
+			hint("attempt", k.Attempt), hint("navigate", k.Down, k.Up), hint("pane", k.Tab), help, hint("back", k.Back))
+	}
+
+	if a.dispatchForm.IsActive() {
+		if a.dispatchForm.IsEditing() {
+			return ui.Hints(hint("done", k.Enter), hint("next field", k.Tab), hint("cancel", k.Back))
+		}
+		return ui.Hints(hint("field", k.Down, k.Up), hint("edit/cycle", k.Enter, k.Left, k.Right),
+			hint("run", k.Apply), hint("defaults", k.Clear), hint("cancel", k.Close))
 	}
 
 	switch a.currentView {
 	case ViewWorkflows:
-		return "enter:view runs  e:enable  D:disable  d:bulk delete  f:filter  ?:help"
+		return ui.Hints(hint("view runs", k.Enter), hint("run workflow", k.Dispatch), hint("enable", k.Enable),
+			hint("disable", k.Disable), hint("bulk delete", k.Delete), hint("filter", k.Filter), help)
 	case ViewMetrics:
-		return "[:prev window  ]:next window  j/k:scroll  ?:help"
+		return ui.Hints(hint("prev window", k.PrevWindow), hint("next window", k.NextWindow), hint("export", k.Export), scroll, help)
 	case ViewCache:
-		return "space:select  d:delete  x:clear all  s:sort  r:refresh  f:filter  ?:help"
+		return ui.Hints(hint("select", k.Select), hint("delete", k.Delete), hint("clear all", k.DeleteAll),
+			hint("sort", k.Sort), hint("refresh", k.Refresh), hint("filter", k.Filter), help)
 	case ViewRunners:
-		return "r:refresh  f:filter  ?:help"
+		return ui.Hints(hint("refresh", k.Refresh), hint("filter", k.Filter), help)
+	case ViewArtifacts:
+		return ui.Hints(hint("download", k.Enter), hint("select", k.Select), hint("delete", k.Delete),
+			hint("sort", k.Sort), hint("refresh", k.Refresh), hint("filter", k.Filter), help)
 	}
 
-	return "?:help  q:quit"
+	return ui.Hints(help, hint("quit", k.Quit))
 }
 
 func (a App) renderRunsLayout() string {
@@ -2331,79 +3360,36 @@ func (a App) renderRunsLayout() string {
 func (a App) renderHelp() string {
 	bold := lipgloss.NewStyle().Bold(true)
 	keyStyle := lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true).Width(14)
-	desc := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
-
-	row := func(k, d string) string {
-		return "  " + keyStyle.Render(k) + desc.Render(d) + "\n"
-	}
-
-	// Left column: Navigation, Runs, Search & Filter, Log Viewer
-	var left strings.Builder
-	left.WriteString(bold.Render("  Navigation") + "\n\n")
-	left.WriteString(row("1-5", "Switch tab"))
-	left.WriteString(row("tab", "Next pane"))
-	left.WriteString(row("shift+tab", "Previous pane"))
-	left.WriteString(row("esc / bksp", "Back / close"))
-	left.WriteString(row("j / k", "Move down / up"))
-	left.WriteString(row("enter", "Select item"))
-	left.WriteString(row("q", "Quit"))
-
-	left.WriteString("\n" + bold.Render("  Runs") + "\n\n")
-	left.WriteString(row("S", "Server-side filter"))
-	left.WriteString(row("space", "Toggle select run"))
-	left.WriteString(row("d", "Delete run"))
-	left.WriteString(row("r", "Refresh"))
-	left.WriteString(row("R", "Rerun all jobs"))
-	left.WriteString(row("F", "Rerun failed jobs"))
-	left.WriteString(row("C / X", "Cancel / force cancel"))
-	left.WriteString(row("i", "Run / job info"))
-	left.WriteString(row("a", "Cycle attempt"))
-	left.WriteString(row("h / l", "Prev / next page"))
-
-	left.WriteString("\n" + bold.Render("  Search & Filter") + "\n\n")
-	left.WriteString(row("/", "Search logs"))
-	left.WriteString(row("f", "Filter list"))
-
-	// Right column: Log Viewer, Jobs, Workflows, Metrics, Cache, Runners
-	var right strings.Builder
-	right.WriteString(bold.Render("  Log Viewer") + "\n\n")
-	right.WriteString(row("/", "Search in log"))
-	right.WriteString(row("n / N", "Next / prev match"))
-	right.WriteString(row("g / G", "Go to top / bottom"))
-	right.WriteString(row("PgUp/PgDn", "Page up / down"))
-	right.WriteString(row("a", "Cycle attempt"))
-	right.WriteString(row("esc", "Exit log view"))
-
-	right.WriteString("\n" + bold.Render("  Jobs") + "\n\n")
-	right.WriteString(row("enter", "View job log"))
-	right.WriteString(row("R", "Rerun job"))
-	right.WriteString(row("i", "Job info"))
-	right.WriteString(row("a", "Cycle attempt"))
-
-	right.WriteString("\n" + bold.Render("  Workflows") + "\n\n")
-	right.WriteString(row("enter", "View runs"))
-	right.WriteString(row("e / D", "Enable / disable"))
-	right.WriteString(row("d / x", "Bulk delete runs"))
-
-	right.WriteString("\n" + bold.Render("  Metrics") + "\n\n")
-	right.WriteString(row("[ / ]", "Cycle time window"))
-
-	right.WriteString("\n" + bold.Render("  Cache") + "\n\n")
-	right.WriteString(row("space", "Toggle select"))
-	right.WriteString(row("d", "Delete selected"))
-	right.WriteString(row("x", "Clear all"))
-	right.WriteString(row("s", "Cycle sort mode"))
-	right.WriteString(row("r", "Refresh"))
-
-	right.WriteString("\n" + bold.Render("  Runners") + "\n\n")
-	right.WriteString(row("r", "Refresh"))
+	desc := lipgloss.NewStyle().Foreground(ui.ColorSubtle)
+
+	// The help is generated from the active bindings, so overrides from
+	// keys.yaml show up here.
+	column := func(contexts ...ui.Context) string {
+		var b strings.Builder
+		for i, ctx := range contexts {
+			section := ui.Keys.Section(ctx)
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(bold.Render("  "+section.Title) + "\n\n")
+			for _, e := range section.Entries {
+				if label := e.Label(); label != "" {
+					b.WriteString("  " + keyStyle.Render(label) + desc.Render(e.Desc) + "\n")
+				}
+			}
+		}
+		return b.String()
+	}
+
+	left := column(ui.ContextGlobal, ui.ContextRuns, ui.ContextJobs)
+	right := column(ui.ContextLog, ui.ContextWorkflows, ui.ContextMetrics, ui.ContextCache, ui.ContextRunners, ui.ContextArtifacts)
 
 	colW := (a.width - 8) / 2
 	if colW < 20 {
 		colW = 20
 	}
-	leftCol := lipgloss.NewStyle().Width(colW).Render(left.String())
-	rightCol := lipgloss.NewStyle().Width(colW).Render(right.String())
+	leftCol := lipgloss.NewStyle().Width(colW).Render(left)
+	rightCol := lipgloss.NewStyle().Width(colW).Render(right)
 
 	return lipgloss.JoinHorizontal(lipgloss.Top, leftCol, "  ", rightCol)
 }
@@ -0,0 +1,272 @@
+package artifactsview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+type artifactItem struct {
+	artifact model.Artifact
+	selected bool
+}
+
+func (a artifactItem) Title() string {
+	mark := " "
+	if a.selected {
+		mark = ui.StyleWarning.Render("● ")
+	}
+	size := ui.StyleWarning.Render(ui.FormatSize(a.artifact.SizeInBytes))
+	expired := ""
+	if a.artifact.Expired {
+		expired = "  " + ui.StyleFailure.Render("[expired]")
+	}
+	return fmt.Sprintf("%s%s  %s%s", mark, a.artifact.Name, size, expired)
+}
+
+func (a artifactItem) Description() string {
+	parts := []string{}
+
+	run := a.artifact.WorkflowRun
+	if run.ID != 0 {
+		parts = append(parts, ui.StyleMuted.Render(fmt.Sprintf("run %d", run.ID)))
+	}
+	if run.HeadBranch != "" {
+		parts = append(parts, ui.StyleInfo.Render(run.HeadBranch))
+	}
+	if len(run.HeadSHA) >= 7 {
+		parts = append(parts, ui.StyleMuted.Render(run.HeadSHA[:7]))
+	}
+	if !a.artifact.CreatedAt.IsZero() {
+		parts = append(parts, ui.StyleMuted.Render("created "+ui.RelativeTime(a.artifact.CreatedAt)))
+	}
+	if !a.artifact.Expired && !a.artifact.ExpiresAt.IsZero() {
+		parts = append(parts, ui.StyleMuted.Render("expires "+a.artifact.ExpiresAt.Local().Format("2006-01-02")))
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+func (a artifactItem) FilterValue() string {
+	return a.artifact.Name + " " + a.artifact.WorkflowRun.HeadBranch
+}
+
+// SortMode determines how artifacts are ordered.
+type SortMode int
+
+const (
+	SortByCreated SortMode = iota
+	SortBySize
+	SortByName
+)
+
+func (s SortMode) String() string {
+	switch s {
+	case SortByCreated:
+		return "created"
+	case SortBySize:
+		return "size"
+	case SortByName:
+		return "name"
+	default:
+		return "created"
+	}
+}
+
+// Model is the artifacts list view.
+type Model struct {
+	list       list.Model
+	artifacts  []model.Artifact
+	selected   map[int64]bool
+	totalCount int
+	sortMode   SortMode
+	totalSize  int64
+	width      int
+	height     int
+	loading    bool
+	err        error
+}
+
+// New creates an artifacts view.
+func New() Model {
+	delegate := list.NewDefaultDelegate()
+	delegate.SetHeight(2)
+	delegate.SetSpacing(0)
+	delegate.Styles = ui.ItemStyles()
+
+	l := list.New(nil, delegate, 0, 0)
+	l.KeyMap = ui.ListKeyMap()
+	l.Styles = ui.ListStyles()
+	l.SetShowTitle(false)
+	l.SetShowHelp(false)
+	l.SetShowStatusBar(true)
+	l.SetFilteringEnabled(true)
+	l.DisableQuitKeybindings()
+
+	return Model{list: l, selected: make(map[int64]bool), loading: true}
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ui.ArtifactsLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.err = nil
+		m.artifacts = msg.Artifacts
+		m.totalCount = msg.TotalCount
+		m.selected = make(map[int64]bool)
+		m.totalSize = 0
+		for _, a := range m.artifacts {
+			m.totalSize += a.SizeInBytes
+		}
+		m.sortArtifacts()
+		cmd := m.list.SetItems(m.buildItems())
+		return m, cmd
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		// Reserve one line for the header.
+		m.list.SetSize(msg.Width, msg.Height-1)
+
+	case tea.KeyMsg:
+		if key.Matches(msg, ui.Keys.Select) && !m.IsFiltering() {
+			if item, ok := m.list.SelectedItem().(artifactItem); ok {
+				id := item.artifact.ID
+				if m.selected[id] {
+					delete(m.selected, id)
+				} else {
+					m.selected[id] = true
+				}
+				cmd := m.list.SetItems(m.buildItems())
+				return m, cmd
+			}
+			return m, nil
+		}
+		if key.Matches(msg, ui.Keys.Sort) && !m.IsFiltering() {
+			m.sortMode = (m.sortMode + 1) % 3
+			m.sortArtifacts()
+			cmd := m.list.SetItems(m.buildItems())
+			return m, cmd
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	if m.loading {
+		return "\n  Loading artifacts..."
+	}
+	if m.err != nil {
+		return ui.RenderError(m.err) + "\n\n  Press " + ui.Keys.Refresh.Help().Key + " to retry."
+	}
+	if len(m.artifacts) == 0 {
+		return "\n  No artifacts found.\n\n  Artifacts are uploaded by workflows with actions/upload-artifact.\n  Press " + ui.Keys.Refresh.Help().Key + " to refresh."
+	}
+
+	countLabel := fmt.Sprintf("%d artifacts", len(m.artifacts))
+	if m.totalCount > len(m.artifacts) {
+		countLabel = fmt.Sprintf("%d / %d artifacts", len(m.artifacts), m.totalCount)
+	}
+
+	k := ui.Keys
+	header := fmt.Sprintf("  %s | Total: %s | Sort: %s | %s",
+		countLabel,
+		ui.FormatSize(m.totalSize),
+		m.sortMode.String(),
+		ui.Hints(ui.Hint("download", k.Enter), ui.Hint("sort", k.Sort), ui.Hint("delete", k.Delete)),
+	)
+	header = ui.StyleMuted.Render(header)
+
+	return header + "\n" + m.list.View()
+}
+
+// SelectedArtifact returns the artifact under the cursor, or nil.
+func (m Model) SelectedArtifact() *model.Artifact {
+	if item, ok := m.list.SelectedItem().(artifactItem); ok {
+		return &item.artifact
+	}
+	return nil
+}
+
+// IsFiltering returns true when the user is actively typing a filter.
+func (m Model) IsFiltering() bool {
+	return m.list.FilterState() == list.Filtering
+}
+
+// HasActiveFilter returns true when a filter is applied.
+func (m Model) HasActiveFilter() bool {
+	return m.list.FilterState() != list.Unfiltered
+}
+
+// ShortHelp returns key bindings for the artifacts view.
+func (m Model) ShortHelp() []key.Binding {
+	return []key.Binding{
+		ui.Keys.Enter,
+		ui.Keys.Sort,
+		ui.Keys.Delete,
+	}
+}
+
+// sortArtifacts sorts m.artifacts in-place based on the current sort mode.
+func (m *Model) sortArtifacts() {
+	switch m.sortMode {
+	case SortByCreated:
+		sort.SliceStable(m.artifacts, func(i, j int) bool {
+			return m.artifacts[i].CreatedAt.After(m.artifacts[j].CreatedAt)
+		})
+	case SortBySize:
+		sort.SliceStable(m.artifacts, func(i, j int) bool {
+			return m.artifacts[i].SizeInBytes > m.artifacts[j].SizeInBytes
+		})
+	case SortByName:
+		sort.SliceStable(m.artifacts, func(i, j int) bool {
+			return strings.ToLower(m.artifacts[i].Name) < strings.ToLower(m.artifacts[j].Name)
+		})
+	}
+}
+
+// buildItems converts the current artifacts slice into list items.
+func (m Model) buildItems() []list.Item {
+	items := make([]list.Item, len(m.artifacts))
+	for i, a := range m.artifacts {
+		items[i] = artifactItem{artifact: a, selected: m.selected[a.ID]}
+	}
+	return items
+}
+
+// SelectedArtifacts returns the IDs of all multi-selected artifacts.
+func (m Model) SelectedArtifacts() []int64 {
+	var ids []int64
+	for id := range m.selected {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// SelectionCount returns the number of selected artifacts.
+func (m Model) SelectionCount() int {
+	return len(m.selected)
+}
+
+// ClearSelection clears all selected artifacts.
+func (m *Model) ClearSelection() {
+	for k := range m.selected {
+		delete(m.selected, k)
+	}
+}
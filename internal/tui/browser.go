@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// openBrowser opens url with the platform's default handler. Failures are
+// reported in the status bar together with the URL so it can be copied.
+func openBrowser(url string) tea.Cmd {
+	return func() tea.Msg {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "darwin":
+			cmd = exec.Command("open", url)
+		case "windows":
+			cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+		default:
+			cmd = exec.Command("xdg-open", url)
+		}
+		if err := cmd.Start(); err != nil {
+			return ui.StatusMsg{Text: fmt.Sprintf("Could not open browser (%s): %s", err, url)}
+		}
+		go cmd.Wait() //nolint:errcheck // reap the handler process
+		return ui.StatusMsg{Text: "Opened " + url}
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -24,7 +23,7 @@ func (c cacheItem) Title() string {
 	if c.selected {
 		mark = ui.StyleWarning.Render("● ")
 	}
-	size := ui.StyleWarning.Render(formatSize(c.entry.SizeInBytes))
+	size := ui.StyleWarning.Render(ui.FormatSize(c.entry.SizeInBytes))
 	return fmt.Sprintf("%s%s  %s", mark, c.entry.Key, size)
 }
 
@@ -44,10 +43,10 @@ func (c cacheItem) Description() string {
 	}
 
 	if !c.entry.CreatedAt.IsZero() {
-		parts = append(parts, ui.StyleMuted.Render("cached "+relativeTime(c.entry.CreatedAt)))
+		parts = append(parts, ui.StyleMuted.Render("cached "+ui.RelativeTime(c.entry.CreatedAt)))
 	}
 	if !c.entry.LastAccessedAt.IsZero() {
-		parts = append(parts, ui.StyleMuted.Render("last used "+relativeTime(c.entry.LastAccessedAt)))
+		parts = append(parts, ui.StyleMuted.Render("last used "+ui.RelativeTime(c.entry.LastAccessedAt)))
 	}
 
 	return strings.Join(parts, "  ")
@@ -98,13 +97,15 @@ func New() Model {
 	delegate := list.NewDefaultDelegate()
 	delegate.SetHeight(2)
 	delegate.SetSpacing(0)
+	delegate.Styles = ui.ItemStyles()
 
 	l := list.New(nil, delegate, 0, 0)
+	l.KeyMap = ui.ListKeyMap()
+	l.Styles = ui.ListStyles()
 	l.SetShowTitle(false)
 	l.SetShowHelp(false)
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
-	l.KeyMap.Filter = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter"))
 	l.DisableQuitKeybindings()
 
 	return Model{list: l, selected: make(map[int64]bool), loading: true}
@@ -138,7 +139,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.list.SetSize(msg.Width, msg.Height-1)
 
 	case tea.KeyMsg:
-		if msg.String() == " " && !m.IsFiltering() {
+		if key.Matches(msg, ui.Keys.Select) && !m.IsFiltering() {
 			if item, ok := m.list.SelectedItem().(cacheItem); ok {
 				id := item.entry.ID
 				if m.selected[id] {
@@ -151,7 +152,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 			return m, nil
 		}
-		if msg.String() == "s" && !m.IsFiltering() {
+		if key.Matches(msg, ui.Keys.Sort) && !m.IsFiltering() {
 			m.sortMode = (m.sortMode + 1) % 3
 			m.sortEntries()
 			cmd := m.list.SetItems(m.buildItems())
@@ -169,10 +170,10 @@ func (m Model) View() string {
 		return "\n  Loading caches..."
 	}
 	if m.err != nil {
-		return fmt.Sprintf("\n  Error: %v\n\n  Press r to retry.", m.err)
+		return ui.RenderError(m.err) + "\n\n  Press " + ui.Keys.Refresh.Help().Key + " to retry."
 	}
 	if len(m.entries) == 0 {
-		return "\n  No caches found.\n\n  This shows GitHub Actions caches (actions/cache).\n  Press r to refresh."
+		return "\n  No caches found.\n\n  This shows GitHub Actions caches (actions/cache).\n  Press " + ui.Keys.Refresh.Help().Key + " to refresh."
 	}
 
 	countLabel := fmt.Sprintf("%d caches", len(m.entries))
@@ -182,7 +183,7 @@ func (m Model) View() string {
 
 	header := fmt.Sprintf("  %s | Total: %s | Sort: %s | s: sort  d: delete  x: clear all",
 		countLabel,
-		formatSize(m.totalSize),
+		ui.FormatSize(m.totalSize),
 		m.sortMode.String(),
 	)
 	header = ui.StyleMuted.Render(header)
@@ -211,9 +212,9 @@ func (m Model) HasActiveFilter() bool {
 // ShortHelp returns key bindings for the cache view.
 func (m Model) ShortHelp() []key.Binding {
 	return []key.Binding{
-		key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "sort")),
-		key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
-		key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "clear all")),
+		ui.Keys.Sort,
+		ui.Keys.Delete,
+		ui.Keys.DeleteAll,
 	}
 }
 
@@ -264,49 +265,3 @@ func (m *Model) ClearSelection() {
 		delete(m.selected, k)
 	}
 }
-
-// formatSize formats a byte count into a human-readable string (KB, MB, GB).
-func formatSize(bytes int64) string {
-	const (
-		kb = 1024
-		mb = 1024 * kb
-		gb = 1024 * mb
-	)
-	switch {
-	case bytes >= gb:
-		return fmt.Sprintf("%.1f GB", float64(bytes)/float64(gb))
-	case bytes >= mb:
-		return fmt.Sprintf("%.1f MB", float64(bytes)/float64(mb))
-	case bytes >= kb:
-		return fmt.Sprintf("%.1f KB", float64(bytes)/float64(kb))
-	default:
-		return fmt.Sprintf("%d B", bytes)
-	}
-}
-
-// relativeTime returns a human-readable relative time string.
-func relativeTime(t time.Time) string {
-	d := time.Since(t)
-	switch {
-	case d < time.Minute:
-		return "just now"
-	case d < time.Hour:
-		m := int(d.Minutes())
-		if m == 1 {
-			return "1 minute ago"
-		}
-		return fmt.Sprintf("%d minutes ago", m)
-	case d < 24*time.Hour:
-		h := int(d.Hours())
-		if h == 1 {
-			return "1 hour ago"
-		}
-		return fmt.Sprintf("%d hours ago", h)
-	default:
-		days := int(d.Hours() / 24)
-		if days == 1 {
-			return "1 day ago"
-		}
-		return fmt.Sprintf("%d days ago", days)
-	}
-}
@@ -0,0 +1,125 @@
+// Package compare shows two runs side by side: their commits and triggers,
+// and how each job's and step's conclusion and duration changed.
+package compare
+
+import (
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// JobDiff pairs a job of the base run with the job of the same name in the
+// head run. Either side is nil when the job only exists in one run.
+type JobDiff struct {
+	Name  string
+	Base  *model.Job
+	Head  *model.Job
+	Steps []StepDiff
+}
+
+// StepDiff pairs steps of the same name within a JobDiff.
+type StepDiff struct {
+	Name string
+	Base *model.Step
+	Head *model.Step
+}
+
+// ConclusionChanged reports whether both runs have the job and it ended
+// differently.
+func (d JobDiff) ConclusionChanged() bool {
+	return d.Base != nil && d.Head != nil && outcome(*d.Base) != outcome(*d.Head)
+}
+
+// Delta is how much longer the head job ran than the base job; ok is false
+// unless both ran to completion.
+func (d JobDiff) Delta() (delta time.Duration, ok bool) {
+	if d.Base == nil || d.Head == nil || d.Base.Duration() == 0 || d.Head.Duration() == 0 {
+		return 0, false
+	}
+	return d.Head.Duration() - d.Base.Duration(), true
+}
+
+// Delta is how much longer the head step ran than the base step.
+func (d StepDiff) Delta() (delta time.Duration, ok bool) {
+	if d.Base == nil || d.Head == nil || d.Base.Duration() == 0 || d.Head.Duration() == 0 {
+		return 0, false
+	}
+	return d.Head.Duration() - d.Base.Duration(), true
+}
+
+// Diff matches the jobs of two runs by name, in the head run's order with
+// jobs that only the base run had appended. Repeated names are paired in
+// order of appearance.
+func Diff(base, head []model.Job) []JobDiff {
+	var out []JobDiff
+	for _, p := range pairByName(base, head, func(j model.Job) string { return j.Name }) {
+		out = append(out, JobDiff{Name: p.name, Base: p.base, Head: p.head, Steps: diffSteps(p.base, p.head)})
+	}
+	return out
+}
+
+func diffSteps(base, head *model.Job) []StepDiff {
+	var baseSteps, headSteps []model.Step
+	if base != nil {
+		baseSteps = base.Steps
+	}
+	if head != nil {
+		headSteps = head.Steps
+	}
+	var out []StepDiff
+	for _, p := range pairByName(baseSteps, headSteps, func(s model.Step) string { return s.Name }) {
+		out = append(out, StepDiff{Name: p.name, Base: p.base, Head: p.head})
+	}
+	return out
+}
+
+// pair is an item of the base list and the item of the same name in the
+// head list; either is nil when only one list has it.
+type pair[T any] struct {
+	name       string
+	base, head *T
+}
+
+// pairByName pairs the items of base and head by name, in head's order with
+// the items only base has appended. Repeated names are paired in order of
+// appearance.
+func pairByName[T any](base, head []T, name func(T) string) []pair[T] {
+	baseByName := make(map[string][]int)
+	for i := range base {
+		n := name(base[i])
+		baseByName[n] = append(baseByName[n], i)
+	}
+	used := make([]bool, len(base))
+
+	var out []pair[T]
+	for i := range head {
+		p := pair[T]{name: name(head[i]), head: &head[i]}
+		if idx := baseByName[p.name]; len(idx) > 0 {
+			p.base = &base[idx[0]]
+			used[idx[0]] = true
+			baseByName[p.name] = idx[1:]
+		}
+		out = append(out, p)
+	}
+	for i := range base {
+		if !used[i] {
+			out = append(out, pair[T]{name: name(base[i]), base: &base[i]})
+		}
+	}
+	return out
+}
+
+// outcome is a job's conclusion, or its status while it has none.
+func outcome(j model.Job) string {
+	if j.Status != model.RunStatusCompleted {
+		return string(j.Status)
+	}
+	return string(j.Conclusion)
+}
+
+func stepOutcome(s model.Step) string {
+	if s.Status != model.RunStatusCompleted {
+		return string(s.Status)
+	}
+	return string(s.Conclusion)
+}
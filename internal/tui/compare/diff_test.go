@@ -0,0 +1,98 @@
+package compare
+
+import (
+	"testing"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+var t0 = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func job(name string, conclusion model.RunConclusion, secs int, steps ...model.Step) model.Job {
+	return model.Job{
+		Name: name, Status: model.RunStatusCompleted, Conclusion: conclusion,
+		StartedAt: t0, CompletedAt: t0.Add(time.Duration(secs) * time.Second),
+		Steps: steps,
+	}
+}
+
+func step(name string, secs int) model.Step {
+	return model.Step{
+		Name: name, Status: model.RunStatusCompleted, Conclusion: model.ConclusionSuccess,
+		StartedAt: t0, CompletedAt: t0.Add(time.Duration(secs) * time.Second),
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base := []model.Job{
+		job("build", model.ConclusionSuccess, 60, step("Checkout", 5), step("Compile", 50)),
+		job("test", model.ConclusionSuccess, 120),
+		job("legacy", model.ConclusionSuccess, 10),
+	}
+	head := []model.Job{
+		job("build", model.ConclusionSuccess, 90, step("Checkout", 5), step("Compile", 80), step("Upload", 3)),
+		job("test", model.ConclusionFailure, 100),
+		job("lint", model.ConclusionSuccess, 20),
+	}
+
+	got := Diff(base, head)
+	tests := []struct {
+		name      string
+		hasBase   bool
+		hasHead   bool
+		changed   bool
+		delta     time.Duration
+		deltaOK   bool
+		stepCount int
+	}{
+		{"build", true, true, false, 30 * time.Second, true, 3},
+		{"test", true, true, true, -20 * time.Second, true, 0},
+		{"lint", false, true, false, 0, false, 0},
+		{"legacy", true, false, false, 0, false, 0},
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("Diff() returned %d jobs, want %d", len(got), len(tests))
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := got[i]
+			if d.Name != tt.name {
+				t.Fatalf("job %d = %q, want %q", i, d.Name, tt.name)
+			}
+			if (d.Base != nil) != tt.hasBase || (d.Head != nil) != tt.hasHead {
+				t.Errorf("sides = %v/%v, want %v/%v", d.Base != nil, d.Head != nil, tt.hasBase, tt.hasHead)
+			}
+			if d.ConclusionChanged() != tt.changed {
+				t.Errorf("ConclusionChanged() = %v, want %v", d.ConclusionChanged(), tt.changed)
+			}
+			if delta, ok := d.Delta(); ok != tt.deltaOK || delta != tt.delta {
+				t.Errorf("Delta() = %v, %v, want %v, %v", delta, ok, tt.delta, tt.deltaOK)
+			}
+			if len(d.Steps) != tt.stepCount {
+				t.Errorf("%d steps, want %d", len(d.Steps), tt.stepCount)
+			}
+		})
+	}
+
+	compile := got[0].Steps[1]
+	if delta, ok := compile.Delta(); !ok || delta != 30*time.Second {
+		t.Errorf("Compile step Delta() = %v, %v, want 30s", delta, ok)
+	}
+	if upload := got[0].Steps[2]; upload.Base != nil {
+		t.Errorf("Upload step should only exist in head")
+	}
+}
+
+func TestDiffPairsRepeatedNames(t *testing.T) {
+	base := []model.Job{job("e2e", model.ConclusionSuccess, 10), job("e2e", model.ConclusionFailure, 20)}
+	head := []model.Job{job("e2e", model.ConclusionSuccess, 15), job("e2e", model.ConclusionSuccess, 25)}
+
+	got := Diff(base, head)
+	if len(got) != 2 {
+		t.Fatalf("Diff() returned %d jobs, want 2", len(got))
+	}
+	if got[0].ConclusionChanged() || !got[1].ConclusionChanged() {
+		t.Errorf("repeated names should pair in order of appearance")
+	}
+}
@@ -0,0 +1,284 @@
+package compare
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+const (
+	nameWidth = 34
+	sideWidth = 20
+)
+
+// Model is the full-screen comparison of two runs. The older run is the
+// base and the newer one the head, whichever order they were marked in.
+type Model struct {
+	viewport  viewport.Model
+	active    bool
+	loading   bool
+	err       error
+	base      model.Run
+	head      model.Run
+	url       string
+	jobs      []JobDiff
+	showSteps bool
+	width     int
+	height    int
+	ready     bool
+}
+
+func New() Model {
+	return Model{}
+}
+
+// Open shows the comparison of runs a and b while their jobs load. url is
+// the commit compare page between their head SHAs.
+func (m *Model) Open(a, b model.Run, url string) {
+	if b.CreatedAt.Before(a.CreatedAt) {
+		a, b = b, a
+	}
+	m.active = true
+	m.loading = true
+	m.err = nil
+	m.base = a
+	m.head = b
+	m.url = url
+	m.jobs = nil
+	m.showSteps = true
+	m.refresh()
+}
+
+// SetJobs supplies the jobs of the base and head runs.
+func (m *Model) SetJobs(base, head []model.Job) {
+	m.loading = false
+	m.jobs = Diff(base, head)
+	m.refresh()
+}
+
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = err
+}
+
+// Runs returns the base and head runs.
+func (m Model) Runs() (base, head model.Run) { return m.base, m.head }
+
+// URL is the commit compare page between the two runs.
+func (m Model) URL() string { return m.url }
+
+func (m Model) IsActive() bool { return m.active }
+func (m Model) Init() tea.Cmd  { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, ui.Keys.Close):
+			m.active = false
+			return m, nil
+		case key.Matches(msg, ui.Keys.ToggleSteps):
+			m.showSteps = !m.showSteps
+			m.refresh()
+			return m, nil
+		case key.Matches(msg, ui.Keys.Top):
+			m.viewport.GotoTop()
+			return m, nil
+		case key.Matches(msg, ui.Keys.Bottom):
+			m.viewport.GotoBottom()
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+		m.refresh()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) refresh() {
+	if !m.ready {
+		return
+	}
+	m.viewport.SetContent(m.render())
+}
+
+func (m Model) render() string {
+	var b strings.Builder
+	bold := lipgloss.NewStyle().Bold(true)
+
+	b.WriteString(row("", bold.Render("Base"), bold.Render("Head"), ""))
+	b.WriteString(row("Run",
+		runLabel(m.base), runLabel(m.head), ""))
+	b.WriteString(row("Commit", m.base.ShortSHA(), m.head.ShortSHA(), sameNote(m.base.HeadSHA == m.head.HeadSHA)))
+	b.WriteString(row("Branch", m.base.HeadBranch, m.head.HeadBranch, ""))
+	b.WriteString(row("Actor", m.base.Actor.Login, m.head.Actor.Login, ""))
+	b.WriteString(row("Event", m.base.Event, m.head.Event, ""))
+	b.WriteString(row("Started", m.base.RunStartedAt.Local().Format("2006-01-02 15:04"),
+		m.head.RunStartedAt.Local().Format("2006-01-02 15:04"), ""))
+	runDelta := ""
+	if m.base.Duration() > 0 && m.head.Duration() > 0 {
+		runDelta = formatDelta(m.head.Duration() - m.base.Duration())
+	}
+	b.WriteString(row("Duration", durationText(m.base.Duration()), durationText(m.head.Duration()), runDelta))
+	if m.url != "" {
+		b.WriteString("\n  " + ui.StyleMuted.Render("Changes: ") + ui.StyleInfo.Render(m.url) + "\n")
+	}
+
+	b.WriteString("\n" + row(bold.Render("Job"), bold.Render("Base"), bold.Render("Head"), bold.Render("Delta")))
+	if len(m.jobs) == 0 {
+		b.WriteString("  No jobs\n")
+	}
+	changed := 0
+	for _, d := range m.jobs {
+		name := truncate(d.Name, nameWidth-2)
+		note := ""
+		if d.ConclusionChanged() {
+			changed++
+			name = ui.StyleWarning.Render(name)
+			note = ui.StyleWarning.Render(fmt.Sprintf("  %s -> %s", outcome(*d.Base), outcome(*d.Head)))
+		}
+		delta := ""
+		if dd, ok := d.Delta(); ok {
+			delta = formatDelta(dd)
+		}
+		b.WriteString(row(name, jobCell(d.Base), jobCell(d.Head), delta) + note)
+		if !m.showSteps {
+			continue
+		}
+		for _, s := range d.Steps {
+			delta := ""
+			if sd, ok := s.Delta(); ok {
+				delta = formatDelta(sd)
+			}
+			b.WriteString(row("    "+ui.StyleMuted.Render(truncate(s.Name, nameWidth-6)),
+				stepCell(s.Base), stepCell(s.Head), delta))
+		}
+	}
+	if changed > 0 {
+		b.WriteString("\n  " + ui.StyleWarning.Render(fmt.Sprintf("%d jobs changed conclusion", changed)) + "\n")
+	}
+	return b.String()
+}
+
+// row lays out one line of the table; cells are padded by display width
+// so styled text lines up.
+func row(name, base, head, delta string) string {
+	return "  " + pad(name, nameWidth) + pad(base, sideWidth) + pad(head, sideWidth) + delta + "\n"
+}
+
+func pad(s string, w int) string {
+	if n := lipgloss.Width(s); n < w {
+		return s + strings.Repeat(" ", w-n)
+	}
+	return s + " "
+}
+
+func runLabel(r model.Run) string {
+	status := string(r.Conclusion)
+	if r.Status != model.RunStatusCompleted {
+		status = string(r.Status)
+	}
+	return fmt.Sprintf("#%d %s", r.RunNumber, ui.ConclusionStyle(status).Render(ui.StatusIcon(status)+" "+status))
+}
+
+func jobCell(j *model.Job) string {
+	if j == nil {
+		return ui.StyleMuted.Render("-")
+	}
+	status := outcome(*j)
+	return ui.ConclusionStyle(status).Render(ui.StatusIcon(status)) + " " + durationText(j.Duration())
+}
+
+func stepCell(s *model.Step) string {
+	if s == nil {
+		return ui.StyleMuted.Render("-")
+	}
+	status := stepOutcome(*s)
+	return ui.ConclusionStyle(status).Render(ui.StatusIcon(status)) + " " + ui.StyleMuted.Render(durationText(s.Duration()))
+}
+
+func sameNote(same bool) string {
+	if same {
+		return ui.StyleMuted.Render("same commit")
+	}
+	return ""
+}
+
+func durationText(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return formatDuration(d)
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatDelta renders a duration change, slower in red and faster in green.
+func formatDelta(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d > 0:
+		return ui.StyleFailure.Render("+" + formatDuration(d))
+	case d < 0:
+		return ui.StyleSuccess.Render("-" + formatDuration(-d))
+	}
+	return ui.StyleMuted.Render("±0s")
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func (m Model) View() string {
+	header := fmt.Sprintf(" Compare  #%d -> #%d", m.base.RunNumber, m.head.RunNumber)
+	if m.ready && !m.loading && m.err == nil {
+		header += fmt.Sprintf("  %3.0f%%", m.viewport.ScrollPercent()*100)
+	}
+	k := ui.Keys
+	hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render("  " + ui.Hints(ui.Hint("steps", k.ToggleSteps),
+		ui.Hint("open compare", k.Open), ui.Hint("scroll", k.Down, k.Up), ui.Hint("back", k.Close)))
+	headerLine := lipgloss.NewStyle().Bold(true).
+		Foreground(ui.ColorText).
+		Render(header) + hints
+
+	switch {
+	case m.err != nil:
+		return headerLine + "\n" + ui.RenderError(m.err)
+	case m.loading:
+		return headerLine + "\n\n  Loading jobs of both runs..."
+	}
+	return headerLine + "\n\n" + m.viewport.View()
+}
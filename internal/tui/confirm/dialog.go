@@ -3,8 +3,11 @@ package confirm
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
 )
 
 type ResultMsg struct {
@@ -43,23 +46,23 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "y", "Y":
+		switch {
+		case key.Matches(msg, ui.Keys.Yes):
 			m.active = false
 			return m, func() tea.Msg {
 				return ResultMsg{Confirmed: true, Action: m.Action, Data: m.Data}
 			}
-		case "n", "N", "esc":
+		case key.Matches(msg, ui.Keys.No):
 			m.active = false
 			return m, func() tea.Msg {
 				return ResultMsg{Confirmed: false, Action: m.Action, Data: m.Data}
 			}
-		case "enter":
+		case key.Matches(msg, ui.Keys.Enter):
 			m.active = false
 			return m, func() tea.Msg {
 				return ResultMsg{Confirmed: m.selected, Action: m.Action, Data: m.Data}
 			}
-		case "tab", "left", "right", "h", "l":
+		case key.Matches(msg, ui.Keys.Tab, ui.Keys.Left, ui.Keys.Right):
 			m.selected = !m.selected
 		}
 	}
@@ -73,28 +76,29 @@ func (m Model) View() string {
 
 	style := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#F59E0B")).
+		BorderForeground(ui.ColorWarning).
 		Padding(1, 2).
 		Width(50)
 
 	title := lipgloss.NewStyle().Bold(true).
-		Foreground(lipgloss.Color("#F59E0B")).
+		Foreground(ui.ColorWarning).
 		Render(m.Title)
 
 	yesStyle := lipgloss.NewStyle().Padding(0, 1)
 	noStyle := lipgloss.NewStyle().Padding(0, 1)
 
 	if m.selected {
-		yesStyle = yesStyle.Bold(true).Background(lipgloss.Color("#10B981")).Foreground(lipgloss.Color("#F9FAFB"))
-		noStyle = noStyle.Foreground(lipgloss.Color("#6B7280"))
+		yesStyle = ui.Badge(ui.ColorSuccess).Padding(0, 1)
+		noStyle = noStyle.Foreground(ui.ColorMuted)
 	} else {
-		yesStyle = yesStyle.Foreground(lipgloss.Color("#6B7280"))
-		noStyle = noStyle.Bold(true).Background(lipgloss.Color("#EF4444")).Foreground(lipgloss.Color("#F9FAFB"))
+		yesStyle = yesStyle.Foreground(ui.ColorMuted)
+		noStyle = ui.Badge(ui.ColorFailure).Padding(0, 1)
 	}
 
-	content := fmt.Sprintf("%s\n\n%s\n\n%s  %s\n\ny/n to confirm, esc to cancel",
+	content := fmt.Sprintf("%s\n\n%s\n\n%s  %s\n\n%s to confirm, %s to cancel",
 		title, m.Message,
-		yesStyle.Render("Yes"), noStyle.Render("No"))
+		yesStyle.Render("Yes"), noStyle.Render("No"),
+		ui.KeyLabel(ui.Keys.Yes), ui.KeyLabel(ui.Keys.No))
 
 	return style.Render(content)
 }
@@ -0,0 +1,213 @@
+package dashboard
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExportFormats are the formats a Report can be written in.
+var ExportFormats = []string{"md", "json", "csv"}
+
+// Report is an export of the metrics of one repository over one window.
+type Report struct {
+	Repo        string    `json:"repo"`
+	Window      string    `json:"window"`
+	Since       time.Time `json:"since"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Metrics     Metrics   `json:"metrics"`
+}
+
+// NewReport builds the report of metrics computed over window at now.
+func NewReport(repo string, window TimeWindow, metrics Metrics, now time.Time) Report {
+	return Report{
+		Repo:        repo,
+		Window:      window.Label,
+		Since:       window.Since(now),
+		GeneratedAt: now.UTC().Truncate(time.Second),
+		Metrics:     metrics,
+	}
+}
+
+// Write writes the report as Markdown, JSON or CSV. Durations are in
+// seconds and rates in percent, except in Markdown, which formats them like
+// the Metrics tab.
+func (r Report) Write(w io.Writer, format string) error {
+	switch format {
+	case "md":
+		return r.writeMarkdown(w)
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case "csv":
+		return r.writeCSV(w)
+	}
+	return fmt.Errorf("unknown format %q, want %s", format, strings.Join(ExportFormats, ", "))
+}
+
+// writeCSV writes one row per value, so that every section fits the same
+// four columns.
+func (r Report) writeCSV(w io.Writer) error {
+	m := r.Metrics
+	cw := csv.NewWriter(w)
+	row := func(section, name, metric string, value any) {
+		var v string
+		switch value := value.(type) {
+		case float64:
+			v = strconv.FormatFloat(value, 'f', 2, 64)
+		default:
+			v = fmt.Sprint(value)
+		}
+		cw.Write([]string{section, name, metric, v})
+	}
+
+	row("section", "name", "metric", "value")
+	row("report", "", "repo", r.Repo)
+	row("report", "", "window", r.Window)
+	row("report", "", "since", r.Since.Format(time.DateOnly))
+	row("report", "", "generated_at", r.GeneratedAt.Format(time.RFC3339))
+
+	row("overview", "", "total_runs", m.TotalRuns)
+	row("overview", "", "success_count", m.SuccessCount)
+	row("overview", "", "failure_count", m.FailureCount)
+	row("overview", "", "cancel_count", m.CancelCount)
+	row("overview", "", "success_rate", m.SuccessRate)
+	row("overview", "", "failure_rate", m.FailureRate)
+	row("overview", "", "retry_rate", m.RetryRate)
+
+	row("performance", "", "mean_duration", m.MeanDuration)
+	row("performance", "", "median_duration", m.MedianDuration)
+	row("performance", "", "p95_duration", m.P95Duration)
+	row("performance", "", "p99_duration", m.P99Duration)
+	row("performance", "", "mean_queue_time", m.MeanQueueTime)
+	row("performance", "", "median_queue_time", m.MedianQueueTime)
+	row("performance", "", "p95_queue_time", m.P95QueueTime)
+
+	for _, s := range m.SlowestWorkflows {
+		row("slowest_workflows", s.Name, "median_duration", s.MedianDuration)
+		row("slowest_workflows", s.Name, "p95_duration", s.P95Duration)
+		row("slowest_workflows", s.Name, "run_count", s.RunCount)
+	}
+	for _, s := range m.TopFailing {
+		row("top_failing_workflows", s.Name, "failure_count", s.FailureCount)
+		row("top_failing_workflows", s.Name, "total_runs", s.TotalRuns)
+		row("top_failing_workflows", s.Name, "failure_rate", s.FailureRate)
+	}
+	for _, s := range m.TopFailingJobs {
+		row("top_failing_jobs", s.Name, "failure_count", s.FailureCount)
+	}
+	for _, e := range sortMapByValue(m.RunsByEvent) {
+		row("runs_by_event", e.Key, "runs", e.Value)
+	}
+	for _, e := range sortMapByValue(m.RunsByActor) {
+		row("runs_by_actor", e.Key, "runs", e.Value)
+	}
+	for _, e := range sortMapByValue(m.RunsByBranch) {
+		row("runs_by_branch", e.Key, "runs", e.Value)
+	}
+
+	row("jobs", "", "total_jobs", m.TotalJobs)
+	row("jobs", "", "job_success_count", m.JobSuccessCount)
+	row("jobs", "", "job_failure_count", m.JobFailureCount)
+	row("jobs", "", "mean_job_duration", m.MeanJobDuration)
+	row("jobs", "", "median_job_duration", m.MedianJobDuration)
+	row("jobs", "", "p95_job_duration", m.P95JobDuration)
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeMarkdown writes the sections of the Metrics tab as tables.
+func (r Report) writeMarkdown(w io.Writer) error {
+	m := r.Metrics
+	var b strings.Builder
+	table := func(title string, header ...string) {
+		fmt.Fprintf(&b, "\n## %s\n\n| %s |\n|", title, strings.Join(header, " | "))
+		for range header {
+			b.WriteString(" --- |")
+		}
+		b.WriteString("\n")
+	}
+	row := func(cells ...any) {
+		b.WriteString("|")
+		for _, c := range cells {
+			fmt.Fprintf(&b, " %s |", mdEscape(fmt.Sprint(c)))
+		}
+		b.WriteString("\n")
+	}
+	pct := func(f float64) string { return fmt.Sprintf("%.1f%%", f) }
+
+	fmt.Fprintf(&b, "# CI health: %s (%s)\n\n", r.Repo, r.Window)
+	fmt.Fprintf(&b, "Runs created since %s, generated %s.\n",
+		r.Since.Format(time.DateOnly), r.GeneratedAt.Format("2006-01-02 15:04 MST"))
+
+	table("Overview", "Metric", "Value")
+	row("Total runs", m.TotalRuns)
+	row("Success", fmt.Sprintf("%d (%s)", m.SuccessCount, pct(m.SuccessRate)))
+	row("Failures", fmt.Sprintf("%d (%s)", m.FailureCount, pct(m.FailureRate)))
+	row("Cancelled", m.CancelCount)
+	row("Retry rate", pct(m.RetryRate))
+
+	table("Performance", "", "Mean", "Median", "p95", "p99")
+	row("Duration", formatSeconds(m.MeanDuration), formatSeconds(m.MedianDuration),
+		formatSeconds(m.P95Duration), formatSeconds(m.P99Duration))
+	row("Queue time", formatSeconds(m.MeanQueueTime), formatSeconds(m.MedianQueueTime),
+		formatSeconds(m.P95QueueTime), "-")
+
+	if len(m.SlowestWorkflows) > 0 {
+		table("Slowest Workflows", "#", "Workflow", "Median", "p95", "Runs")
+		for i, s := range m.SlowestWorkflows {
+			row(i+1, s.Name, formatSeconds(s.MedianDuration), formatSeconds(s.P95Duration), s.RunCount)
+		}
+	}
+	if len(m.TopFailing) > 0 {
+		table("Top Failing Workflows", "#", "Workflow", "Failure rate", "Failures")
+		for i, s := range m.TopFailing {
+			row(i+1, s.Name, pct(s.FailureRate), fmt.Sprintf("%d/%d", s.FailureCount, s.TotalRuns))
+		}
+	}
+	if len(m.TopFailingJobs) > 0 {
+		table("Top Failing Jobs", "#", "Job", "Failures")
+		for i, s := range m.TopFailingJobs {
+			row(i+1, s.Name, s.FailureCount)
+		}
+	}
+	breakdown := func(title, column string, counts map[string]int, limit int) {
+		if len(counts) == 0 {
+			return
+		}
+		table(title, column, "Runs")
+		entries := sortMapByValue(counts)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[:limit]
+		}
+		for _, e := range entries {
+			row(e.Key, e.Value)
+		}
+	}
+	breakdown("Runs by Event", "Event", m.RunsByEvent, 0)
+	breakdown("Top Actors", "Actor", m.RunsByActor, 10)
+	breakdown("Top Branches", "Branch", m.RunsByBranch, 10)
+
+	if m.TotalJobs > 0 {
+		table("Job Performance", "Metric", "Value")
+		row("Total jobs", m.TotalJobs)
+		row("Succeeded", m.JobSuccessCount)
+		row("Failed", m.JobFailureCount)
+		row("Duration", fmt.Sprintf("mean %s / median %s / p95 %s",
+			formatSeconds(m.MeanJobDuration), formatSeconds(m.MedianJobDuration), formatSeconds(m.P95JobDuration)))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// mdEscape keeps a workflow, job or branch name from breaking a table row.
+func mdEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,144 @@
+package dashboard
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		in      string
+		days    int
+		wantErr bool
+	}{
+		{in: "7d", days: 7},
+		{in: "30d", days: 30},
+		{in: "24h", days: 1},
+		{in: "72h", days: 3},
+		{in: "12h", wantErr: true},
+		{in: "0d", wantErr: true},
+		{in: "-3d", wantErr: true},
+		{in: "30", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "2w", wantErr: true},
+		{in: "48dh", wantErr: true},
+	}
+	for _, tt := range tests {
+		w, err := ParseWindow(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseWindow(%q) = %+v, want an error", tt.in, w)
+			}
+			continue
+		}
+		if err != nil || w.Days != tt.days || w.Label != tt.in {
+			t.Errorf("ParseWindow(%q) = %+v, %v, want %d days", tt.in, w, err, tt.days)
+		}
+	}
+}
+
+func TestWindowSince(t *testing.T) {
+	now := time.Date(2025, 3, 10, 1, 30, 0, 0, time.FixedZone("CET", 3600))
+	got := TimeWindow{Label: "7d", Days: 7}.Since(now)
+	if want := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Since() = %v, want %v", got, want)
+	}
+}
+
+func testReport() Report {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	return NewReport("octo/repo", TimeWindow{Label: "7d", Days: 7}, Metrics{
+		TotalRuns:      100,
+		SuccessCount:   90,
+		FailureCount:   10,
+		SuccessRate:    90,
+		FailureRate:    10,
+		MedianDuration: 90,
+		P95Duration:    600,
+		TopFailing:     []WorkflowStat{{Name: "CI | lint", FailureCount: 10, TotalRuns: 40, FailureRate: 25}},
+		TopFailingJobs: []JobStat{{Name: "test", FailureCount: 7}},
+		RunsByEvent:    map[string]int{"push": 60, "pull_request": 40},
+	}, now)
+}
+
+func TestReportMarkdown(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport().Write(&b, "md"); err != nil {
+		t.Fatal(err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"# CI health: octo/repo (7d)\n",
+		"Runs created since 2025-03-03, generated 2025-03-10 12:00 UTC.",
+		"| Total runs | 100 |",
+		"| Duration | 0s | 2m | 10m | 0s |",
+		`| 1 | CI \| lint | 25.0% | 10/40 |`,
+		"| push | 60 |\n| pull_request | 40 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("report lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Job Performance") {
+		t.Errorf("report has a job section without jobs:\n%s", got)
+	}
+}
+
+func TestReportCSV(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport().Write(&b, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := make(map[string]string)
+	for _, r := range records {
+		values[r[0]+"/"+r[1]+"/"+r[2]] = r[3]
+	}
+	for key, want := range map[string]string{
+		"report//repo":                                 "octo/repo",
+		"overview//total_runs":                         "100",
+		"performance//p95_duration":                    "600.00",
+		"top_failing_workflows/CI | lint/failure_rate": "25.00",
+		"runs_by_event/push/runs":                      "60",
+	} {
+		if got := values[key]; got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestReportJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := testReport().Write(&b, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Repo    string
+		Since   time.Time
+		Metrics struct {
+			TotalRuns  int `json:"total_runs"`
+			TopFailing []struct {
+				Name string
+			} `json:"top_failing"`
+		}
+	}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Repo != "octo/repo" || got.Metrics.TotalRuns != 100 || len(got.Metrics.TopFailing) != 1 {
+		t.Errorf("decoded %+v from:\n%s", got, b.String())
+	}
+}
+
+func TestReportUnknownFormat(t *testing.T) {
+	if err := testReport().Write(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("Write(xml) succeeded")
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -23,6 +26,28 @@ func (w TimeWindow) String() string {
 	return w.Label
 }
 
+// Since is the first day, in UTC, of the window ending at now.
+func (w TimeWindow) Since(now time.Time) time.Time {
+	since := now.Add(-time.Duration(w.Days) * 24 * time.Hour).UTC()
+	return time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ParseWindow parses a window such as "30d" or "24h". Hours must make
+// whole days, since runs are filtered by creation date.
+func ParseWindow(s string) (TimeWindow, error) {
+	if len(s) >= 2 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		switch unit := s[len(s)-1]; {
+		case err != nil || n <= 0:
+		case unit == 'd':
+			return TimeWindow{Label: s, Days: n}, nil
+		case unit == 'h' && n%24 == 0:
+			return TimeWindow{Label: s, Days: n / 24}, nil
+		}
+	}
+	return TimeWindow{}, fmt.Errorf("invalid window %q, want a number of days such as 7d or 30d", s)
+}
+
 // DefaultWindows is used as a fallback when retention days are unknown.
 var DefaultWindows = []TimeWindow{
 	{Label: "24h", Days: 1},
@@ -65,58 +90,57 @@ func WindowsForRetention(retentionDays int) []TimeWindow {
 }
 
 type Metrics struct {
-	TotalRuns      int // API total count (may exceed sampled runs)
-	SampledRuns    int // number of runs actually fetched and analyzed
-	SuccessCount   int
-	FailureCount   int
-	CancelCount    int
-	SuccessRate    float64
-	FailureRate    float64
-	MedianDuration float64 // seconds
-	P95Duration    float64 // seconds
-	RetryRate      float64
-	TopFailing     []WorkflowStat
-	TopFailingJobs []JobStat
+	TotalRuns      int            `json:"total_runs"` // every run created in the window
+	SuccessCount   int            `json:"success_count"`
+	FailureCount   int            `json:"failure_count"`
+	CancelCount    int            `json:"cancel_count"`
+	SuccessRate    float64        `json:"success_rate"`
+	FailureRate    float64        `json:"failure_rate"`
+	MedianDuration float64        `json:"median_duration"` // seconds
+	P95Duration    float64        `json:"p95_duration"`    // seconds
+	RetryRate      float64        `json:"retry_rate"`
+	TopFailing     []WorkflowStat `json:"top_failing"`
+	TopFailingJobs []JobStat      `json:"top_failing_jobs"`
 
 	// Usage breakdowns
-	RunsByEvent  map[string]int
-	RunsByActor  map[string]int
-	RunsByBranch map[string]int
+	RunsByEvent  map[string]int `json:"runs_by_event"`
+	RunsByActor  map[string]int `json:"runs_by_actor"`
+	RunsByBranch map[string]int `json:"runs_by_branch"`
 
 	// Performance
-	MeanDuration    float64
-	P99Duration     float64
-	MeanQueueTime   float64
-	MedianQueueTime float64
-	P95QueueTime    float64
-	SlowestWorkflows []WorkflowDurationStat
+	MeanDuration     float64                `json:"mean_duration"`
+	P99Duration      float64                `json:"p99_duration"`
+	MeanQueueTime    float64                `json:"mean_queue_time"`
+	MedianQueueTime  float64                `json:"median_queue_time"`
+	P95QueueTime     float64                `json:"p95_queue_time"`
+	SlowestWorkflows []WorkflowDurationStat `json:"slowest_workflows"`
 
 	// Job-level
-	TotalJobs       int
-	JobSuccessCount int
-	JobFailureCount int
-	MeanJobDuration   float64
-	MedianJobDuration float64
-	P95JobDuration    float64
+	TotalJobs         int     `json:"total_jobs"`
+	JobSuccessCount   int     `json:"job_success_count"`
+	JobFailureCount   int     `json:"job_failure_count"`
+	MeanJobDuration   float64 `json:"mean_job_duration"`
+	MedianJobDuration float64 `json:"median_job_duration"`
+	P95JobDuration    float64 `json:"p95_job_duration"`
 }
 
 type WorkflowStat struct {
-	Name         string
-	FailureCount int
-	TotalRuns    int
-	FailureRate  float64
+	Name         string  `json:"name"`
+	FailureCount int     `json:"failure_count"`
+	TotalRuns    int     `json:"total_runs"`
+	FailureRate  float64 `json:"failure_rate"`
 }
 
 type JobStat struct {
-	Name         string
-	FailureCount int
+	Name         string `json:"name"`
+	FailureCount int    `json:"failure_count"`
 }
 
 type WorkflowDurationStat struct {
-	Name           string
-	MedianDuration float64
-	P95Duration    float64
-	RunCount       int
+	Name           string  `json:"name"`
+	MedianDuration float64 `json:"median_duration"`
+	P95Duration    float64 `json:"p95_duration"`
+	RunCount       int     `json:"run_count"`
 }
 
 type mapEntry struct {
@@ -130,19 +154,18 @@ func sortMapByValue(m map[string]int) []mapEntry {
 		entries = append(entries, mapEntry{k, v})
 	}
 	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Value > entries[j].Value
+		if entries[i].Value != entries[j].Value {
+			return entries[i].Value > entries[j].Value
+		}
+		return entries[i].Key < entries[j].Key
 	})
 	return entries
 }
 
-func ComputeMetrics(runs []model.Run, jobs []model.Job, totalCount int) Metrics {
+func ComputeMetrics(runs []model.Run, jobs []model.Job) Metrics {
 	m := Metrics{}
-	m.SampledRuns = len(runs)
-	m.TotalRuns = totalCount
-	if m.TotalRuns < m.SampledRuns {
-		m.TotalRuns = m.SampledRuns
-	}
-	if m.SampledRuns == 0 {
+	m.TotalRuns = len(runs)
+	if m.TotalRuns == 0 {
 		return m
 	}
 
@@ -211,9 +234,9 @@ func ComputeMetrics(runs []model.Run, jobs []model.Job, totalCount int) Metrics
 		}
 	}
 
-	m.SuccessRate = float64(m.SuccessCount) / float64(m.SampledRuns) * 100
-	m.FailureRate = float64(m.FailureCount) / float64(m.SampledRuns) * 100
-	m.RetryRate = float64(retries) / float64(m.SampledRuns) * 100
+	m.SuccessRate = float64(m.SuccessCount) / float64(m.TotalRuns) * 100
+	m.FailureRate = float64(m.FailureCount) / float64(m.TotalRuns) * 100
+	m.RetryRate = float64(retries) / float64(m.TotalRuns) * 100
 
 	sort.Float64s(durations)
 	if len(durations) > 0 {
@@ -333,6 +356,7 @@ type Model struct {
 	width     int
 	height    int
 	loading   bool
+	progress  string
 	ready     bool
 }
 
@@ -357,9 +381,15 @@ func (m *Model) SetWindows(windows []TimeWindow) {
 	}
 }
 
+// SetProgress shows how far loading has got.
+func (m *Model) SetProgress(progress string) {
+	m.progress = progress
+}
+
 func (m *Model) SetMetrics(metrics *Metrics) {
 	m.metrics = metrics
 	m.loading = false
+	m.progress = ""
 	if m.ready {
 		m.viewport.SetContent(m.render())
 	}
@@ -371,6 +401,14 @@ type WindowChangedMsg struct {
 	Window TimeWindow
 }
 
+// Metrics returns the metrics shown, or nil while none are loaded.
+func (m Model) Metrics() *Metrics {
+	if m.loading {
+		return nil
+	}
+	return m.metrics
+}
+
 func (m Model) Window() TimeWindow {
 	if m.windowIdx >= 0 && m.windowIdx < len(m.windows) {
 		return m.windows[m.windowIdx]
@@ -382,12 +420,12 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		var newIdx int = -1
-		switch msg.String() {
-		case "[":
+		switch {
+		case key.Matches(msg, ui.Keys.PrevWindow):
 			if m.windowIdx > 0 {
 				newIdx = m.windowIdx - 1
 			}
-		case "]":
+		case key.Matches(msg, ui.Keys.NextWindow):
 			if m.windowIdx < len(m.windows)-1 {
 				newIdx = m.windowIdx + 1
 			}
@@ -395,6 +433,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		if newIdx >= 0 && newIdx != m.windowIdx {
 			m.windowIdx = newIdx
 			m.loading = true
+			m.progress = ""
 			w := m.windows[newIdx]
 			return m, func() tea.Msg {
 				return WindowChangedMsg{Window: w}
@@ -406,6 +445,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.height = msg.Height
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
 			m.ready = true
 		} else {
 			m.viewport.Width = msg.Width
@@ -435,11 +475,7 @@ func (m Model) render() string {
 	// ── Overview ──────────────────────────────────────────────────────
 	b.WriteString(bold.Render(fmt.Sprintf("  Overview (%s)", w.Label)) + "\n\n")
 
-	totalLabel := bold.Render(fmt.Sprintf("%d", met.TotalRuns))
-	if met.SampledRuns < met.TotalRuns {
-		totalLabel += muted.Render(fmt.Sprintf("  (analyzed %d)", met.SampledRuns))
-	}
-	b.WriteString(fmt.Sprintf("  Total Runs: %s\n", totalLabel))
+	b.WriteString(fmt.Sprintf("  Total Runs: %s\n", bold.Render(fmt.Sprintf("%d", met.TotalRuns))))
 	b.WriteString(fmt.Sprintf("  Success:    %s (%s)\n",
 		ui.StyleSuccess.Render(fmt.Sprintf("%d", met.SuccessCount)),
 		fmt.Sprintf("%.1f%%", met.SuccessRate)))
@@ -623,12 +659,15 @@ func formatSeconds(s float64) string {
 
 func (m Model) View() string {
 	if m.loading {
+		if m.progress != "" {
+			return "\n  Loading metrics: " + m.progress
+		}
 		return "\n  Loading metrics..."
 	}
 
 	// Build tabs line showing all windows with current highlighted
 	muted := lipgloss.NewStyle().Foreground(ui.ColorMuted)
-	active := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#F9FAFB"))
+	active := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorText)
 
 	var parts []string
 	for i, w := range m.windows {
@@ -638,7 +677,7 @@ func (m Model) View() string {
 			parts = append(parts, muted.Render(w.Label))
 		}
 	}
-	tabs := "  " + strings.Join(parts, "  ") + "    " + muted.Render("press [ or ] to switch")
+	tabs := "  " + strings.Join(parts, "  ") + "    " + muted.Render(fmt.Sprintf("press %s or %s to switch", ui.Keys.PrevWindow.Help().Key, ui.Keys.NextWindow.Help().Key))
 
 	if m.ready {
 		return tabs + "\n" + m.viewport.View()
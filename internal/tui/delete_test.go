@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/config"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+func TestDeleteIDsConcurrently(t *testing.T) {
+	client := &api.Client{}
+	ids := []int64{1, 2, 3, 4, 5, 6, 7}
+	out := deleteIDsConcurrently(func(int64) *api.Client { return client }, ids, 3,
+		func(_ *api.Client, _ context.Context, id int64) error {
+			if id%3 == 0 {
+				return errors.New("conflict")
+			}
+			return nil
+		})
+	slices.Sort(out.failed)
+	if out.deleted != 5 || !slices.Equal(out.failed, []int64{3, 6}) || out.err == nil {
+		t.Errorf("deleteIDsConcurrently() = %+v, want 5 deleted and 3, 6 failed", out)
+	}
+}
+
+func TestPartialCacheDeleteOffersResume(t *testing.T) {
+	a := NewApp(config.Config{}, &api.Client{}, nil)
+	m, _ := a.Update(ui.ActionsCacheDeletedMsg{Err: errors.New("deleted 1/3 caches"), Remaining: []int64{4, 5}})
+	a = *m.(*App)
+	if !a.confirmDialog.IsActive() {
+		t.Error("no resume dialog after a partial cache delete")
+	}
+}
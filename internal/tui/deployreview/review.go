@@ -0,0 +1,297 @@
+package deployreview
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// ResultMsg is emitted when the user approves, rejects or closes the overlay.
+type ResultMsg struct {
+	Submitted      bool
+	Run            model.Run
+	EnvironmentIDs []int64
+	Environments   []string
+	State          model.DeploymentReviewState
+	Comment        string
+}
+
+// Model is the pending deployment review overlay. It lists the environments
+// a waiting run is blocked on; the ones the current user may approve are
+// selected by default.
+type Model struct {
+	active      bool
+	loading     bool
+	run         model.Run
+	deployments []model.PendingDeployment
+	selected    map[int64]bool
+	cursor      int
+	comment     textinput.Model
+	err         string
+	width       int
+	height      int
+	now         func() time.Time
+}
+
+// New creates an active overlay for run, waiting for its deployments.
+func New(run model.Run) Model {
+	c := textinput.New()
+	c.Placeholder = "optional comment"
+	c.CharLimit = 512
+	ui.StyleTextInput(&c)
+	c.Width = 46
+	return Model{
+		active:   true,
+		loading:  true,
+		run:      run,
+		selected: make(map[int64]bool),
+		comment:  c,
+		now:      time.Now,
+	}
+}
+
+// SetDeployments fills the overlay once the pending deployments are loaded.
+func (m *Model) SetDeployments(deployments []model.PendingDeployment) {
+	m.loading = false
+	m.err = ""
+	m.deployments = deployments
+	m.cursor = 0
+	m.selected = make(map[int64]bool)
+	for _, d := range deployments {
+		if d.CurrentUserCanApprove {
+			m.selected[d.Environment.ID] = true
+		}
+	}
+	if len(deployments) == 0 {
+		m.err = "No deployments are waiting for review."
+	}
+}
+
+// SetError shows an error in the overlay.
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = ui.FormatError(err)
+}
+
+// Run returns the run being reviewed.
+func (m Model) Run() model.Run { return m.run }
+
+// IsActive reports whether the overlay is currently visible.
+func (m Model) IsActive() bool { return m.active }
+
+// IsEditing reports whether the comment field has focus.
+func (m Model) IsEditing() bool { return m.comment.Focused() }
+
+// SetSize stores terminal dimensions so the overlay can centre itself.
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Init satisfies the tea.Model interface.
+func (m Model) Init() tea.Cmd { return nil }
+
+// Update handles key events while the overlay is active.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.comment.Focused() {
+		switch {
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.Back):
+			m.active = false
+			return m, m.emitResult("")
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.Enter, ui.Keys.Tab, ui.Keys.Up, ui.Keys.Down):
+			m.comment.Blur()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.comment, cmd = m.comment.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, ui.Keys.Down):
+		if m.cursor < len(m.deployments)-1 {
+			m.cursor++
+		}
+	case key.Matches(keyMsg, ui.Keys.Up):
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case key.Matches(keyMsg, ui.Keys.Select):
+		if m.cursor < len(m.deployments) {
+			d := m.deployments[m.cursor]
+			if !d.CurrentUserCanApprove {
+				m.err = fmt.Sprintf("You are not a required reviewer for %s.", d.Environment.Name)
+				return m, nil
+			}
+			m.selected[d.Environment.ID] = !m.selected[d.Environment.ID]
+			m.err = ""
+		}
+	case key.Matches(keyMsg, ui.Keys.Comment, ui.Keys.Enter, ui.Keys.Tab):
+		m.comment.Focus()
+		return m, textinput.Blink
+	case key.Matches(keyMsg, ui.Keys.Apply):
+		return m.submit(model.DeploymentApproved)
+	case key.Matches(keyMsg, ui.Keys.Reject):
+		return m.submit(model.DeploymentRejected)
+	case key.Matches(keyMsg, ui.Keys.Close):
+		m.active = false
+		return m, m.emitResult("")
+	}
+	return m, nil
+}
+
+func (m Model) submit(state model.DeploymentReviewState) (Model, tea.Cmd) {
+	if m.loading {
+		return m, nil
+	}
+	if len(m.selectedIDs()) == 0 {
+		m.err = "Select at least one environment you can review."
+		return m, nil
+	}
+	m.active = false
+	return m, m.emitResult(state)
+}
+
+// selectedIDs returns the selected environments in display order.
+func (m Model) selectedIDs() []int64 {
+	var ids []int64
+	for _, d := range m.deployments {
+		if m.selected[d.Environment.ID] {
+			ids = append(ids, d.Environment.ID)
+		}
+	}
+	return ids
+}
+
+func (m Model) emitResult(state model.DeploymentReviewState) tea.Cmd {
+	result := ResultMsg{Run: m.run}
+	if state != "" {
+		result.Submitted = true
+		result.State = state
+		result.Comment = strings.TrimSpace(m.comment.Value())
+		for _, d := range m.deployments {
+			if m.selected[d.Environment.ID] {
+				result.EnvironmentIDs = append(result.EnvironmentIDs, d.Environment.ID)
+				result.Environments = append(result.Environments, d.Environment.Name)
+			}
+		}
+	}
+	return func() tea.Msg { return result }
+}
+
+// View renders the overlay.
+func (m Model) View() string {
+	if !m.active {
+		return ""
+	}
+
+	nameStyle := lipgloss.NewStyle().Foreground(ui.ColorText)
+	focusedStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorPrimary)
+	hintStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+
+	var rows []string
+	if m.loading {
+		rows = append(rows, hintStyle.Render("  Loading pending deployments..."))
+	}
+	for i, d := range m.deployments {
+		cursor := "  "
+		ns := nameStyle
+		if i == m.cursor {
+			cursor = focusedStyle.Render("> ")
+			ns = focusedStyle
+		}
+		box := "[ ]"
+		switch {
+		case !d.CurrentUserCanApprove:
+			box = hintStyle.Render(" - ")
+		case m.selected[d.Environment.ID]:
+			box = "[x]"
+		}
+		line := fmt.Sprintf("%s%s %s", cursor, box, ns.Render(d.Environment.Name))
+		if d.WaitTimer > 0 {
+			wait := fmt.Sprintf("wait timer %dm", d.WaitTimer)
+			if left := d.WaitRemaining(m.now()); left > 0 {
+				wait += fmt.Sprintf(", %s left", left.Round(time.Second))
+			}
+			line += "  " + ui.StyleWarning.Render(wait)
+		}
+		rows = append(rows, line)
+
+		var reviewers []string
+		for _, r := range d.Reviewers {
+			reviewers = append(reviewers, r.DisplayName())
+		}
+		detail := "no required reviewers"
+		if len(reviewers) > 0 {
+			detail = "reviewers: " + strings.Join(reviewers, ", ")
+		}
+		if !d.CurrentUserCanApprove {
+			detail += " (you cannot approve)"
+		}
+		rows = append(rows, hintStyle.Render("      "+truncate(detail, 52)))
+	}
+
+	rows = append(rows, "", "  Comment: "+m.comment.View())
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ui.ColorPrimary).
+		MarginBottom(1).
+		Render(fmt.Sprintf("Review Deployments: #%d %s", m.run.RunNumber, truncate(m.run.DisplayTitle, 30)))
+
+	var footer []string
+	if m.err != "" {
+		footer = append(footer, ui.StyleFailure.Render(m.err))
+	}
+	k := ui.Keys
+	if m.comment.Focused() {
+		footer = append(footer, hintStyle.Render(ui.Hints(ui.Hint("done", k.Enter), ui.Hint("cancel", k.Back))))
+	} else {
+		footer = append(footer, hintStyle.Render(ui.Hints(ui.Hint("approve", k.Apply), ui.Hint("reject", k.Reject),
+			ui.Hint("toggle", k.Select), ui.Hint("comment", k.Comment), ui.Hint("cancel", k.Close))))
+	}
+	help := lipgloss.NewStyle().MarginTop(1).Render(strings.Join(footer, "\n"))
+
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		strings.Join(rows, "\n"),
+		help,
+	)
+
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorPrimary).
+		Padding(1, 2).
+		Width(64).
+		Render(body)
+
+	if m.width > 0 && m.height > 0 {
+		return lipgloss.Place(m.width, m.height,
+			lipgloss.Center, lipgloss.Center,
+			box)
+	}
+	return box
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
@@ -0,0 +1,70 @@
+package deployreview
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func keyPress(s string) tea.KeyMsg {
+	if s == " " {
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func deployment(id int64, name string, canApprove bool) model.PendingDeployment {
+	d := model.PendingDeployment{CurrentUserCanApprove: canApprove}
+	d.Environment.ID = id
+	d.Environment.Name = name
+	return d
+}
+
+func TestReviewSubmit(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []string
+		wantState  model.DeploymentReviewState
+		wantIDs    []int64
+		wantActive bool
+	}{
+		{"approve preselected", []string{"a"}, model.DeploymentApproved, []int64{1, 3}, false},
+		{"reject after deselecting", []string{" ", "x"}, model.DeploymentRejected, []int64{3}, false},
+		{"cannot toggle unapprovable", []string{"j", " ", "a"}, model.DeploymentApproved, []int64{1, 3}, false},
+		{"nothing selected", []string{" ", "j", "j", " ", "a"}, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(model.Run{ID: 9, RunNumber: 4})
+			m.SetDeployments([]model.PendingDeployment{
+				deployment(1, "staging", true),
+				deployment(2, "production", false),
+				deployment(3, "canary", true),
+			})
+
+			var cmd tea.Cmd
+			for _, k := range tt.keys {
+				m, cmd = m.Update(keyPress(k))
+			}
+			if m.IsActive() != tt.wantActive {
+				t.Fatalf("IsActive() = %v, want %v", m.IsActive(), tt.wantActive)
+			}
+			if tt.wantActive {
+				return
+			}
+			res, ok := cmd().(ResultMsg)
+			if !ok || !res.Submitted {
+				t.Fatalf("result = %#v, want a submitted ResultMsg", res)
+			}
+			if res.State != tt.wantState || !reflect.DeepEqual(res.EnvironmentIDs, tt.wantIDs) {
+				t.Errorf("result = %s %v, want %s %v", res.State, res.EnvironmentIDs, tt.wantState, tt.wantIDs)
+			}
+			if res.Run.ID != 9 {
+				t.Errorf("result run = %d, want 9", res.Run.ID)
+			}
+		})
+	}
+}
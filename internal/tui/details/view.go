@@ -147,6 +147,9 @@ type Model struct {
 
 	// Available logs keyed by job name — used to show log indicator
 	availableLogs map[string]string
+
+	// Artifacts uploaded by the run, listed below the jobs
+	artifacts []model.Artifact
 }
 
 func New() Model {
@@ -158,6 +161,7 @@ func (m *Model) SetRun(run *model.Run) {
 	m.loading = true
 	m.cursor = 0
 	m.viewingAttempt = 0
+	m.artifacts = nil
 	if run != nil {
 		m.maxAttempt = run.RunAttempt
 	} else {
@@ -248,6 +252,33 @@ func (m Model) SelectedJob() *model.Job {
 	return nil
 }
 
+// SelectedArtifact returns the artifact under the cursor, or nil when the
+// cursor is on a job.
+func (m Model) SelectedArtifact() *model.Artifact {
+	i := m.cursor - len(m.flatJobs())
+	if i >= 0 && i < len(m.artifacts) {
+		return &m.artifacts[i]
+	}
+	return nil
+}
+
+// itemCount is the number of cursor positions: jobs followed by artifacts.
+func (m Model) itemCount() int {
+	return len(m.flatJobs()) + len(m.artifacts)
+}
+
+// clampCursor keeps the cursor within the jobs and artifacts list.
+func (m *Model) clampCursor() {
+	n := m.itemCount()
+	if m.cursor >= n {
+		if n > 0 {
+			m.cursor = n - 1
+		} else {
+			m.cursor = 0
+		}
+	}
+}
+
 func (m Model) flatJobs() []model.Job {
 	var jobs []model.Job
 	for _, g := range m.groups {
@@ -280,23 +311,29 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		})
 		m.groups = groupJobs(m.jobs)
 		// Preserve cursor position across refreshes; clamp if jobs list shrank
-		flat := m.flatJobs()
-		if m.cursor >= len(flat) {
-			if len(flat) > 0 {
-				m.cursor = len(flat) - 1
-			} else {
-				m.cursor = 0
-			}
+		m.clampCursor()
+		if m.ready {
+			m.viewport.SetContent(m.renderJobs())
 		}
+
+	case ui.RunArtifactsLoadedMsg:
+		if m.run == nil || msg.RunID != m.run.ID || msg.Err != nil {
+			return m, nil
+		}
+		m.artifacts = msg.Artifacts
+		sort.Slice(m.artifacts, func(i, j int) bool {
+			return m.artifacts[i].Name < m.artifacts[j].Name
+		})
+		m.clampCursor()
 		if m.ready {
 			m.viewport.SetContent(m.renderJobs())
 		}
+		return m, nil
 
 	case tea.KeyMsg:
-		flat := m.flatJobs()
 		switch {
 		case key.Matches(msg, ui.Keys.Down):
-			if m.cursor < len(flat)-1 {
+			if m.cursor < m.itemCount()-1 {
 				m.cursor++
 				if m.ready {
 					m.viewport.SetContent(m.renderJobs())
@@ -316,6 +353,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.height = msg.Height
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width, msg.Height-1)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
 			m.ready = true
 		} else {
 			m.viewport.Width = msg.Width
@@ -371,8 +409,8 @@ func (m Model) renderJobs() string {
 	}
 
 	bold := lipgloss.NewStyle().Bold(true)
-	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-	highlight := lipgloss.NewStyle().Background(lipgloss.Color("#1F2937"))
+	muted := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	highlight := ui.StyleSelected
 
 	var b strings.Builder
 	idx := 0
@@ -501,6 +539,28 @@ func (m Model) renderJobs() string {
 			}
 		}
 	}
+
+	if len(m.artifacts) > 0 {
+		b.WriteString(fmt.Sprintf("\n  %s %s\n",
+			bold.Render("Artifacts"),
+			muted.Render(fmt.Sprintf("(%d)", len(m.artifacts)))))
+		for _, a := range m.artifacts {
+			cursor := "  "
+			if idx == m.cursor {
+				cursor = "> "
+			}
+			expired := ""
+			if a.Expired {
+				expired = muted.Render(" [expired]")
+			}
+			line := fmt.Sprintf("%s  %s  %s%s", cursor, a.Name, ui.FormatSize(a.SizeInBytes), expired)
+			if idx == m.cursor {
+				line = highlight.Render(line)
+			}
+			b.WriteString(line + "\n")
+			idx++
+		}
+	}
 	return b.String()
 }
 
@@ -509,7 +569,7 @@ func (m Model) View() string {
 		return "\n  Loading jobs..."
 	}
 	if m.err != nil {
-		return fmt.Sprintf("\n  Error: %v", m.err)
+		return ui.RenderError(m.err)
 	}
 	if m.run == nil {
 		return "\n  Select a run"
@@ -0,0 +1,543 @@
+package dispatchform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// ---------------------------------------------------------------------------
+// Messages
+// ---------------------------------------------------------------------------
+
+// ResultMsg is emitted when the user submits or cancels the form.
+type ResultMsg struct {
+	Submitted bool
+	Workflow  model.Workflow
+	Ref       string
+	Inputs    map[string]string
+}
+
+// RefChangedMsg is emitted when the user edits the ref, so the workflow file
+// can be re-read at that ref and the inputs rebuilt.
+type RefChangedMsg struct {
+	Workflow model.Workflow
+	Ref      string
+}
+
+// ---------------------------------------------------------------------------
+// Fields
+// ---------------------------------------------------------------------------
+
+// inputField holds the editing state for one workflow input.
+type inputField struct {
+	input   model.DispatchInput
+	options []string // choice options or environment names
+	optIdx  int      // -1 = unset
+	boolVal bool
+	text    textinput.Model
+}
+
+// usesText reports whether the field is edited as free text. Environment
+// inputs fall back to text when the repository has no environments.
+func (f inputField) usesText() bool {
+	switch f.input.Type {
+	case model.DispatchInputChoice, model.DispatchInputEnvironment:
+		return len(f.options) == 0
+	case model.DispatchInputBoolean:
+		return false
+	}
+	return true
+}
+
+func (f inputField) value() string {
+	switch {
+	case f.input.Type == model.DispatchInputBoolean:
+		return strconv.FormatBool(f.boolVal)
+	case f.usesText():
+		return strings.TrimSpace(f.text.Value())
+	case f.optIdx >= 0 && f.optIdx < len(f.options):
+		return f.options[f.optIdx]
+	}
+	return ""
+}
+
+func newInputField(in model.DispatchInput, environments []string) inputField {
+	f := inputField{input: in, optIdx: -1}
+	switch in.Type {
+	case model.DispatchInputChoice:
+		f.options = in.Options
+	case model.DispatchInputEnvironment:
+		f.options = environments
+	}
+
+	f.text = textinput.New()
+	f.text.CharLimit = 256
+	ui.StyleTextInput(&f.text)
+	f.text.Width = 34
+	if in.Type == model.DispatchInputNumber {
+		f.text.Placeholder = "number"
+	}
+	f.setValue(in.Default)
+	return f
+}
+
+// setValue applies a string value to whichever editor the field uses.
+func (f *inputField) setValue(v string) {
+	switch {
+	case f.input.Type == model.DispatchInputBoolean:
+		f.boolVal = v == "true"
+	case f.usesText():
+		f.text.SetValue(v)
+	default:
+		f.optIdx = -1
+		for i, o := range f.options {
+			if o == v {
+				f.optIdx = i
+				break
+			}
+		}
+		// GitHub falls back to the first option for required choices.
+		if f.optIdx < 0 && f.input.Required && len(f.options) > 0 {
+			f.optIdx = 0
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Model
+// ---------------------------------------------------------------------------
+
+// Model is the workflow dispatch form overlay. Focus index 0 is the ref
+// field; the workflow's inputs follow in declaration order.
+type Model struct {
+	active   bool
+	loading  bool
+	workflow model.Workflow
+	ref      textinput.Model
+	loadedAt string // ref the current inputs were parsed from
+	fields   []inputField
+	focused  int
+	err      string
+	width    int
+	height   int
+}
+
+// New creates an active dispatch form for a workflow with inputs parsed at ref.
+func New(wf model.Workflow, ref string, inputs []model.DispatchInput, environments []string) Model {
+	r := textinput.New()
+	r.Placeholder = "branch or tag"
+	r.CharLimit = 256
+	ui.StyleTextInput(&r)
+	r.Width = 34
+	r.SetValue(ref)
+
+	m := Model{
+		active:   true,
+		workflow: wf,
+		ref:      r,
+		loadedAt: ref,
+	}
+	for _, in := range inputs {
+		m.fields = append(m.fields, newInputField(in, environments))
+	}
+	return m
+}
+
+// SetInputs replaces the inputs after the workflow was re-read at a new ref.
+// Values the user already entered are kept for inputs that still exist.
+func (m *Model) SetInputs(ref string, inputs []model.DispatchInput, environments []string) {
+	previous := make(map[string]string, len(m.fields))
+	for _, f := range m.fields {
+		previous[f.input.Name] = f.value()
+	}
+
+	m.loading = false
+	m.err = ""
+	m.loadedAt = ref
+	m.fields = nil
+	for _, in := range inputs {
+		f := newInputField(in, environments)
+		if v, ok := previous[in.Name]; ok {
+			f.setValue(v)
+		}
+		m.fields = append(m.fields, f)
+	}
+	if m.focused > len(m.fields) {
+		m.focused = len(m.fields)
+	}
+}
+
+// SetError shows an error in the form, e.g. when the ref could not be read.
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = ui.FormatError(err)
+}
+
+// Workflow returns the workflow being dispatched.
+func (m Model) Workflow() model.Workflow { return m.workflow }
+
+// IsActive reports whether the overlay is currently visible.
+func (m Model) IsActive() bool { return m.active }
+
+// IsEditing reports whether a text field has focus.
+func (m Model) IsEditing() bool { return m.isTextFieldFocused() }
+
+// SetSize stores terminal dimensions so the overlay can centre itself.
+func (m *Model) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Init satisfies the tea.Model interface.
+func (m Model) Init() tea.Cmd { return nil }
+
+// ---------------------------------------------------------------------------
+// Update
+// ---------------------------------------------------------------------------
+
+// Update handles key events while the overlay is active.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if !m.active {
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	// When a text input is focused, let it handle most keys first.
+	if m.isTextFieldFocused() {
+		switch {
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.Back):
+			m.active = false
+			return m, m.emitResult(false)
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.Enter):
+			return m, m.blurTextInputs()
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.Up):
+			cmd := m.blurTextInputs()
+			m.moveFocus(-1)
+			return m, cmd
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.Down):
+			cmd := m.blurTextInputs()
+			m.moveFocus(1)
+			return m, cmd
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.Tab):
+			cmd := m.blurTextInputs()
+			m.moveFocus(1)
+			m.focusCurrentTextInput()
+			return m, tea.Batch(cmd, textinput.Blink)
+		case ui.MatchesWhileTyping(keyMsg, ui.Keys.ShiftTab):
+			cmd := m.blurTextInputs()
+			m.moveFocus(-1)
+			m.focusCurrentTextInput()
+			return m, tea.Batch(cmd, textinput.Blink)
+		default:
+			var cmd tea.Cmd
+			if m.focused == 0 {
+				m.ref, cmd = m.ref.Update(msg)
+			} else {
+				f := &m.fields[m.focused-1]
+				f.text, cmd = f.text.Update(msg)
+			}
+			return m, cmd
+		}
+	}
+
+	switch {
+	case key.Matches(keyMsg, ui.Keys.Down, ui.Keys.Tab):
+		m.moveFocus(1)
+	case key.Matches(keyMsg, ui.Keys.Up, ui.Keys.ShiftTab):
+		m.moveFocus(-1)
+
+	// Cycle forward / toggle / enter text input.
+	case key.Matches(keyMsg, ui.Keys.Enter, ui.Keys.Right, ui.Keys.Select):
+		if m.focused == 0 {
+			m.ref.Focus()
+			return m, textinput.Blink
+		}
+		f := &m.fields[m.focused-1]
+		switch {
+		case f.input.Type == model.DispatchInputBoolean:
+			f.boolVal = !f.boolVal
+		case f.usesText():
+			f.text.Focus()
+			return m, textinput.Blink
+		default:
+			f.optIdx = cycle(f.optIdx, len(f.options), 1, f.input.Required)
+		}
+
+	// Cycle backward.
+	case key.Matches(keyMsg, ui.Keys.Left):
+		if m.focused == 0 {
+			return m, nil
+		}
+		f := &m.fields[m.focused-1]
+		switch {
+		case f.input.Type == model.DispatchInputBoolean:
+			f.boolVal = !f.boolVal
+		case !f.usesText():
+			f.optIdx = cycle(f.optIdx, len(f.options), -1, f.input.Required)
+		}
+
+	// Reset to the defaults declared in the workflow file.
+	case key.Matches(keyMsg, ui.Keys.Clear):
+		for i := range m.fields {
+			m.fields[i].setValue(m.fields[i].input.Default)
+		}
+		m.err = ""
+
+	// Submit.
+	case key.Matches(keyMsg, ui.Keys.Apply):
+		if m.loading {
+			return m, nil
+		}
+		if err := m.validate(); err != "" {
+			m.err = err
+			return m, nil
+		}
+		m.active = false
+		return m, m.emitResult(true)
+
+	// Cancel.
+	case key.Matches(keyMsg, ui.Keys.Close):
+		m.active = false
+		return m, m.emitResult(false)
+	}
+	return m, nil
+}
+
+// ---------------------------------------------------------------------------
+// View
+// ---------------------------------------------------------------------------
+
+// View renders the overlay.
+func (m Model) View() string {
+	if !m.active {
+		return ""
+	}
+
+	labelStyle := lipgloss.NewStyle().Width(18).Foreground(ui.ColorMuted)
+	focusedLabelStyle := lipgloss.NewStyle().Width(18).Bold(true).Foreground(ui.ColorPrimary)
+	valueStyle := lipgloss.NewStyle().Foreground(ui.ColorText)
+	unsetStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true)
+	hintStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+
+	row := func(idx int, label, value string) string {
+		ls := labelStyle
+		cursor := "  "
+		if idx == m.focused {
+			ls = focusedLabelStyle
+			cursor = lipgloss.NewStyle().Foreground(ui.ColorPrimary).Render("> ")
+		}
+		return fmt.Sprintf("%s%s %s", cursor, ls.Render(label), value)
+	}
+
+	rows := []string{row(0, "Ref:", m.ref.View())}
+
+	if m.loading {
+		rows = append(rows, "", hintStyle.Render(fmt.Sprintf("  Loading inputs at %s...", strings.TrimSpace(m.ref.Value()))))
+	} else if len(m.fields) == 0 {
+		rows = append(rows, "", hintStyle.Render("  This workflow takes no inputs."))
+	}
+
+	if !m.loading {
+		for i, f := range m.fields {
+			label := truncate(f.input.Name, 15)
+			if f.input.Required {
+				label += "*"
+			}
+			label += ":"
+
+			var value string
+			switch {
+			case f.input.Type == model.DispatchInputBoolean:
+				if f.boolVal {
+					value = valueStyle.Render("[x] true")
+				} else {
+					value = valueStyle.Render("[ ] false")
+				}
+			case f.usesText():
+				value = f.text.View()
+			case f.optIdx >= 0 && f.optIdx < len(f.options):
+				value = valueStyle.Render(fmt.Sprintf("< %s >", f.options[f.optIdx]))
+			default:
+				value = unsetStyle.Render("(not set)")
+			}
+			rows = append(rows, row(i+1, label, value))
+
+			if i+1 == m.focused {
+				hint := string(f.input.Type)
+				if f.input.Description != "" {
+					hint += " — " + f.input.Description
+				}
+				rows = append(rows, hintStyle.Render("    "+truncate(hint, 56)))
+			}
+		}
+	}
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ui.ColorPrimary).
+		MarginBottom(1).
+		Render("Run Workflow: " + m.workflow.Name)
+
+	var footer []string
+	if m.err != "" {
+		footer = append(footer, ui.StyleFailure.Render(m.err))
+	}
+	if m.loadedAt != strings.TrimSpace(m.ref.Value()) && !m.loading {
+		footer = append(footer, hintStyle.Render("Inputs shown for "+m.loadedAt))
+	}
+	k := ui.Keys
+	footer = append(footer, hintStyle.Render(ui.Hints(ui.Hint("run", k.Apply), ui.Hint("defaults", k.Clear),
+		ui.Hint("edit/cycle", k.Enter), ui.Hint("cancel", k.Close))))
+
+	help := lipgloss.NewStyle().MarginTop(1).Render(strings.Join(footer, "\n"))
+
+	body := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		strings.Join(rows, "\n"),
+		help,
+	)
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ui.ColorPrimary).
+		Padding(1, 2).
+		Width(64)
+
+	box := boxStyle.Render(body)
+
+	// Centre the box in the terminal.
+	if m.width > 0 && m.height > 0 {
+		return lipgloss.Place(m.width, m.height,
+			lipgloss.Center, lipgloss.Center,
+			box)
+	}
+	return box
+}
+
+// ---------------------------------------------------------------------------
+// Helpers
+// ---------------------------------------------------------------------------
+
+func (m *Model) moveFocus(delta int) {
+	count := len(m.fields) + 1
+	if m.loading {
+		count = 1
+	}
+	m.focused = (m.focused + delta + count) % count
+}
+
+func (m Model) isTextFieldFocused() bool {
+	if m.ref.Focused() {
+		return true
+	}
+	for _, f := range m.fields {
+		if f.text.Focused() {
+			return true
+		}
+	}
+	return false
+}
+
+// blurTextInputs removes focus from all text inputs. Leaving the ref field
+// with a new value triggers a reload of the inputs at that ref.
+func (m *Model) blurTextInputs() tea.Cmd {
+	var cmd tea.Cmd
+	if m.ref.Focused() {
+		m.ref.Blur()
+		ref := strings.TrimSpace(m.ref.Value())
+		if ref != "" && ref != m.loadedAt {
+			m.loading = true
+			m.err = ""
+			wf := m.workflow
+			cmd = func() tea.Msg { return RefChangedMsg{Workflow: wf, Ref: ref} }
+		}
+	}
+	for i := range m.fields {
+		m.fields[i].text.Blur()
+	}
+	return cmd
+}
+
+func (m *Model) focusCurrentTextInput() {
+	if m.focused == 0 {
+		m.ref.Focus()
+		return
+	}
+	if f := &m.fields[m.focused-1]; f.usesText() {
+		f.text.Focus()
+	}
+}
+
+// validate returns an error message when a required input is empty or a
+// number input does not parse.
+func (m Model) validate() string {
+	if strings.TrimSpace(m.ref.Value()) == "" {
+		return "ref is required"
+	}
+	for _, f := range m.fields {
+		v := f.value()
+		if f.input.Required && v == "" {
+			return fmt.Sprintf("input %q is required", f.input.Name)
+		}
+		if f.input.Type == model.DispatchInputNumber && v != "" {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return fmt.Sprintf("input %q must be a number", f.input.Name)
+			}
+		}
+	}
+	return ""
+}
+
+func (m Model) emitResult(submitted bool) tea.Cmd {
+	result := ResultMsg{Submitted: submitted, Workflow: m.workflow}
+	if submitted {
+		result.Ref = strings.TrimSpace(m.ref.Value())
+		result.Inputs = make(map[string]string, len(m.fields))
+		for _, f := range m.fields {
+			if v := f.value(); v != "" {
+				result.Inputs[f.input.Name] = v
+			}
+		}
+	}
+	return func() tea.Msg { return result }
+}
+
+// cycle moves an option index by delta. Optional fields include -1 (unset)
+// in the cycle; required fields only cycle through real options.
+func cycle(idx, count, delta int, required bool) int {
+	if count == 0 {
+		return -1
+	}
+	lo := -1
+	if required {
+		lo = 0
+	}
+	idx += delta
+	if idx >= count {
+		idx = lo
+	}
+	if idx < lo {
+		idx = count - 1
+	}
+	return idx
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
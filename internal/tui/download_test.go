@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateUnique(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "dist.zip"), []byte("old"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"dist (1).zip", "dist (2).zip"} {
+		f, path, renamed, err := createUnique(dir, "dist", ".zip")
+		if err != nil {
+			t.Fatalf("createUnique() error = %v", err)
+		}
+		f.Close()
+		if filepath.Base(path) != want || !renamed {
+			t.Errorf("createUnique() = %q, renamed %v, want %q, renamed", filepath.Base(path), renamed, want)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "dist.zip")); string(data) != "old" {
+		t.Errorf("existing archive overwritten: %q", data)
+	}
+
+	f, path, renamed, err := createUnique(dir, "logs", ".zip")
+	if err != nil {
+		t.Fatalf("createUnique() error = %v", err)
+	}
+	f.Close()
+	if filepath.Base(path) != "logs.zip" || renamed {
+		t.Errorf("createUnique() = %q, renamed %v, want logs.zip", filepath.Base(path), renamed)
+	}
+}
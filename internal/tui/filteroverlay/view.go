@@ -6,6 +6,7 @@ import (
 
 	"github.com/altinukshini/gha-tui/internal/model"
 	"github.com/altinukshini/gha-tui/internal/ui"
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -17,6 +18,7 @@ import (
 
 // FilterResult holds the filter values selected by the user.
 type FilterResult struct {
+	Repo         string // owner/repo when watching several; empty means all
 	WorkflowID   int64
 	WorkflowName string
 	Event        string
@@ -27,12 +29,15 @@ type FilterResult struct {
 
 // IsEmpty returns true when no filter criteria are set.
 func (f FilterResult) IsEmpty() bool {
-	return f.WorkflowID == 0 && f.Event == "" && f.Status == "" && f.Branch == "" && f.Actor == ""
+	return f.Repo == "" && f.WorkflowID == 0 && f.Event == "" && f.Status == "" && f.Branch == "" && f.Actor == ""
 }
 
 // Summary returns a short human-readable summary suitable for a tab label.
 func (f FilterResult) Summary() string {
 	var parts []string
+	if f.Repo != "" {
+		parts = append(parts, "repo:"+f.Repo)
+	}
 	if f.WorkflowName != "" {
 		parts = append(parts, f.WorkflowName)
 	}
@@ -71,7 +76,8 @@ type ResultMsg struct {
 type field int
 
 const (
-	fieldWorkflow field = iota
+	fieldRepo field = iota
+	fieldWorkflow
 	fieldEvent
 	fieldStatus
 	fieldBranch
@@ -96,6 +102,8 @@ var (
 type Model struct {
 	active      bool
 	focused     field
+	repos       []string
+	repoIdx     int // -1 = all
 	workflows   []model.Workflow
 	workflowIdx int // -1 = all
 	eventIdx    int // -1 = all
@@ -107,22 +115,27 @@ type Model struct {
 }
 
 // New creates a new filter overlay pre-populated with the given current filter
-// values. The overlay starts in the active state.
-func New(workflows []model.Workflow, current FilterResult) Model {
+// values. The overlay starts in the active state. The repo field is only
+// shown when more than one repo is watched.
+func New(repos []string, workflows []model.Workflow, current FilterResult) Model {
 	branch := textinput.New()
 	branch.Placeholder = "e.g. main"
 	branch.CharLimit = 128
+	ui.StyleTextInput(&branch)
 	branch.Width = 30
 	branch.SetValue(current.Branch)
 
 	actor := textinput.New()
 	actor.Placeholder = "e.g. octocat"
 	actor.CharLimit = 128
+	ui.StyleTextInput(&actor)
 	actor.Width = 30
 	actor.SetValue(current.Actor)
 
 	m := Model{
 		active:      true,
+		repos:       repos,
+		repoIdx:     -1,
 		workflows:   workflows,
 		workflowIdx: -1,
 		eventIdx:    -1,
@@ -131,6 +144,20 @@ func New(workflows []model.Workflow, current FilterResult) Model {
 		actor:       actor,
 	}
 
+	if !m.showRepo() {
+		m.focused = fieldWorkflow
+	}
+
+	// Resolve current repo selection.
+	if current.Repo != "" {
+		for i, r := range repos {
+			if strings.EqualFold(r, current.Repo) {
+				m.repoIdx = i
+				break
+			}
+		}
+	}
+
 	// Resolve current workflow selection.
 	if current.WorkflowID != 0 {
 		for i, w := range workflows {
@@ -190,24 +217,24 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// When a text input is focused, let it handle most keys first.
 		if m.isTextFieldFocused() {
-			switch msg.String() {
-			case "esc":
+			switch {
+			case ui.MatchesWhileTyping(msg, ui.Keys.Back):
 				m.active = false
 				return m, emitResult(false, FilterResult{})
-			case "up", "k":
+			case ui.MatchesWhileTyping(msg, ui.Keys.Up):
 				m.blurTextInputs()
 				m.moveFocus(-1)
 				return m, nil
-			case "down", "j":
+			case ui.MatchesWhileTyping(msg, ui.Keys.Down):
 				m.blurTextInputs()
 				m.moveFocus(1)
 				return m, nil
-			case "tab":
+			case ui.MatchesWhileTyping(msg, ui.Keys.Tab):
 				m.blurTextInputs()
 				m.moveFocus(1)
 				m.focusCurrentTextInput()
 				return m, nil
-			case "shift+tab":
+			case ui.MatchesWhileTyping(msg, ui.Keys.ShiftTab):
 				m.blurTextInputs()
 				m.moveFocus(-1)
 				m.focusCurrentTextInput()
@@ -224,17 +251,19 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 		}
 
-		switch msg.String() {
-		case "j", "down":
+		switch {
+		case key.Matches(msg, ui.Keys.Down, ui.Keys.Tab):
 			m.moveFocus(1)
 			return m, nil
-		case "k", "up":
+		case key.Matches(msg, ui.Keys.Up, ui.Keys.ShiftTab):
 			m.moveFocus(-1)
 			return m, nil
 
 		// Cycle forward / enter text input.
-		case "enter", "right", "l":
+		case key.Matches(msg, ui.Keys.Enter, ui.Keys.Right):
 			switch m.focused {
+			case fieldRepo:
+				m.repoIdx = cycleForward(m.repoIdx, len(m.repos))
 			case fieldWorkflow:
 				m.workflowIdx = cycleForward(m.workflowIdx, len(m.workflows))
 			case fieldEvent:
@@ -251,8 +280,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 
 		// Cycle backward.
-		case "left", "h":
+		case key.Matches(msg, ui.Keys.Left):
 			switch m.focused {
+			case fieldRepo:
+				m.repoIdx = cycleBackward(m.repoIdx, len(m.repos))
 			case fieldWorkflow:
 				m.workflowIdx = cycleBackward(m.workflowIdx, len(m.workflows))
 			case fieldEvent:
@@ -263,12 +294,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 
 		// Apply.
-		case "a":
+		case key.Matches(msg, ui.Keys.Apply):
 			m.active = false
 			return m, emitResult(true, m.buildFilterResult())
 
 		// Clear.
-		case "c":
+		case key.Matches(msg, ui.Keys.Clear):
+			m.repoIdx = -1
 			m.workflowIdx = -1
 			m.eventIdx = -1
 			m.statusIdx = -1
@@ -277,7 +309,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 
 		// Cancel.
-		case "esc":
+		case key.Matches(msg, ui.Keys.Back):
 			m.active = false
 			return m, emitResult(false, FilterResult{})
 		}
@@ -298,12 +330,15 @@ func (m Model) View() string {
 
 	labelStyle := lipgloss.NewStyle().Width(12).Foreground(ui.ColorMuted)
 	focusedLabelStyle := lipgloss.NewStyle().Width(12).Bold(true).Foreground(ui.ColorPrimary)
-	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB"))
+	valueStyle := lipgloss.NewStyle().Foreground(ui.ColorText)
 	allStyle := lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true)
 
 	rows := make([]string, 0, int(fieldCount))
 
 	for f := field(0); f < fieldCount; f++ {
+		if f == fieldRepo && !m.showRepo() {
+			continue
+		}
 		ls := labelStyle
 		if f == m.focused {
 			ls = focusedLabelStyle
@@ -311,6 +346,13 @@ func (m Model) View() string {
 
 		var label, value string
 		switch f {
+		case fieldRepo:
+			label = "Repo:"
+			if m.repoIdx < 0 || m.repoIdx >= len(m.repos) {
+				value = allStyle.Render("All repos")
+			} else {
+				value = valueStyle.Render(m.repos[m.repoIdx])
+			}
 		case fieldWorkflow:
 			label = "Workflow:"
 			if m.workflowIdx < 0 || m.workflowIdx >= len(m.workflows) {
@@ -357,7 +399,7 @@ func (m Model) View() string {
 	help := lipgloss.NewStyle().
 		Foreground(ui.ColorMuted).
 		MarginTop(1).
-		Render("a: apply  c: clear  esc: cancel")
+		Render(ui.Hints(ui.Hint("apply", ui.Keys.Apply), ui.Hint("clear", ui.Keys.Clear), ui.Hint("cancel", ui.Keys.Back)))
 
 	body := lipgloss.JoinVertical(lipgloss.Left,
 		title,
@@ -395,6 +437,14 @@ func (m *Model) moveFocus(delta int) {
 		next = 0
 	}
 	m.focused = field(next)
+	if m.focused == fieldRepo && !m.showRepo() {
+		m.moveFocus(delta)
+	}
+}
+
+// showRepo reports whether the repo field is offered.
+func (m Model) showRepo() bool {
+	return len(m.repos) > 1
 }
 
 func (m Model) isTextFieldFocused() bool {
@@ -419,6 +469,9 @@ func (m Model) buildFilterResult() FilterResult {
 		Branch: strings.TrimSpace(m.branch.Value()),
 		Actor:  strings.TrimSpace(m.actor.Value()),
 	}
+	if m.repoIdx >= 0 && m.repoIdx < len(m.repos) {
+		r.Repo = m.repos[m.repoIdx]
+	}
 	if m.workflowIdx >= 0 && m.workflowIdx < len(m.workflows) {
 		r.WorkflowID = m.workflows[m.workflowIdx].ID
 		r.WorkflowName = m.workflows[m.workflowIdx].Name
@@ -2,15 +2,16 @@ package tui
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/altinukshini/gha-tui/internal/ui"
 )
 
-func RenderHeader(repo string, rateRemaining, rateLimit int, width int) string {
+func RenderHeader(repo string, rateRemaining, rateLimit int, rateReset time.Time, width int) string {
 	left := lipgloss.NewStyle().Bold(true).
-		Foreground(lipgloss.Color("#F9FAFB")).
+		Foreground(ui.ColorText).
 		Render(fmt.Sprintf(" gha-tui | %s", repo))
 
 	rate := ""
@@ -21,8 +22,11 @@ func RenderHeader(repo string, rateRemaining, rateLimit int, width int) string {
 		} else if rateRemaining < 500 {
 			color = ui.ColorWarning
 		}
-		rate = lipgloss.NewStyle().Foreground(color).
-			Render(fmt.Sprintf("API: %d/%d ", rateRemaining, rateLimit))
+		text := fmt.Sprintf("API: %d/%d ", rateRemaining, rateLimit)
+		if !rateReset.IsZero() {
+			text = fmt.Sprintf("API: %d/%d, paused until %s ", rateRemaining, rateLimit, rateReset.Local().Format("15:04"))
+		}
+		rate = lipgloss.NewStyle().Foreground(color).Render(text)
 	}
 
 	gap := width - lipgloss.Width(left) - lipgloss.Width(rate)
@@ -32,7 +36,7 @@ func RenderHeader(repo string, rateRemaining, rateLimit int, width int) string {
 	padding := lipgloss.NewStyle().Width(gap).Render("")
 
 	return lipgloss.NewStyle().
-		Background(lipgloss.Color("#1F2937")).
+		Background(ui.ColorHighlight).
 		Width(width).
 		Render(left + padding + rate)
 }
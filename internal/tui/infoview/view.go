@@ -19,10 +19,12 @@ type Model struct {
 	jobs       []model.Job
 	job        *model.Job
 	showingJob bool
-	viewport   viewport.Model
-	width      int
-	height     int
-	ready      bool
+	// Check-run annotations of job, if loaded
+	annotations []model.Annotation
+	viewport    viewport.Model
+	width       int
+	height      int
+	ready       bool
 }
 
 func New() Model {
@@ -38,6 +40,9 @@ func (m *Model) SetRun(run *model.Run) {
 }
 
 func (m *Model) SetJob(job *model.Job) {
+	if m.job == nil || job == nil || m.job.ID != job.ID {
+		m.annotations = nil
+	}
 	m.job = job
 	m.showingJob = true
 	if m.ready {
@@ -45,6 +50,17 @@ func (m *Model) SetJob(job *model.Job) {
 	}
 }
 
+// SetAnnotations sets the check-run annotations shown for the current job.
+func (m *Model) SetAnnotations(jobID int64, annotations []model.Annotation) {
+	if m.job == nil || m.job.ID != jobID {
+		return
+	}
+	m.annotations = annotations
+	if m.ready {
+		m.viewport.SetContent(m.render())
+	}
+}
+
 func (m Model) Job() *model.Job {
 	return m.job
 }
@@ -77,6 +93,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		headerH := 1
 		if !m.ready {
 			m.viewport = viewport.New(wsm.Width, wsm.Height-headerH)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
 			m.ready = true
 			if m.run != nil || m.job != nil {
 				m.viewport.SetContent(m.render())
@@ -95,11 +112,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	if m.showingJob {
 		if m.job == nil {
-			return "\n  Select a job and press 'i' to view info"
+			return "\n  Select a job and press '" + ui.Keys.Info.Help().Key + "' to view info"
 		}
 	} else {
 		if m.run == nil {
-			return "\n  Select a run and press 'i' to view info"
+			return "\n  Select a run and press '" + ui.Keys.Info.Help().Key + "' to view info"
 		}
 	}
 
@@ -110,10 +127,11 @@ func (m Model) View() string {
 	} else {
 		header = fmt.Sprintf(" Run #%d Info  %3.0f%%", m.run.RunNumber, pct)
 	}
-	hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render(
-		"  j/k:scroll  g/G:top/bot  PgUp/Dn:page  esc:back")
+	k := ui.Keys
+	hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render("  " + ui.Hints(ui.Hint("scroll", k.Down, k.Up),
+		ui.Hint("page", k.PageUp, k.PageDown), ui.Hint("back", k.Exit)))
 	headerLine := lipgloss.NewStyle().Bold(true).
-		Foreground(lipgloss.Color("#F9FAFB")).
+		Foreground(ui.ColorText).
 		Render(header) + hints
 
 	return headerLine + "\n" + m.viewport.View()
@@ -134,7 +152,7 @@ func (m Model) renderRun() string {
 	r := m.run
 	bold := lipgloss.NewStyle().Bold(true)
 	label := lipgloss.NewStyle().Foreground(ui.ColorMuted).Width(16)
-	value := lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB"))
+	value := lipgloss.NewStyle().Foreground(ui.ColorText)
 
 	row := func(l, v string) string {
 		return "  " + label.Render(l) + value.Render(v) + "\n"
@@ -260,7 +278,7 @@ func (m Model) renderJob() string {
 	j := m.job
 	bold := lipgloss.NewStyle().Bold(true)
 	label := lipgloss.NewStyle().Foreground(ui.ColorMuted).Width(16)
-	value := lipgloss.NewStyle().Foreground(lipgloss.Color("#F9FAFB"))
+	value := lipgloss.NewStyle().Foreground(ui.ColorText)
 
 	row := func(l, v string) string {
 		return "  " + label.Render(l) + value.Render(v) + "\n"
@@ -273,6 +291,32 @@ func (m Model) renderJob() string {
 	b.WriteString("  " + bold.Render(j.Name) + "\n")
 	b.WriteString("\n")
 
+	// Annotations first: they usually name the error directly
+	if len(m.annotations) > 0 {
+		b.WriteString("  " + bold.Render("Annotations") + "\n\n")
+		for _, a := range m.annotations {
+			style := ui.StyleInfo
+			switch a.AnnotationLevel {
+			case "failure":
+				style = ui.StyleFailure
+			case "warning":
+				style = ui.StyleWarning
+			}
+			head := style.Render(a.AnnotationLevel)
+			if loc := a.Location(); loc != "" {
+				head += "  " + value.Render(loc)
+			}
+			if a.Title != "" {
+				head += "  " + bold.Render(a.Title)
+			}
+			b.WriteString("  " + head + "\n")
+			for _, line := range strings.Split(strings.TrimSpace(a.Message), "\n") {
+				b.WriteString("    " + value.Render(line) + "\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+
 	// Status with colored icon
 	icon := ui.StatusIcon(string(j.Conclusion))
 	statusStr := string(j.Status)
@@ -0,0 +1,210 @@
+// Package jobgraph renders the needs: dependencies of a run's jobs as an
+// ASCII graph coloured by each job's status.
+package jobgraph
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// Node is a job declared in the workflow file together with the run's jobs
+// created from it (one per matrix combination or called job).
+type Node struct {
+	Spec       model.WorkflowJob
+	Jobs       []model.Job
+	Needs      []*Node
+	Dependents []*Node
+	stage      int
+}
+
+// Graph is the job DAG of a run, nodes in declaration order.
+type Graph struct {
+	Nodes []*Node
+}
+
+// Build links the workflow's jobs by needs: and attaches the run's jobs to
+// the declaration they came from. Unknown needs are ignored.
+func Build(specs []model.WorkflowJob, jobs []model.Job) *Graph {
+	g := &Graph{}
+	byID := make(map[string]*Node, len(specs))
+	for _, s := range specs {
+		n := &Node{Spec: s}
+		g.Nodes = append(g.Nodes, n)
+		byID[s.ID] = n
+	}
+	for _, n := range g.Nodes {
+		for _, id := range n.Spec.Needs {
+			if dep, ok := byID[id]; ok && dep != n {
+				n.Needs = append(n.Needs, dep)
+				dep.Dependents = append(dep.Dependents, n)
+			}
+		}
+	}
+
+	matchers := make([]*regexp.Regexp, len(g.Nodes))
+	for i, n := range g.Nodes {
+		matchers[i] = namePattern(n.Spec.DisplayName())
+	}
+	for _, j := range jobs {
+		// Literal names win over names with expressions, which match loosely.
+		for _, literal := range []bool{true, false} {
+			if i := matchJob(g.Nodes, matchers, j.Name, literal); i >= 0 {
+				g.Nodes[i].Jobs = append(g.Nodes[i].Jobs, j)
+				break
+			}
+		}
+	}
+
+	g.assignStages()
+	return g
+}
+
+func matchJob(nodes []*Node, matchers []*regexp.Regexp, name string, literal bool) int {
+	for i, n := range nodes {
+		if strings.Contains(n.Spec.DisplayName(), "${{") == literal {
+			continue
+		}
+		candidate := name
+		if n.Spec.Uses != "" {
+			// Jobs of a called workflow are named "caller / callee".
+			candidate, _, _ = strings.Cut(name, " / ")
+		}
+		if matchers[i].MatchString(candidate) {
+			return i
+		}
+	}
+	return -1
+}
+
+// namePattern matches a job's display name, with ${{ }} expressions as
+// wildcards and an optional " (matrix, values)" suffix.
+func namePattern(name string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for {
+		start := strings.Index(name, "${{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(name[start:], "}}")
+		if end < 0 {
+			break
+		}
+		b.WriteString(regexp.QuoteMeta(name[:start]))
+		b.WriteString(".*")
+		name = name[start+end+2:]
+	}
+	b.WriteString(regexp.QuoteMeta(name))
+	b.WriteString(`( \(.*\))?$`)
+	return regexp.MustCompile(b.String())
+}
+
+// assignStages sets each node's stage to the length of its longest needs:
+// chain. Cycles (invalid in Actions) are cut where they are found.
+func (g *Graph) assignStages() {
+	const visiting = -1
+	state := make(map[*Node]int)
+	var visit func(n *Node) int
+	visit = func(n *Node) int {
+		switch state[n] {
+		case visiting:
+			return 0
+		case 0:
+			state[n] = visiting
+			stage := 0
+			for _, dep := range n.Needs {
+				if s := visit(dep) + 1; s > stage {
+					stage = s
+				}
+			}
+			n.stage = stage
+			state[n] = 1
+		}
+		return n.stage
+	}
+	for _, n := range g.Nodes {
+		visit(n)
+	}
+}
+
+// Status summarises the node's jobs as a status icon key: "failure" if any
+// failed, then "in_progress", "queued", "cancelled", "skipped" when all were
+// skipped, and "success". A node without jobs has not been reached yet and
+// reports "pending".
+func (n *Node) Status() string {
+	if len(n.Jobs) == 0 {
+		return "pending"
+	}
+	var failed, running, queued, cancelled, skipped int
+	for _, j := range n.Jobs {
+		switch {
+		case j.Conclusion == model.ConclusionFailure || j.Conclusion == model.ConclusionTimedOut:
+			failed++
+		case j.Status == model.RunStatusInProgress:
+			running++
+		case j.Status != model.RunStatusCompleted:
+			queued++
+		case j.Conclusion == model.ConclusionCancelled:
+			cancelled++
+		case j.Conclusion == model.ConclusionSkipped:
+			skipped++
+		}
+	}
+	switch {
+	case failed > 0:
+		return "failure"
+	case running > 0:
+		return "in_progress"
+	case queued > 0:
+		return "queued"
+	case cancelled > 0:
+		return "cancelled"
+	case skipped == len(n.Jobs):
+		return "skipped"
+	}
+	return "success"
+}
+
+// BlockedBy returns the failed or cancelled upstream jobs that kept a
+// skipped, cancelled or pending node from running, following chains of
+// skipped jobs back to their cause.
+func (n *Node) BlockedBy() []*Node {
+	switch n.Status() {
+	case "skipped", "cancelled", "pending":
+	default:
+		return nil
+	}
+	var out []*Node
+	seen := make(map[*Node]bool)
+	var walk func(*Node)
+	walk = func(n *Node) {
+		for _, dep := range n.Needs {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			switch dep.Status() {
+			case "failure", "cancelled":
+				out = append(out, dep)
+			case "skipped", "pending":
+				walk(dep)
+			}
+		}
+	}
+	walk(n)
+	return out
+}
+
+// primaryParent is the need a node is drawn under: the one in the latest
+// stage, i.e. the last to finish before the node can start.
+func (n *Node) primaryParent() *Node {
+	var p *Node
+	for _, dep := range n.Needs {
+		if p == nil || dep.stage >= p.stage {
+			p = dep
+		}
+	}
+	return p
+}
@@ -0,0 +1,108 @@
+package jobgraph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func job(name string, status model.RunStatus, conclusion model.RunConclusion) model.Job {
+	return model.Job{Name: name, Status: status, Conclusion: conclusion}
+}
+
+func testGraph() *Graph {
+	specs := []model.WorkflowJob{
+		{ID: "lint"},
+		{ID: "build", Name: "Build ${{ matrix.os }}"},
+		{ID: "test", Needs: []string{"build"}},
+		{ID: "e2e", Name: "E2E", Needs: []string{"lint", "test"}},
+		{ID: "release", Needs: []string{"e2e"}, Uses: "./.github/workflows/release.yml"},
+	}
+	jobs := []model.Job{
+		job("lint", model.RunStatusCompleted, model.ConclusionSuccess),
+		job("Build linux", model.RunStatusCompleted, model.ConclusionSuccess),
+		job("Build macos", model.RunStatusCompleted, model.ConclusionFailure),
+		job("test", model.RunStatusCompleted, model.ConclusionSkipped),
+		job("E2E", model.RunStatusCompleted, model.ConclusionSkipped),
+		job("release / publish", model.RunStatusCompleted, model.ConclusionSkipped),
+	}
+	return Build(specs, jobs)
+}
+
+func TestBuild(t *testing.T) {
+	g := testGraph()
+	tests := []struct {
+		id        string
+		jobs      int
+		status    string
+		stage     int
+		blockedBy string
+	}{
+		{"lint", 1, "success", 0, ""},
+		{"build", 2, "failure", 0, ""},
+		{"test", 1, "skipped", 1, "build"},
+		{"e2e", 1, "skipped", 2, "build"},
+		{"release", 1, "skipped", 3, "build"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			n := g.Nodes[i]
+			if n.Spec.ID != tt.id {
+				t.Fatalf("node %d = %s, want %s", i, n.Spec.ID, tt.id)
+			}
+			if len(n.Jobs) != tt.jobs {
+				t.Errorf("jobs = %d, want %d", len(n.Jobs), tt.jobs)
+			}
+			if got := n.Status(); got != tt.status {
+				t.Errorf("Status() = %s, want %s", got, tt.status)
+			}
+			if n.stage != tt.stage {
+				t.Errorf("stage = %d, want %d", n.stage, tt.stage)
+			}
+			var blocked []string
+			for _, b := range n.BlockedBy() {
+				blocked = append(blocked, b.Spec.ID)
+			}
+			if got := strings.Join(blocked, ","); got != tt.blockedBy {
+				t.Errorf("BlockedBy() = %q, want %q", got, tt.blockedBy)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	got := testGraph().Render()
+	want := []string{
+		"V lint",
+		"X Build ${{ matrix.os }} [build] (1/2 failed)",
+		"`-> - test  blocked by build",
+		"    `-> - E2E [e2e]  +needs lint  blocked by build",
+		"        `-> - release  blocked by build",
+		"X build blocked test, e2e, release",
+	}
+	for _, line := range want {
+		if !strings.Contains(got, line) {
+			t.Errorf("Render() missing %q in:\n%s", line, got)
+		}
+	}
+}
+
+func TestNamePattern(t *testing.T) {
+	tests := []struct {
+		name, job string
+		want      bool
+	}{
+		{"test", "test", true},
+		{"test", "test (ubuntu, 1.22)", true},
+		{"test", "test-e2e", false},
+		{"Build ${{ matrix.os }}", "Build windows", true},
+		{"Build ${{ matrix.os }}", "Deploy windows", false},
+		{"a.b", "axb", false},
+	}
+	for _, tt := range tests {
+		if got := namePattern(tt.name).MatchString(tt.job); got != tt.want {
+			t.Errorf("namePattern(%q).MatchString(%q) = %v, want %v", tt.name, tt.job, got, tt.want)
+		}
+	}
+}
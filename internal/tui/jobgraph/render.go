@@ -0,0 +1,112 @@
+package jobgraph
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// Render draws the graph as an ASCII tree. Jobs without needs are roots and
+// every other job hangs under its primary parent; further needs are listed
+// after the name. Skipped or pending jobs name the failures that blocked
+// them.
+func (g *Graph) Render() string {
+	var b strings.Builder
+	for _, n := range g.Nodes {
+		if len(n.Needs) == 0 {
+			g.renderNode(&b, n, "", "")
+		}
+	}
+
+	var blocking []string
+	for _, n := range g.Nodes {
+		if s := n.Status(); s != "failure" && s != "cancelled" {
+			continue
+		}
+		var blocked []string
+		for _, other := range g.Nodes {
+			for _, by := range other.BlockedBy() {
+				if by == n {
+					blocked = append(blocked, other.Spec.ID)
+				}
+			}
+		}
+		if len(blocked) > 0 {
+			blocking = append(blocking, fmt.Sprintf("  %s %s blocked %s",
+				ui.StatusIcon(n.Status()), n.Spec.ID, strings.Join(blocked, ", ")))
+		}
+	}
+	if len(blocking) > 0 {
+		b.WriteString("\n" + strings.Join(blocking, "\n") + "\n")
+	}
+	return b.String()
+}
+
+func (g *Graph) renderNode(b *strings.Builder, n *Node, prefix, branch string) {
+	status := n.Status()
+	line := prefix + branch + ui.StatusIcon(status) + " " + ui.ConclusionStyle(status).Render(n.Spec.DisplayName())
+	if n.Spec.DisplayName() != n.Spec.ID {
+		line += ui.StyleMuted.Render(" [" + n.Spec.ID + "]")
+	}
+	if detail := jobsDetail(n); detail != "" {
+		line += ui.StyleMuted.Render(" " + detail)
+	}
+
+	primary := n.primaryParent()
+	var others []string
+	for _, dep := range n.Needs {
+		if dep != primary {
+			others = append(others, dep.Spec.ID)
+		}
+	}
+	if len(others) > 0 {
+		line += ui.StyleMuted.Render("  +needs " + strings.Join(others, ", "))
+	}
+	if blockers := n.BlockedBy(); len(blockers) > 0 {
+		var ids []string
+		for _, by := range blockers {
+			ids = append(ids, by.Spec.ID)
+		}
+		line += ui.StyleFailure.Render("  blocked by " + strings.Join(ids, ", "))
+	}
+	b.WriteString(line + "\n")
+
+	var children []*Node
+	for _, d := range n.Dependents {
+		if d.primaryParent() == n {
+			children = append(children, d)
+		}
+	}
+	childPrefix := prefix
+	switch branch {
+	case "|-> ":
+		childPrefix += "|   "
+	case "`-> ":
+		childPrefix += "    "
+	}
+	for i, c := range children {
+		br := "|-> "
+		if i == len(children)-1 {
+			br = "`-> "
+		}
+		g.renderNode(b, c, childPrefix, br)
+	}
+}
+
+// jobsDetail summarises nodes that expanded into several jobs.
+func jobsDetail(n *Node) string {
+	if len(n.Jobs) < 2 {
+		return ""
+	}
+	failed := 0
+	for _, j := range n.Jobs {
+		if j.Failed() {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Sprintf("(%d/%d failed)", failed, len(n.Jobs))
+	}
+	return fmt.Sprintf("(%d jobs)", len(n.Jobs))
+}
@@ -0,0 +1,133 @@
+package jobgraph
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// Model is the full-screen job graph overlay for one run.
+type Model struct {
+	viewport viewport.Model
+	active   bool
+	loading  bool
+	err      error
+	run      model.Run
+	specs    []model.WorkflowJob
+	jobs     []model.Job
+	width    int
+	height   int
+	ready    bool
+}
+
+func New() Model {
+	return Model{}
+}
+
+// Open shows the overlay for run while its workflow file and jobs load.
+func (m *Model) Open(run model.Run) {
+	m.active = true
+	m.loading = true
+	m.err = nil
+	m.run = run
+	m.specs = nil
+	m.jobs = nil
+	m.refresh()
+}
+
+// SetGraph supplies the jobs declared in the workflow file and the run's jobs.
+func (m *Model) SetGraph(specs []model.WorkflowJob, jobs []model.Job) {
+	m.loading = false
+	m.specs = specs
+	m.jobs = jobs
+	m.refresh()
+}
+
+// SetJobs updates job statuses, e.g. from the auto-refresh of a running run.
+func (m *Model) SetJobs(jobs []model.Job) {
+	if m.loading || m.err != nil {
+		return
+	}
+	m.jobs = jobs
+	m.refresh()
+}
+
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = err
+}
+
+func (m Model) RunID() int64   { return m.run.ID }
+func (m Model) IsActive() bool { return m.active }
+func (m Model) Init() tea.Cmd  { return nil }
+
+func (m *Model) refresh() {
+	if !m.ready {
+		return
+	}
+	if m.specs == nil {
+		m.viewport.SetContent("")
+		return
+	}
+	m.viewport.SetContent(Build(m.specs, m.jobs).Render())
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, ui.Keys.Close):
+			m.active = false
+			return m, nil
+		case key.Matches(msg, ui.Keys.Top):
+			m.viewport.GotoTop()
+			return m, nil
+		case key.Matches(msg, ui.Keys.Bottom):
+			m.viewport.GotoBottom()
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+		m.refresh()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m Model) View() string {
+	header := fmt.Sprintf(" Job Graph  #%d %s", m.run.RunNumber, m.run.DisplayTitle)
+	if m.ready && !m.loading && m.err == nil {
+		header += fmt.Sprintf("  %3.0f%%", m.viewport.ScrollPercent()*100)
+	}
+	k := ui.Keys
+	hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render("  " + ui.Hints(ui.Hint("scroll", k.Down, k.Up),
+		ui.Hint("top/bot", k.Top, k.Bottom), ui.Hint("back", k.Close)))
+	headerLine := lipgloss.NewStyle().Bold(true).
+		Foreground(ui.ColorText).
+		Render(header) + hints
+
+	switch {
+	case m.err != nil:
+		return headerLine + "\n" + ui.RenderError(m.err)
+	case m.loading:
+		return headerLine + "\n\n  Loading workflow file and jobs..."
+	}
+	return headerLine + "\n\n" + m.viewport.View()
+}
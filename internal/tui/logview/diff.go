@@ -0,0 +1,249 @@
+package logview
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DiffLine is a log line present in only one of two compared logs.
+type DiffLine struct {
+	Removed bool   // only in the base log; otherwise only in the head log
+	Line    int    // 1-based line number in its own log
+	Text    string // the line as logged, timestamp stripped
+	Gap     bool   // matching lines precede it since the previous DiffLine
+}
+
+var (
+	logTimestamp = regexp.MustCompile(`^\x{FEFF}?\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?Z ?`)
+	ansiEscape   = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+	// volatile tokens that differ between otherwise identical runs, in the
+	// order they are replaced.
+	volatile = []struct {
+		re   *regexp.Regexp
+		repl string
+	}{
+		{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+		{regexp.MustCompile(`\b\d{2}:\d{2}:\d{2}(\.\d+)?\b`), "<time>"},
+		{regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`), "<uuid>"},
+		{regexp.MustCompile(`(/home/runner/work/_temp|/tmp|/var/folders|[A-Za-z]:\\a\\_temp|[A-Za-z]:\\Users\\[^\\\s]+\\AppData\\Local\\Temp)[/\\][^\s'"]*`), "<tmp>"},
+		{regexp.MustCompile(`(?i)\b[0-9a-f]{12,64}\b`), "<hex>"},
+		{regexp.MustCompile(`\b\d+(\.\d+)?\s?(ns|µs|us|ms|s|sec|secs|seconds?|m|min|minutes?|h)\b`), "<dur>"},
+		{regexp.MustCompile(`\b(\d+h)?(\d+m)?\d+(\.\d+)?s\b`), "<dur>"},
+	}
+)
+
+// StripTimestamp removes the timestamp GitHub prefixes to every log line.
+func StripTimestamp(line string) string {
+	return logTimestamp.ReplaceAllString(line, "")
+}
+
+// Normalize reduces a log line to what should match between two runs of the
+// same job: the timestamp prefix and colour codes are dropped, and times,
+// durations, temp paths, UUIDs and hashes are replaced by placeholders.
+func Normalize(line string) string {
+	line = ansiEscape.ReplaceAllString(StripTimestamp(line), "")
+	for _, v := range volatile {
+		line = v.re.ReplaceAllString(line, v.repl)
+	}
+	return strings.TrimRight(line, " \t\r")
+}
+
+// maxEdits bounds the time spent on the line-by-line diff. Logs that differ
+// more than this are compared as multisets of lines instead, which still
+// lists what is unique to each side but not where it moved.
+const maxEdits = 4000
+
+// Diff compares two logs after normalisation and returns only the lines
+// that differ, in log order with removed lines before added ones at each
+// position.
+func Diff(base, head string) []DiffLine {
+	a := strings.Split(strings.TrimRight(base, "\n"), "\n")
+	b := strings.Split(strings.TrimRight(head, "\n"), "\n")
+	na := make([]string, len(a))
+	for i, l := range a {
+		na[i] = Normalize(l)
+	}
+	nb := make([]string, len(b))
+	for i, l := range b {
+		nb[i] = Normalize(l)
+	}
+
+	keepA, keepB, ok := matchLines(na, nb)
+	if !ok {
+		keepA, keepB = matchMultiset(na, nb)
+	}
+
+	var out []DiffLine
+	gap := false
+	emit := func(l DiffLine) {
+		l.Gap = gap && len(out) > 0
+		gap = false
+		out = append(out, l)
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		for i < len(a) && !keepA[i] {
+			emit(DiffLine{Removed: true, Line: i + 1, Text: StripTimestamp(a[i])})
+			i++
+		}
+		for j < len(b) && !keepB[j] {
+			emit(DiffLine{Line: j + 1, Text: StripTimestamp(b[j])})
+			j++
+		}
+		if i == len(a) || j == len(b) {
+			break
+		}
+		i++
+		j++
+		gap = true
+	}
+	return out
+}
+
+// matchLines marks the lines of a and b that belong to a shortest edit
+// script, or reports false when more than maxEdits edits are needed. It uses
+// the linear-space variant of Myers' algorithm: each middle snake splits the
+// problem in two, so memory stays proportional to the length of the logs.
+func matchLines(a, b []string) (keepA, keepB []bool, ok bool) {
+	d := &differ{
+		a: a, b: b,
+		keepA: make([]bool, len(a)),
+		keepB: make([]bool, len(b)),
+		off:   (len(a)+len(b)+1)/2 + 1,
+	}
+	d.vf = make([]int, 2*d.off+1)
+	d.vb = make([]int, 2*d.off+1)
+	if !d.compare(0, len(a), 0, len(b), maxEdits) {
+		return nil, nil, false
+	}
+	return d.keepA, d.keepB, true
+}
+
+// differ holds the state of one matchLines call. vf and vb are the furthest
+// reaching forward and backward paths by diagonal, shifted by off; every
+// middleSnake call reuses them.
+type differ struct {
+	a, b         []string
+	keepA, keepB []bool
+	vf, vb       []int
+	off          int
+}
+
+// compare matches a[a0:a1] against b[b0:b1]. limit, when positive, is the
+// most edits allowed; compare reports false when more are needed, leaving
+// the lines past the common prefix and suffix unmatched.
+func (d *differ) compare(a0, a1, b0, b1, limit int) bool {
+	// Common prefix and suffix are matched up front.
+	for a0 < a1 && b0 < b1 && d.a[a0] == d.b[b0] {
+		d.keepA[a0], d.keepB[b0] = true, true
+		a0++
+		b0++
+	}
+	for a1 > a0 && b1 > b0 && d.a[a1-1] == d.b[b1-1] {
+		a1--
+		b1--
+		d.keepA[a1], d.keepB[b1] = true, true
+	}
+	if a0 == a1 || b0 == b1 {
+		return true
+	}
+
+	x, y, u, v, ok := d.middleSnake(a0, a1, b0, b1, limit)
+	if !ok {
+		return false
+	}
+	for i := 0; i < u-x; i++ {
+		d.keepA[x+i], d.keepB[y+i] = true, true
+	}
+	d.compare(a0, x, b0, y, 0)
+	d.compare(u, a1, v, b1, 0)
+	return true
+}
+
+// middleSnake runs the search from both ends of a[a0:a1] and b[b0:b1] until
+// the paths meet, and returns the snake (x, y) to (u, v) in the middle of a
+// shortest edit script. It reports false when limit is positive and the
+// script needs more edits.
+func (d *differ) middleSnake(a0, a1, b0, b1, limit int) (x, y, u, v int, ok bool) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta%2 != 0
+	vf, vb, off := d.vf, d.vb, d.off
+	vf[off+1], vb[off+1] = 0, 0
+	for e := 0; e <= (n+m+1)/2; e++ {
+		if limit > 0 && 2*e-1 > limit {
+			return 0, 0, 0, 0, false
+		}
+		// Forward paths of e edits.
+		for k := -e; k <= e; k += 2 {
+			var px int
+			if k == -e || (k != e && vf[off+k-1] < vf[off+k+1]) {
+				px = vf[off+k+1]
+			} else {
+				px = vf[off+k-1] + 1
+			}
+			py := px - k
+			sx, sy := px, py
+			for px < n && py < m && d.a[a0+px] == d.b[b0+py] {
+				px++
+				py++
+			}
+			vf[off+k] = px
+			// A backward path of e-1 edits on the same diagonal meets it.
+			if c := delta - k; odd && c >= -(e-1) && c <= e-1 && px+vb[off+c] >= n {
+				return a0 + sx, b0 + sy, a0 + px, b0 + py, true
+			}
+		}
+		// Backward paths of e edits, walking from the ends of both.
+		for c := -e; c <= e; c += 2 {
+			var px int
+			if c == -e || (c != e && vb[off+c-1] < vb[off+c+1]) {
+				px = vb[off+c+1]
+			} else {
+				px = vb[off+c-1] + 1
+			}
+			py := px - c
+			sx, sy := px, py
+			for px < n && py < m && d.a[a1-1-px] == d.b[b1-1-py] {
+				px++
+				py++
+			}
+			vb[off+c] = px
+			if k := delta - c; !odd && k >= -e && k <= e && vf[off+k]+px >= n {
+				return a1 - px, b1 - py, a1 - sx, b1 - sy, true
+			}
+		}
+	}
+	// Unreachable: the paths meet within (n+m+1)/2 edits from each end.
+	// Should they not, the range is left unmatched, shown as replaced, rather
+	// than split into itself forever.
+	return 0, 0, 0, 0, false
+}
+
+// matchMultiset keeps as many copies of each line as both logs contain.
+func matchMultiset(a, b []string) (keepA, keepB []bool) {
+	count := make(map[string]int)
+	for _, l := range b {
+		count[l]++
+	}
+	keepA = make([]bool, len(a))
+	for i, l := range a {
+		if count[l] > 0 {
+			count[l]--
+			keepA[i] = true
+		}
+	}
+	count = make(map[string]int)
+	for _, l := range a {
+		count[l]++
+	}
+	keepB = make([]bool, len(b))
+	for i, l := range b {
+		if count[l] > 0 {
+			count[l]--
+			keepB[i] = true
+		}
+	}
+	return keepA, keepB
+}
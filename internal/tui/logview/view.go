@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
 )
 
 type Model struct {
@@ -36,16 +40,32 @@ type Model struct {
 
 	// Live tailing for in-progress jobs
 	tailing bool
+
+	// Check-run annotations of the job and the jump list over them
+	annotations   []model.Annotation
+	picking       bool
+	annotationIdx int
+
+	// Diff against the same job's log in another attempt or run. The log
+	// shown before the diff is kept to return to.
+	diffing      bool
+	diffTitle    string
+	diffRemoved  int
+	diffAdded    int
+	savedJobName string
+	savedContent string
 }
 
 func New() Model {
 	ti := textinput.New()
 	ti.Placeholder = "Search in log..."
 	ti.CharLimit = 256
+	ui.StyleTextInput(&ti)
 	return Model{searchInput: ti, jumpLine: -1, wrap: true}
 }
 
 func (m *Model) SetContent(jobName, content string) {
+	m.diffing = false
 	m.jobName = jobName
 	m.content = content
 	m.loading = false
@@ -76,6 +96,10 @@ func (m *Model) GotoLine(line int) {
 // If the viewport was at the bottom (following), it auto-scrolls to bottom.
 // Otherwise it restores the previous YOffset.
 func (m *Model) UpdateContent(content string) {
+	if m.diffing {
+		m.savedContent = content
+		return
+	}
 	m.content = content
 	if !m.ready {
 		return
@@ -101,6 +125,74 @@ func (m *Model) UpdateContent(content string) {
 	}
 }
 
+// SetDiff replaces the log with the lines that differ between the same
+// job's log in a base and a head attempt or run, after Normalize. ExitDiff
+// returns to the log shown before.
+func (m *Model) SetDiff(title, base, head string) {
+	if !m.diffing {
+		m.savedJobName, m.savedContent = m.jobName, m.content
+	}
+	lines := Diff(base, head)
+	m.SetContent(title, renderDiff(lines))
+	m.diffing = true
+	m.diffTitle = title
+	m.diffRemoved, m.diffAdded = 0, 0
+	for _, l := range lines {
+		if l.Removed {
+			m.diffRemoved++
+		} else {
+			m.diffAdded++
+		}
+	}
+}
+
+// ExitDiff restores the log that was shown before SetDiff.
+func (m *Model) ExitDiff() {
+	if m.diffing {
+		m.SetContent(m.savedJobName, m.savedContent)
+		m.savedJobName, m.savedContent = "", ""
+	}
+}
+
+// IsDiffing reports whether a log diff is shown.
+func (m Model) IsDiffing() bool {
+	return m.diffing
+}
+
+// renderDiff lays out diff lines as "-  12 | text" for the base and
+// "+  14 | text" for the head, with "..." where matching lines were skipped.
+func renderDiff(lines []DiffLine) string {
+	if len(lines) == 0 {
+		return "\n  No differences once timestamps, durations, temp paths and hashes are ignored.\n"
+	}
+	var b strings.Builder
+	for _, l := range lines {
+		if l.Gap {
+			b.WriteString("  ...\n")
+		}
+		sign := "+"
+		if l.Removed {
+			sign = "-"
+		}
+		fmt.Fprintf(&b, "%s %5d | %s\n", sign, l.Line, l.Text)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// SetAnnotations sets the check-run annotations offered in the jump list.
+func (m *Model) SetAnnotations(annotations []model.Annotation) {
+	m.annotations = annotations
+	m.annotationIdx = 0
+	if len(annotations) == 0 {
+		m.picking = false
+	}
+}
+
+// IsPicking reports whether the annotation jump list is open.
+func (m Model) IsPicking() bool {
+	return m.picking
+}
+
 func (m *Model) SetTailing(tailing bool) {
 	m.tailing = tailing
 }
@@ -120,9 +212,29 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.picking {
+			switch {
+			case key.Matches(msg, ui.Keys.Down):
+				if m.annotationIdx < len(m.annotations)-1 {
+					m.annotationIdx++
+				}
+			case key.Matches(msg, ui.Keys.Up):
+				if m.annotationIdx > 0 {
+					m.annotationIdx--
+				}
+			case key.Matches(msg, ui.Keys.Enter):
+				m.picking = false
+				if line := AnnotationLine(m.content, m.annotations[m.annotationIdx]); line >= 0 {
+					m.GotoLine(line + 1)
+				}
+			case key.Matches(msg, ui.Keys.Annotations, ui.Keys.Close):
+				m.picking = false
+			}
+			return m, nil
+		}
 		if m.searching {
-			switch msg.String() {
-			case "enter":
+			switch {
+			case ui.MatchesWhileTyping(msg, ui.Keys.Enter):
 				query := m.searchInput.Value()
 				if query != "" {
 					m.searchQuery = query
@@ -136,7 +248,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.searching = false
 				m.searchInput.Blur()
 				return m, nil
-			case "esc":
+			case ui.MatchesWhileTyping(msg, ui.Keys.Back):
 				m.searching = false
 				m.searchInput.Blur()
 				return m, nil
@@ -146,37 +258,42 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, cmd
 		}
 
-		switch msg.String() {
-		case "/":
+		switch {
+		case key.Matches(msg, ui.Keys.Search):
 			m.searching = true
 			m.jumpLine = -1
 			m.searchInput.SetValue("")
 			m.searchInput.Focus()
 			return m, textinput.Blink
-		case "n":
+		case key.Matches(msg, ui.Keys.NextMatch):
 			if len(m.matchLines) > 0 {
 				m.matchIndex = (m.matchIndex + 1) % len(m.matchLines)
 				m.refreshViewport()
 				m.viewport.SetYOffset(m.wrappedLineFor(m.matchLines[m.matchIndex]))
 			}
 			return m, nil
-		case "N":
+		case key.Matches(msg, ui.Keys.PrevMatch):
 			if len(m.matchLines) > 0 {
 				m.matchIndex = (m.matchIndex - 1 + len(m.matchLines)) % len(m.matchLines)
 				m.refreshViewport()
 				m.viewport.SetYOffset(m.wrappedLineFor(m.matchLines[m.matchIndex]))
 			}
 			return m, nil
-		case "w":
+		case key.Matches(msg, ui.Keys.Annotations):
+			if len(m.annotations) > 0 && m.content != "" && !m.diffing {
+				m.picking = true
+			}
+			return m, nil
+		case key.Matches(msg, ui.Keys.Wrap):
 			m.wrap = !m.wrap
 			if m.content != "" {
 				m.refreshViewport()
 			}
 			return m, nil
-		case "g":
+		case key.Matches(msg, ui.Keys.Top):
 			m.viewport.GotoTop()
 			return m, nil
-		case "G":
+		case key.Matches(msg, ui.Keys.Bottom):
 			m.viewport.GotoBottom()
 			return m, nil
 		}
@@ -190,6 +307,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		}
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width, msg.Height-headerH)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
 			m.ready = true
 			if m.content != "" {
 				m.refreshViewport()
@@ -251,8 +369,10 @@ func (m *Model) refreshViewport() {
 		currentMatchLine = m.matchLines[m.matchIndex]
 	}
 
-	highlightStyle := lipgloss.NewStyle().Background(lipgloss.Color("#374151"))
-	currentStyle := lipgloss.NewStyle().Background(lipgloss.Color("#92400E")).Bold(true)
+	highlightStyle := ui.StyleMatchLine
+	currentStyle := ui.StyleCurrentMatch
+	removedStyle := lipgloss.NewStyle().Foreground(ui.ColorFailure)
+	addedStyle := lipgloss.NewStyle().Foreground(ui.ColorSuccess)
 
 	wrapWidth := m.width
 	doWrap := m.wrap && wrapWidth > 0
@@ -272,6 +392,18 @@ func (m *Model) refreshViewport() {
 		}
 
 		// Apply highlight to all segments of this source line
+		if m.diffing && !matchSet[i] && i != currentMatchLine {
+			style := removedStyle
+			switch {
+			case strings.HasPrefix(line, "+ "):
+				style = addedStyle
+			case !strings.HasPrefix(line, "- "):
+				style = lipgloss.NewStyle().Foreground(ui.ColorMuted)
+			}
+			for j := range segments {
+				segments[j] = style.Render(segments[j])
+			}
+		}
 		if hasSearch || hasJump {
 			if i == currentMatchLine || (hasJump && i == m.jumpLine) {
 				for j := range segments {
@@ -298,6 +430,30 @@ func (m Model) wrappedLineFor(sourceLine int) int {
 	return m.sourceToWrapped[sourceLine]
 }
 
+// AnnotationLine returns the 0-based log line an annotation was raised on,
+// or -1. Workflow commands appear in the log as "##[error]<message>", so a
+// line carrying a ##[ marker and the message's first line is preferred over
+// any other line that merely mentions the message.
+func AnnotationLine(content string, a model.Annotation) int {
+	msg := a.Summary()
+	if msg == "" {
+		return -1
+	}
+	fallback := -1
+	for i, line := range strings.Split(content, "\n") {
+		if !strings.Contains(line, msg) {
+			continue
+		}
+		if strings.Contains(line, "##[") {
+			return i
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
 // wrapLine hard-wraps a single line into segments of at most width runes.
 func wrapLine(line string, width int) []string {
 	runes := []rune(line)
@@ -326,24 +482,41 @@ func (m Model) View() string {
 	// Header line
 	liveTag := ""
 	if m.tailing {
-		liveTag = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#10B981")).Render(" [LIVE]")
+		liveTag = lipgloss.NewStyle().Bold(true).Foreground(ui.ColorSuccess).Render(" [LIVE]")
 	}
 	wrapTag := ""
 	if m.wrap {
-		wrapTag = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render(" [wrap]")
+		wrapTag = lipgloss.NewStyle().Foreground(ui.ColorMuted).Render(" [wrap]")
 	}
 	headerParts := fmt.Sprintf(" %s%s%s  %3.f%%", m.jobName, liveTag, wrapTag, m.viewport.ScrollPercent()*100)
+	if m.diffing {
+		headerParts += fmt.Sprintf("  [-%d +%d lines]", m.diffRemoved, m.diffAdded)
+	}
 	if m.searchQuery != "" && m.matchTotal > 0 {
 		headerParts += fmt.Sprintf("  [%d/%d matches]", m.matchIndex+1, m.matchTotal)
 	} else if m.searchQuery != "" {
 		headerParts += "  [no matches]"
 	}
-	hints := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render(
-		"  /:search  n/N:match  w:wrap  g/G:top/bot  esc:back")
+	k := ui.Keys
+	var annotationsHint string
+	back := ui.Hint("back", k.Exit)
+	if m.diffing {
+		back = ui.Hint("back to log", k.Exit)
+	} else if len(m.annotations) > 0 {
+		headerParts += fmt.Sprintf("  [%d annotations]", len(m.annotations))
+		annotationsHint = ui.Hint("annotations", k.Annotations)
+	}
+	hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render("  " + ui.Hints(annotationsHint,
+		ui.Hint("search", k.Search), ui.Hint("match", k.NextMatch, k.PrevMatch), ui.Hint("wrap", k.Wrap),
+		ui.Hint("top/bot", k.Top, k.Bottom), back))
 	header := lipgloss.NewStyle().Bold(true).
-		Foreground(lipgloss.Color("#F9FAFB")).
+		Foreground(ui.ColorText).
 		Render(headerParts) + hints
 
+	if m.picking {
+		return header + "\n" + m.renderAnnotations()
+	}
+
 	if m.searching {
 		searchLine := "  /" + m.searchInput.View()
 		return header + "\n" + searchLine + "\n" + m.viewport.View()
@@ -351,3 +524,42 @@ func (m Model) View() string {
 
 	return header + "\n" + m.viewport.View()
 }
+
+// renderAnnotations renders the jump list in place of the log.
+func (m Model) renderAnnotations() string {
+	muted := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	cursorStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorPrimary)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("  Annotations") +
+		muted.Render("  "+ui.Hints(ui.Hint("move", ui.Keys.Down, ui.Keys.Up),
+			ui.Hint("jump to log line", ui.Keys.Enter), ui.Hint("close", ui.Keys.Close))) + "\n\n")
+	for i, a := range m.annotations {
+		cursor := "  "
+		if i == m.annotationIdx {
+			cursor = cursorStyle.Render("> ")
+		}
+		text := a.Summary()
+		if a.Title != "" {
+			text = a.Title + ": " + text
+		}
+		if loc := a.Location(); loc != "" {
+			text = loc + "  " + text
+		}
+		if AnnotationLine(m.content, a) < 0 {
+			text += muted.Render("  (not in log)")
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, annotationIcon(a.AnnotationLevel), text))
+	}
+	return b.String()
+}
+
+func annotationIcon(level string) string {
+	switch level {
+	case "failure":
+		return lipgloss.NewStyle().Foreground(ui.ColorFailure).Render("✗")
+	case "warning":
+		return lipgloss.NewStyle().Foreground(ui.ColorWarning).Render("!")
+	}
+	return lipgloss.NewStyle().Foreground(ui.ColorInfo).Render("i")
+}
@@ -0,0 +1,168 @@
+package logview
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func TestAnnotationLine(t *testing.T) {
+	content := "2024-05-01T10:00:00Z Run go test ./...\n" +
+		"2024-05-01T10:00:01Z Error: expected 2, got 3\n" +
+		"2024-05-01T10:00:01Z ##[error]expected 2, got 3\n" +
+		"2024-05-01T10:00:02Z ##[error]Process completed with exit code 1."
+
+	tests := []struct {
+		name    string
+		message string
+		want    int
+	}{
+		{"prefers the ##[error] line", "expected 2, got 3", 2},
+		{"multi-line message matches its first line", "Process completed with exit code 1.\nmore detail", 3},
+		{"plain mention as fallback", "Run go test", 0},
+		{"not in log", "segfault", -1},
+		{"empty message", "", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AnnotationLine(content, model.Annotation{Message: tt.message}); got != tt.want {
+				t.Errorf("AnnotationLine() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+	}{
+		{"timestamp prefix", "2024-05-01T10:00:00.1234567Z Run make", "2024-06-02T11:30:12.7654321Z Run make"},
+		{"durations", "ok  example.com/pkg  1.234s", "ok  example.com/pkg  0.87s"},
+		{"step durations", "Finished in 3m12s", "Finished in 45s"},
+		{"temp paths", "/home/runner/work/_temp/4f2a.sh: line 1", "/home/runner/work/_temp/9c1b.sh: line 1"},
+		{"tmp dirs", "wrote /tmp/go-build123/b001/exe", "wrote /tmp/go-build987/b001/exe"},
+		{"uuids", "request 123e4567-e89b-12d3-a456-426614174000 failed", "request 9f8e7d6c-e89b-12d3-a456-426614174999 failed"},
+		{"hashes", "Cache hit for key deps-0123456789abcdef0123", "Cache hit for key deps-fedcba9876543210fedc"},
+		{"colour codes", "\x1b[32mPASS\x1b[0m", "PASS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if a, b := Normalize(tt.a), Normalize(tt.b); a != b {
+				t.Errorf("Normalize() = %q and %q, want equal", a, b)
+			}
+		})
+	}
+
+	if Normalize("expected 2, got 3") == Normalize("expected 2, got 4") {
+		t.Error("Normalize() should keep ordinary numbers")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	base := "2024-05-01T10:00:00Z Run go test ./...\n" +
+		"2024-05-01T10:00:01Z === RUN TestA\n" +
+		"2024-05-01T10:00:02Z --- PASS: TestA (0.01s)\n" +
+		"2024-05-01T10:00:03Z === RUN TestB\n" +
+		"2024-05-01T10:00:04Z --- FAIL: TestB (0.30s)\n" +
+		"2024-05-01T10:00:05Z     b_test.go:12: connection reset\n" +
+		"2024-05-01T10:00:06Z FAIL\n"
+	head := "2024-05-02T09:00:00Z Run go test ./...\n" +
+		"2024-05-02T09:00:01Z === RUN TestA\n" +
+		"2024-05-02T09:00:02Z --- PASS: TestA (0.02s)\n" +
+		"2024-05-02T09:00:03Z === RUN TestB\n" +
+		"2024-05-02T09:00:04Z --- PASS: TestB (0.25s)\n" +
+		"2024-05-02T09:00:06Z ok\n"
+
+	got := Diff(base, head)
+	want := []DiffLine{
+		{Removed: true, Line: 5, Text: "--- FAIL: TestB (0.30s)"},
+		{Removed: true, Line: 6, Text: "    b_test.go:12: connection reset"},
+		{Removed: true, Line: 7, Text: "FAIL"},
+		{Line: 5, Text: "--- PASS: TestB (0.25s)"},
+		{Line: 6, Text: "ok"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Diff() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Diff()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if d := Diff(base, base); len(d) != 0 {
+		t.Errorf("Diff() of identical logs = %+v, want none", d)
+	}
+}
+
+func TestDiffMarksGaps(t *testing.T) {
+	base := "a\nx\nb\nc\nd\ny\ne"
+	head := "a\nb\nc\nd\ne\nz"
+	got := Diff(base, head)
+	var gaps []bool
+	for _, l := range got {
+		gaps = append(gaps, l.Gap)
+	}
+	// x removed; y removed after the matching b, c, d; z added after e.
+	want := []bool{false, true, true}
+	if len(gaps) != len(want) {
+		t.Fatalf("Diff() = %+v", got)
+	}
+	for i := range want {
+		if gaps[i] != want[i] {
+			t.Errorf("Diff()[%d].Gap = %v, want %v", i, gaps[i], want[i])
+		}
+	}
+}
+
+func TestMatchLinesIsShortest(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	gen := func() []string {
+		l := make([]string, rng.IntN(40))
+		for i := range l {
+			l[i] = string(rune('a' + rng.IntN(4)))
+		}
+		return l
+	}
+	for range 500 {
+		a, b := gen(), gen()
+		keepA, keepB, ok := matchLines(a, b)
+		if !ok {
+			t.Fatalf("matchLines(%q, %q) gave up", a, b)
+		}
+		var ka, kb []string
+		for i, keep := range keepA {
+			if keep {
+				ka = append(ka, a[i])
+			}
+		}
+		for i, keep := range keepB {
+			if keep {
+				kb = append(kb, b[i])
+			}
+		}
+		if !slices.Equal(ka, kb) {
+			t.Fatalf("matchLines(%q, %q) kept %q and %q", a, b, ka, kb)
+		}
+		// Longest common subsequence by dynamic programming.
+		lcs := make([][]int, len(a)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		if len(ka) != lcs[0][0] {
+			t.Fatalf("matchLines(%q, %q) kept %d lines, want %d", a, b, len(ka), lcs[0][0])
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/cache"
+	"github.com/altinukshini/gha-tui/internal/config"
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/tui/dashboard"
+	"github.com/altinukshini/gha-tui/internal/tui/orgview"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// orgSummaryConcurrency is the number of repositories summarized at once.
+const orgSummaryConcurrency = 4
+
+// OrgApp is the organization overview. Selecting a repository opens the
+// per-repository App in its place; quitting that App returns here.
+type OrgApp struct {
+	cfg      config.Config // Owner is the organization; Repo is empty
+	client   *api.Client
+	logCache *cache.LogCache
+
+	view      orgview.Model
+	windows   []dashboard.TimeWindow
+	windowIdx int
+	sem       chan struct{} // bounds concurrent summaries
+	// live holds the part of each repository's summary that does not
+	// depend on the window, so that changing the window does not fetch it
+	// again. Reloading the repositories clears it.
+	live map[string]model.RepoSummary
+
+	// child is the App of the repository being browsed. Its messages are
+	// tagged with childGen so those of an App that was closed are dropped.
+	child    *App
+	childGen int
+
+	width  int
+	height int
+	status string
+	// limitNote notes the lists that stopped at the item limit.
+	limitNote string
+}
+
+// childMsg is a message produced by a command of the child App.
+type childMsg struct {
+	gen int
+	msg tea.Msg
+}
+
+// NewOrgApp creates the overview of the organization cfg.Owner. client is
+// scoped to the organization; repository clients are derived from it.
+func NewOrgApp(cfg config.Config, client *api.Client, logCache *cache.LogCache) OrgApp {
+	windows := dashboard.DefaultWindows
+	return OrgApp{
+		cfg:       cfg,
+		client:    client,
+		logCache:  logCache,
+		view:      orgview.New(cfg.Owner, windows[1].Label),
+		windows:   windows,
+		windowIdx: 1,
+		sem:       make(chan struct{}, orgSummaryConcurrency),
+		live:      make(map[string]model.RepoSummary),
+		status:    fmt.Sprintf("Loading repositories of %s...", cfg.Owner),
+	}
+}
+
+func (o OrgApp) Init() tea.Cmd {
+	return o.fetchOrgRepos()
+}
+
+// fetchOrgRepos lists the organization's active repositories with their
+// cache usage. Archived repositories cannot run workflows and are skipped.
+func (o OrgApp) fetchOrgRepos() tea.Cmd {
+	client := o.client
+	return func() tea.Msg {
+		resp, err := client.ListOrgRepos(context.Background(), listOptions())
+		if err != nil {
+			if errors.Is(err, api.ErrNotFound) {
+				err = fmt.Errorf("%s is not an organization or is not visible to you: %w", o.cfg.Owner, err)
+			}
+			return ui.OrgReposLoadedMsg{Err: err}
+		}
+		var repos []model.Repository
+		for _, r := range resp.Repositories {
+			if !r.Archived {
+				repos = append(repos, r)
+			}
+		}
+
+		msg := ui.OrgReposLoadedMsg{Repos: repos, Cache: make(map[string]model.RepoCacheUsage), Truncated: resp.Truncated}
+		usage, err := client.ListOrgCacheUsage(context.Background(), listOptions())
+		if err != nil {
+			msg.CacheErr = err
+			return msg
+		}
+		for _, u := range usage.Usages {
+			msg.Cache[u.FullName] = u
+		}
+		msg.CacheTruncated = usage.TotalCount > len(usage.Usages)
+		return msg
+	}
+}
+
+// fetchRepoSummaries summarizes every repository over the current window,
+// a few at a time.
+func (o OrgApp) fetchRepoSummaries(repos []model.Repository) tea.Cmd {
+	days := o.windows[o.windowIdx].Days
+	cmds := make([]tea.Cmd, len(repos))
+	for i, r := range repos {
+		var live *model.RepoSummary
+		if s, ok := o.live[r.FullName]; ok {
+			live = &s
+		}
+		cmds[i] = o.fetchRepoSummary(r, days, live)
+	}
+	return tea.Batch(cmds...)
+}
+
+// fetchRepoSummary counts the completed and failed runs of a repository of
+// the last days days. Unless live already has them, it also reads the latest
+// default-branch run and counts the runs in flight. Only total_count is
+// used, so each count costs one request.
+func (o OrgApp) fetchRepoSummary(repo model.Repository, days int, live *model.RepoSummary) tea.Cmd {
+	client := o.client.ForRepo(o.cfg.Owner, repo.Name)
+	sem := o.sem
+	return func() tea.Msg {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+		ctx := context.Background()
+		msg := ui.OrgRepoSummaryMsg{Repo: repo.FullName, Days: days}
+		created := ">=" + time.Now().AddDate(0, 0, -days).UTC().Format("2006-01-02")
+
+		count := func(f api.RunsFilter) (*model.RunsResponse, error) {
+			if err := client.RateLimit().Wait(ctx); err != nil {
+				return nil, err
+			}
+			f.PerPage = 1
+			return client.ListRuns(ctx, f)
+		}
+		type countOf struct {
+			filter api.RunsFilter
+			n      *int
+		}
+		counts := []countOf{
+			{api.RunsFilter{Status: string(model.RunStatusCompleted), Created: created}, &msg.Summary.Completed},
+			{api.RunsFilter{Status: string(model.ConclusionFailure), Created: created}, &msg.Summary.Failed},
+		}
+		if live != nil {
+			msg.Summary = *live
+		} else {
+			latest, err := count(api.RunsFilter{Branch: repo.DefaultBranch})
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			if len(latest.Runs) > 0 {
+				msg.Summary.LatestRun = &latest.Runs[0]
+			}
+			counts = append(counts,
+				countOf{api.RunsFilter{Status: string(model.RunStatusInProgress)}, &msg.Summary.InProgress},
+				countOf{api.RunsFilter{Status: string(model.RunStatusQueued)}, &msg.Summary.Queued})
+		}
+		for _, c := range counts {
+			resp, err := count(c.filter)
+			if err != nil {
+				msg.Err = err
+				return msg
+			}
+			*c.n = resp.TotalCount
+		}
+		return msg
+	}
+}
+
+// openRepo replaces the overview with the App of a repository.
+func (o *OrgApp) openRepo(repo model.Repository) tea.Cmd {
+	cfg := o.cfg.WithRepo(o.cfg.Owner, repo.Name)
+	app := NewApp(cfg, o.client.ForRepo(o.cfg.Owner, repo.Name), o.logCache)
+	o.child = &app
+	o.childGen++
+
+	m, sizeCmd := app.Update(tea.WindowSizeMsg{Width: o.width, Height: o.height})
+	o.child = m.(*App)
+	return tea.Batch(o.wrap(app.Init()), o.wrap(sizeCmd))
+}
+
+// wrap tags the messages of a child command with the current generation,
+// looking inside batches so each of their commands is tagged too.
+func (o OrgApp) wrap(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	gen := o.childGen
+	var tag func(tea.Cmd) tea.Cmd
+	tag = func(cmd tea.Cmd) tea.Cmd {
+		if cmd == nil {
+			return nil
+		}
+		return func() tea.Msg {
+			msg := cmd()
+			if batch, ok := msg.(tea.BatchMsg); ok {
+				tagged := make(tea.BatchMsg, len(batch))
+				for i, c := range batch {
+					tagged[i] = tag(c)
+				}
+				return tagged
+			}
+			return childMsg{gen: gen, msg: msg}
+		}
+	}
+	return tag(cmd)
+}
+
+func (o OrgApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if key.Matches(msg, ui.Keys.ForceQuit) {
+			return &o, tea.Quit
+		}
+
+	case tea.WindowSizeMsg:
+		o.width, o.height = msg.Width, msg.Height
+		o.view, _ = o.view.Update(tea.WindowSizeMsg{Width: msg.Width - 4, Height: msg.Height - 4})
+
+	case childMsg:
+		if o.child == nil || msg.gen != o.childGen {
+			return &o, nil
+		}
+		if _, ok := msg.msg.(tea.QuitMsg); ok {
+			o.child = nil
+			o.status = o.overviewStatus()
+			return &o, nil
+		}
+		return o.updateChild(msg.msg)
+
+	case ui.OrgReposLoadedMsg:
+		if msg.Err != nil {
+			o.view.SetError(msg.Err)
+			o.status = fmt.Sprintf("Error loading repositories: %s", ui.FormatError(msg.Err))
+			return &o, nil
+		}
+		o.view.SetRepos(msg.Repos, msg.Cache, msg.CacheErr)
+		o.limitNote = ""
+		if msg.Truncated {
+			o.limitNote += fmt.Sprintf(" — list stopped at %d", listLimit)
+		}
+		if msg.CacheTruncated {
+			o.limitNote += fmt.Sprintf(" — cache usage stopped at %d repositories", listLimit)
+		}
+		o.status = o.overviewStatus()
+		return &o, o.fetchRepoSummaries(msg.Repos)
+
+	case ui.OrgRepoSummaryMsg:
+		if msg.Err == nil {
+			s := msg.Summary
+			o.live[msg.Repo] = model.RepoSummary{LatestRun: s.LatestRun, InProgress: s.InProgress, Queued: s.Queued}
+		}
+		if msg.Days == o.windows[o.windowIdx].Days {
+			o.view.SetSummary(msg.Repo, msg.Summary, msg.Err)
+			o.status = o.overviewStatus()
+		}
+		return &o, nil
+	}
+
+	if o.child != nil {
+		return o.updateChild(msg)
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch k := &ui.Keys; k.Lookup(ui.ContextOrg, keyMsg) {
+		case &k.Quit:
+			return &o, tea.Quit
+		case &k.Enter:
+			if repo := o.view.Selected(); repo != nil {
+				return &o, o.openRepo(*repo)
+			}
+			return &o, nil
+		case &k.Refresh:
+			o.live = make(map[string]model.RepoSummary)
+			o.status = fmt.Sprintf("Loading repositories of %s...", o.cfg.Owner)
+			return &o, o.fetchOrgRepos()
+		case &k.CycleWindow:
+			o.windowIdx = (o.windowIdx + 1) % len(o.windows)
+			o.view.SetWindow(o.windows[o.windowIdx].Label)
+			o.status = o.overviewStatus()
+			return &o, o.fetchRepoSummaries(o.view.Repos())
+		}
+	}
+
+	var cmd tea.Cmd
+	o.view, cmd = o.view.Update(msg)
+	return &o, cmd
+}
+
+// updateChild hands a message to the repository App.
+func (o OrgApp) updateChild(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := o.child.Update(msg)
+	o.child = m.(*App)
+	return &o, o.wrap(cmd)
+}
+
+func (o OrgApp) overviewStatus() string {
+	if n := o.view.Pending(); n > 0 {
+		return fmt.Sprintf("Summarizing %d repositories...", n)
+	}
+	return fmt.Sprintf("%d repositories%s", len(o.view.Repos()), o.limitNote)
+}
+
+func (o OrgApp) hints() string {
+	k := ui.Keys
+	return ui.Hints(ui.Hint("open", k.Enter), ui.Hint("sort", k.Sort), ui.Hint("window", k.CycleWindow),
+		ui.Hint("refresh", k.Refresh), ui.Hint("quit", k.Quit))
+}
+
+func (o OrgApp) View() string {
+	if o.child != nil {
+		return o.child.View()
+	}
+	snap := o.client.RateLimit().Snapshot()
+	var reset time.Time
+	if exhausted, at := o.client.RateLimit().Exhausted(); exhausted {
+		reset = at
+	}
+	header := RenderHeader("org: "+o.cfg.Owner, snap.Remaining, snap.Limit, reset, o.width)
+	contentH := max(o.height-4, 1)
+	content := ui.StylePaneFocused.Width(o.width - 2).Height(contentH).Render(o.view.View())
+	return header + "\n" + content + "\n" + RenderStatusBar(o.status, o.hints(), o.width)
+}
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/config"
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+func TestOrgAppOpensAndClosesRepo(t *testing.T) {
+	o := NewOrgApp(config.Config{Owner: "octo"}, &api.Client{}, nil)
+	update := func(msg tea.Msg) tea.Cmd {
+		m, cmd := o.Update(msg)
+		o = *m.(*OrgApp)
+		return cmd
+	}
+	update(tea.WindowSizeMsg{Width: 120, Height: 30})
+	update(ui.OrgReposLoadedMsg{Repos: []model.Repository{
+		{Name: "api", FullName: "octo/api", DefaultBranch: "main"},
+		{Name: "web", FullName: "octo/web", DefaultBranch: "main"},
+	}})
+
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	if o.child == nil || o.child.cfg.RepoNWO() != "octo/web" {
+		t.Fatalf("enter did not open octo/web: %+v", o.child)
+	}
+	first := o.childGen
+
+	// The child's quit returns to the overview rather than exiting.
+	if cmd := update(o.wrap(tea.Quit)()); cmd != nil {
+		t.Errorf("child quit returned a command")
+	}
+	if o.child != nil {
+		t.Fatal("child still open after it quit")
+	}
+
+	// Messages of a closed child are dropped.
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	if o.childGen == first {
+		t.Fatal("reopening did not start a new generation")
+	}
+	update(childMsg{gen: first, msg: tea.QuitMsg{}})
+	if o.child == nil {
+		t.Error("a stale quit closed the new child")
+	}
+}
+
+func TestOrgAppDropsSummariesOfOldWindow(t *testing.T) {
+	o := NewOrgApp(config.Config{Owner: "octo"}, &api.Client{}, nil)
+	m, _ := o.Update(ui.OrgReposLoadedMsg{Repos: []model.Repository{{Name: "api", FullName: "octo/api"}}})
+	o = *m.(*OrgApp)
+	m, _ = o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	o = *m.(*OrgApp)
+
+	old := o.windows[(o.windowIdx+len(o.windows)-1)%len(o.windows)].Days
+	m, _ = o.Update(ui.OrgRepoSummaryMsg{Repo: "octo/api", Days: old})
+	o = *m.(*OrgApp)
+	if o.view.Pending() != 1 {
+		t.Error("summary of the previous window was applied")
+	}
+	m, _ = o.Update(ui.OrgRepoSummaryMsg{Repo: "octo/api", Days: o.windows[o.windowIdx].Days})
+	o = *m.(*OrgApp)
+	if o.view.Pending() != 0 {
+		t.Error("summary of the current window was dropped")
+	}
+}
+
+func TestOrgAppNotesTruncatedList(t *testing.T) {
+	o := NewOrgApp(config.Config{Owner: "octo"}, &api.Client{}, nil)
+	m, _ := o.Update(ui.OrgReposLoadedMsg{Repos: []model.Repository{{Name: "api", FullName: "octo/api"}}, Truncated: true})
+	o = *m.(*OrgApp)
+	m, _ = o.Update(ui.OrgRepoSummaryMsg{Repo: "octo/api", Days: o.windows[o.windowIdx].Days})
+	o = *m.(*OrgApp)
+	if want := "1 repositories — list stopped at 1000"; o.status != want {
+		t.Errorf("status = %q, want %q", o.status, want)
+	}
+}
+
+func TestOrgAppReusesLiveCountsAcrossWindows(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, `{"total_count":2,"workflow_runs":[{"id":1}]}`)
+	}))
+	t.Cleanup(srv.Close)
+	client, err := api.NewClient("octo", "", api.Options{
+		Host:      strings.TrimPrefix(srv.URL, "https://"),
+		AuthToken: "test-token",
+		Transport: srv.Client().Transport,
+		Retry:     &api.RetryPolicy{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := NewOrgApp(config.Config{Owner: "octo"}, client, nil)
+	repo := model.Repository{Name: "api", FullName: "octo/api", DefaultBranch: "main"}
+	m, _ := o.Update(ui.OrgReposLoadedMsg{Repos: []model.Repository{repo}})
+	o = *m.(*OrgApp)
+
+	summarize := func() ui.OrgRepoSummaryMsg {
+		t.Helper()
+		msg := o.fetchRepoSummaries([]model.Repository{repo})().(ui.OrgRepoSummaryMsg)
+		m, _ := o.Update(msg)
+		o = *m.(*OrgApp)
+		return msg
+	}
+	summarize()
+	if n := requests.Swap(0); n != 5 {
+		t.Errorf("first summary made %d requests, want 5", n)
+	}
+	m, _ = o.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	o = *m.(*OrgApp)
+	msg := summarize()
+	if n := requests.Load(); n != 2 {
+		t.Errorf("summary of the next window made %d requests, want 2", n)
+	}
+	if msg.Summary.LatestRun == nil || msg.Summary.InProgress != 2 || msg.Summary.Failed != 2 {
+		t.Errorf("summary = %+v, want the live counts kept", msg.Summary)
+	}
+}
@@ -0,0 +1,367 @@
+// Package orgview is the organization overview: one row per repository
+// with the state of its default branch, the runs in flight, how often runs
+// failed over a time window and how much Actions cache it holds.
+package orgview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// row is one repository of the overview. summary is nil until it loads.
+type row struct {
+	repo    model.Repository
+	cache   model.RepoCacheUsage
+	summary *model.RepoSummary
+	err     error
+}
+
+// broken reports whether the latest run on the default branch failed.
+func (r row) broken() bool {
+	return r.summary != nil && r.summary.LatestRun != nil &&
+		r.summary.LatestRun.Conclusion == model.ConclusionFailure
+}
+
+type sortOrder int
+
+const (
+	sortName sortOrder = iota
+	sortBroken
+	sortFailureRate
+	sortActive
+	sortCount
+)
+
+var sortLabels = [sortCount]string{"name", "broken first", "failure rate", "activity"}
+
+// Model is the organization overview table.
+type Model struct {
+	org      string
+	window   string
+	rows     []row
+	cursor   int
+	offset   int
+	sort     sortOrder
+	loading  bool
+	err      error
+	cacheErr error
+	now      func() time.Time
+	width    int
+	height   int
+}
+
+func New(org, window string) Model {
+	return Model{org: org, window: window, loading: true, now: time.Now}
+}
+
+// SetRepos lists the organization's repositories; their summaries load
+// afterwards. cache holds the cache usage by full name.
+func (m *Model) SetRepos(repos []model.Repository, cache map[string]model.RepoCacheUsage, cacheErr error) {
+	selected := m.Selected()
+	m.loading = false
+	m.err = nil
+	m.cacheErr = cacheErr
+	m.rows = make([]row, len(repos))
+	for i, r := range repos {
+		m.rows[i] = row{repo: r, cache: cache[r.FullName]}
+	}
+	m.resort(selected)
+}
+
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = err
+}
+
+// SetSummary fills in the summary of one repository.
+func (m *Model) SetSummary(fullName string, s model.RepoSummary, err error) {
+	selected := m.Selected()
+	for i := range m.rows {
+		if m.rows[i].repo.FullName == fullName {
+			m.rows[i].summary, m.rows[i].err = &s, err
+			if err != nil {
+				m.rows[i].summary = nil
+			}
+		}
+	}
+	m.resort(selected)
+}
+
+// SetWindow changes the failure-rate window and drops the summaries
+// computed over the previous one.
+func (m *Model) SetWindow(label string) {
+	m.window = label
+	for i := range m.rows {
+		m.rows[i].summary, m.rows[i].err = nil, nil
+	}
+}
+
+// Selected returns the repository under the cursor.
+func (m Model) Selected() *model.Repository {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	r := m.rows[m.cursor].repo
+	return &r
+}
+
+// Repos returns the repositories in display order.
+func (m Model) Repos() []model.Repository {
+	repos := make([]model.Repository, len(m.rows))
+	for i, r := range m.rows {
+		repos[i] = r.repo
+	}
+	return repos
+}
+
+// Pending returns how many repositories are still waiting for a summary.
+func (m Model) Pending() int {
+	n := 0
+	for _, r := range m.rows {
+		if r.summary == nil && r.err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// resort orders the rows and keeps the cursor on the selected repository.
+func (m *Model) resort(selected *model.Repository) {
+	sortRows(m.rows, m.sort)
+	m.cursor = 0
+	if selected != nil {
+		for i, r := range m.rows {
+			if r.repo.FullName == selected.FullName {
+				m.cursor = i
+			}
+		}
+	}
+	m.clamp()
+}
+
+// sortRows orders rows by the given order, by name among equals. Rows whose
+// summary has not loaded sort last except by name.
+func sortRows(rows []row, order sortOrder) {
+	key := func(r row) float64 {
+		if order == sortName {
+			return 0
+		}
+		if r.summary == nil {
+			return -1
+		}
+		switch order {
+		case sortBroken:
+			k := r.summary.FailureRate()
+			if r.broken() {
+				k += 2
+			}
+			return k
+		case sortFailureRate:
+			return r.summary.FailureRate()
+		case sortActive:
+			return float64(r.summary.InProgress + r.summary.Queued)
+		}
+		return 0
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if ki, kj := key(rows[i]), key(rows[j]); ki != kj {
+			return ki > kj
+		}
+		return strings.ToLower(rows[i].repo.Name) < strings.ToLower(rows[j].repo.Name)
+	})
+}
+
+func (m Model) Init() tea.Cmd { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, ui.Keys.Down):
+			m.cursor++
+		case key.Matches(msg, ui.Keys.Up):
+			m.cursor--
+		case key.Matches(msg, ui.Keys.PageDown):
+			m.cursor += m.visibleRows()
+		case key.Matches(msg, ui.Keys.PageUp):
+			m.cursor -= m.visibleRows()
+		case key.Matches(msg, ui.Keys.Top):
+			m.cursor = 0
+		case key.Matches(msg, ui.Keys.Bottom):
+			m.cursor = len(m.rows) - 1
+		case key.Matches(msg, ui.Keys.Sort):
+			m.sort = (m.sort + 1) % sortCount
+			m.resort(m.Selected())
+		}
+		m.clamp()
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.clamp()
+	}
+	return m, nil
+}
+
+// visibleRows is the number of repositories that fit below the headers.
+func (m Model) visibleRows() int {
+	return max(m.height-4, 1)
+}
+
+func (m *Model) clamp() {
+	m.cursor = max(min(m.cursor, len(m.rows)-1), 0)
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if n := m.visibleRows(); m.cursor >= m.offset+n {
+		m.offset = m.cursor - n + 1
+	}
+}
+
+const (
+	latestWidth = 30
+	countWidth  = 9
+	rateWidth   = 16
+)
+
+func (m Model) View() string {
+	switch {
+	case m.err != nil:
+		return ui.RenderError(m.err)
+	case m.loading:
+		return fmt.Sprintf("\n  Loading repositories of %s...", m.org)
+	case len(m.rows) == 0:
+		return fmt.Sprintf("\n  %s has no repositories with Actions.", m.org)
+	}
+
+	var b strings.Builder
+	b.WriteString(ui.StyleMuted.Render(m.summaryLine()) + "\n\n")
+
+	nameWidth := 10
+	for _, r := range m.rows {
+		nameWidth = max(nameWidth, min(len(r.repo.Name), 40))
+	}
+	nameWidth += 2
+	bold := lipgloss.NewStyle().Bold(true)
+	b.WriteString(bold.Render(line(nameWidth, "Repository", "Default branch", "Running", "Queued", "Failed ("+m.window+")", "Cache")) + "\n")
+
+	end := min(m.offset+m.visibleRows(), len(m.rows))
+	for i := m.offset; i < end; i++ {
+		l := m.renderRow(m.rows[i], nameWidth)
+		if i == m.cursor {
+			l = ui.StyleSelected.Width(m.width).Render(l)
+		}
+		b.WriteString(l + "\n")
+	}
+	return b.String()
+}
+
+func (m Model) summaryLine() string {
+	broken, running, queued := 0, 0, 0
+	for _, r := range m.rows {
+		if r.broken() {
+			broken++
+		}
+		if r.summary != nil {
+			running += r.summary.InProgress
+			queued += r.summary.Queued
+		}
+	}
+	s := fmt.Sprintf("  %s: %d repos | %d broken | %d running | %d queued | window %s | sort: %s",
+		m.org, len(m.rows), broken, running, queued, m.window, sortLabels[m.sort])
+	if n := m.Pending(); n > 0 {
+		s += fmt.Sprintf(" | loading %d...", n)
+	}
+	if m.cacheErr != nil {
+		s += " | cache usage unavailable"
+	}
+	return s
+}
+
+func (m Model) renderRow(r row, nameWidth int) string {
+	name := truncate(r.repo.Name, nameWidth-2)
+	cache := ui.StyleMuted.Render("-")
+	if r.cache.Count > 0 {
+		cache = fmt.Sprintf("%s (%d)", ui.FormatSize(r.cache.SizeInBytes), r.cache.Count)
+	}
+
+	switch {
+	case r.err != nil:
+		return line(nameWidth, name, ui.StyleFailure.Render(truncate(ui.FormatError(r.err), latestWidth-2)), "", "", "", cache)
+	case r.summary == nil:
+		return line(nameWidth, name, ui.StyleMuted.Render("..."), "", "", "", cache)
+	}
+
+	s := r.summary
+	latest := ui.StyleMuted.Render("no runs")
+	if run := s.LatestRun; run != nil {
+		status := string(run.Conclusion)
+		if run.Status != model.RunStatusCompleted {
+			status = string(run.Status)
+		}
+		latest = ui.ConclusionStyle(status).Render(ui.StatusIcon(status)+" "+status) +
+			ui.StyleMuted.Render(" "+age(m.now().Sub(run.CreatedAt)))
+	}
+
+	rate := ui.StyleMuted.Render("-")
+	if s.Completed > 0 {
+		style := ui.StyleSuccess
+		switch pct := s.FailureRate() * 100; {
+		case pct >= 25:
+			style = ui.StyleFailure
+		case pct > 0:
+			style = ui.StyleWarning
+		}
+		rate = style.Render(fmt.Sprintf("%3.0f%%", s.FailureRate()*100)) +
+			ui.StyleMuted.Render(fmt.Sprintf(" %d/%d", s.Failed, s.Completed))
+	}
+
+	return line(nameWidth, name, latest, count(s.InProgress, ui.StyleInfo), count(s.Queued, ui.StyleWarning), rate, cache)
+}
+
+// line lays out one row; cells are padded by display width so styled text
+// lines up.
+func line(nameWidth int, name, latest, running, queued, rate, cache string) string {
+	return "  " + pad(name, nameWidth) + pad(latest, latestWidth) + pad(running, countWidth) +
+		pad(queued, countWidth) + pad(rate, rateWidth) + cache
+}
+
+func pad(s string, w int) string {
+	if n := lipgloss.Width(s); n < w {
+		return s + strings.Repeat(" ", w-n)
+	}
+	return s + " "
+}
+
+func count(n int, style lipgloss.Style) string {
+	if n == 0 {
+		return ui.StyleMuted.Render("0")
+	}
+	return style.Render(fmt.Sprint(n))
+}
+
+func age(d time.Duration) string {
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
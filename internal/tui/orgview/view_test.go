@@ -0,0 +1,98 @@
+package orgview
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func run(conclusion model.RunConclusion) *model.Run {
+	return &model.Run{Status: model.RunStatusCompleted, Conclusion: conclusion, CreatedAt: now.Add(-2 * time.Hour)}
+}
+
+func newModel() Model {
+	m := New("octo", "7d")
+	m.now = func() time.Time { return now }
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	m.SetRepos([]model.Repository{
+		{Name: "web", FullName: "octo/web"},
+		{Name: "api", FullName: "octo/api"},
+		{Name: "docs", FullName: "octo/docs"},
+		{Name: "cli", FullName: "octo/cli"},
+	}, map[string]model.RepoCacheUsage{
+		"octo/api": {FullName: "octo/api", SizeInBytes: 3 << 20, Count: 4},
+	}, nil)
+	m.SetSummary("octo/web", model.RepoSummary{LatestRun: run(model.ConclusionSuccess), Completed: 10, Failed: 5, InProgress: 1}, nil)
+	m.SetSummary("octo/api", model.RepoSummary{LatestRun: run(model.ConclusionFailure), Completed: 10, Failed: 1, Queued: 3}, nil)
+	m.SetSummary("octo/docs", model.RepoSummary{}, nil)
+	return m
+}
+
+func names(m Model) string {
+	var out []string
+	for _, r := range m.rows {
+		out = append(out, r.repo.Name)
+	}
+	return strings.Join(out, " ")
+}
+
+func TestSortOrders(t *testing.T) {
+	m := newModel()
+	tests := []struct {
+		order sortOrder
+		want  string
+	}{
+		{sortName, "api cli docs web"},
+		{sortBroken, "api web docs cli"},
+		{sortFailureRate, "web api docs cli"},
+		{sortActive, "api web docs cli"},
+	}
+	for _, tt := range tests {
+		t.Run(sortLabels[tt.order], func(t *testing.T) {
+			sortRows(m.rows, tt.order)
+			if got := names(m); got != tt.want {
+				t.Errorf("order = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortKeepsSelection(t *testing.T) {
+	m := newModel()
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	if got := m.Selected().Name; got != "web" {
+		t.Fatalf("Selected() = %s after G, want web", got)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if got := m.Selected().Name; got != "web" {
+		t.Errorf("Selected() = %s after sorting, want web", got)
+	}
+}
+
+func TestViewShowsSummaries(t *testing.T) {
+	m := newModel()
+	out := m.View()
+	for _, want := range []string{
+		"4 repos | 1 broken | 1 running | 3 queued",
+		"loading 1...",
+		"failure 2h ago",
+		"50% 5/10",
+		"3.0 MB (4)",
+		"no runs",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("View() missing %q:\n%s", want, out)
+		}
+	}
+
+	m.SetWindow("30d")
+	if m.Pending() != 4 {
+		t.Errorf("Pending() = %d after changing the window, want 4", m.Pending())
+	}
+}
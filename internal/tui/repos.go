@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// repoClients returns the client of every watched repository, the primary
+// repository's first.
+func (a App) repoClients() []*api.Client {
+	return append([]*api.Client{a.client}, a.watched...)
+}
+
+// clientFor returns the client of the repository a run belongs to. Runs of
+// an unknown repository are taken to be the primary repository's.
+func (a App) clientFor(run *model.Run) *api.Client {
+	if run != nil {
+		for _, c := range a.watched {
+			if strings.EqualFold(c.RepoNWO(), run.Repository.FullName) {
+				return c
+			}
+		}
+	}
+	return a.client
+}
+
+// runClient returns the client of the run with the given ID, looked up
+// among the runs the app is showing.
+func (a App) runClient(runID int64) *api.Client {
+	if len(a.watched) == 0 {
+		return a.client
+	}
+	if run := a.runsView.RunByID(runID); run != nil {
+		return a.clientFor(run)
+	}
+	for _, run := range []*model.Run{a.detailsView.Run(), a.infoView.Run()} {
+		if run != nil && run.ID == runID {
+			return a.clientFor(run)
+		}
+	}
+	for _, run := range a.runsView.CompareRuns() {
+		if run.ID == runID {
+			return a.clientFor(&run)
+		}
+	}
+	return a.client
+}
+
+// detailsClient returns the client of the run open in the details pane,
+// for requests that only name one of its jobs.
+func (a App) detailsClient() *api.Client {
+	return a.clientFor(a.detailsView.Run())
+}
+
+// listRuns lists one page of runs. With several watched repositories the
+// page is fetched from each, or from the one the filter picks, and merged
+// newest first; pages is then the page count of the repository with the
+// most matching runs. A workflow filter only applies to the primary
+// repository, whose workflows the filter offers.
+func (a App) listRuns(ctx context.Context, filter api.RunsFilter) (resp *model.RunsResponse, pages int, err error) {
+	if len(a.watched) == 0 {
+		resp, err = a.client.ListRuns(ctx, filter)
+		return resp, 0, err
+	}
+	clients := a.repoClients()
+	if filter.WorkflowID != 0 {
+		clients = clients[:1]
+	}
+	if repo := a.runsFilter.Repo; repo != "" {
+		var picked []*api.Client
+		for _, c := range clients {
+			if strings.EqualFold(c.RepoNWO(), repo) {
+				picked = append(picked, c)
+			}
+		}
+		clients = picked
+	}
+	return mergeRuns(ctx, clients, filter)
+}
+
+// mergeRuns fetches the same page of runs from every client concurrently
+// and merges them newest first. Runs are tagged with their repository when
+// the API left it out.
+func mergeRuns(ctx context.Context, clients []*api.Client, filter api.RunsFilter) (*model.RunsResponse, int, error) {
+	resps := make([]*model.RunsResponse, len(clients))
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resps[i], errs[i] = c.ListRuns(ctx, filter)
+		}()
+	}
+	wg.Wait()
+
+	merged := &model.RunsResponse{}
+	pages := 1
+	for i, resp := range resps {
+		if errs[i] != nil {
+			return nil, 0, fmt.Errorf("%s: %w", clients[i].RepoNWO(), errs[i])
+		}
+		for _, r := range resp.Runs {
+			if r.Repository.FullName == "" {
+				r.Repository.FullName = clients[i].RepoNWO()
+			}
+			merged.Runs = append(merged.Runs, r)
+		}
+		merged.TotalCount += resp.TotalCount
+		if filter.PerPage > 0 {
+			pages = max(pages, (resp.TotalCount+filter.PerPage-1)/filter.PerPage)
+		}
+	}
+	sort.SliceStable(merged.Runs, func(i, j int) bool {
+		return merged.Runs[i].CreatedAt.After(merged.Runs[j].CreatedAt)
+	})
+	return merged, pages, nil
+}
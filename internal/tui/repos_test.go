@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+	"github.com/altinukshini/gha-tui/internal/config"
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// newRepoClients serves the runs of two repositories: octo/app has 45
+// runs, octo/lib 3, and each response carries two of them.
+func newRepoClients(t *testing.T) (app, lib *api.Client) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/octo/app/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count":45,"workflow_runs":[
+			{"id":1,"created_at":"2026-03-01T12:00:00Z","repository":{"full_name":"octo/app"}},
+			{"id":3,"created_at":"2026-03-01T10:00:00Z","repository":{"full_name":"octo/app"}}]}`)
+	})
+	mux.HandleFunc("/api/v3/repos/octo/lib/actions/runs", func(w http.ResponseWriter, r *http.Request) {
+		// No repository field: the client's repository is assumed.
+		fmt.Fprint(w, `{"total_count":3,"workflow_runs":[
+			{"id":2,"created_at":"2026-03-01T11:00:00Z"},
+			{"id":4,"created_at":"2026-03-01T09:00:00Z"}]}`)
+	})
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+
+	opts := api.Options{
+		Host:        strings.TrimPrefix(srv.URL, "https://"),
+		AuthToken:   "test-token",
+		Transport:   srv.Client().Transport,
+		Retry:       &api.RetryPolicy{},
+		RateTracker: api.NewRateTracker(),
+	}
+	var err error
+	if app, err = api.NewClient("octo", "app", opts); err != nil {
+		t.Fatal(err)
+	}
+	if lib, err = api.NewClient("octo", "lib", opts); err != nil {
+		t.Fatal(err)
+	}
+	return app, lib
+}
+
+func TestListRunsMergesWatchedRepos(t *testing.T) {
+	primary, lib := newRepoClients(t)
+	a := NewApp(config.Config{Owner: "octo", Repo: "app", Watch: []string{"octo/lib"}}, primary, nil, lib)
+
+	resp, pages, err := a.listRuns(context.Background(), api.RunsFilter{PerPage: a.cfg.RunsPerPage, Page: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range resp.Runs {
+		got = append(got, fmt.Sprintf("%d:%s", r.ID, r.Repository.FullName))
+	}
+	want := "1:octo/app 2:octo/lib 3:octo/app 4:octo/lib"
+	if strings.Join(got, " ") != want {
+		t.Errorf("runs = %v, want %s", got, want)
+	}
+	if resp.TotalCount != 48 || pages != 2 {
+		t.Errorf("TotalCount = %d, pages = %d, want 48 and 2", resp.TotalCount, pages)
+	}
+
+	// Each run is handled by its own repository's client.
+	a.runsView, _ = a.runsView.Update(ui.RunsLoadedMsg{Runs: resp.Runs})
+	if c := a.runClient(2); c != lib {
+		t.Errorf("runClient(2) = %s, want octo/lib", c.RepoNWO())
+	}
+	if c := a.runClient(3); c != primary {
+		t.Errorf("runClient(3) = %s, want octo/app", c.RepoNWO())
+	}
+
+	// The repo filter narrows the listing to one repository.
+	a.runsFilter.Repo = "octo/lib"
+	resp, pages, err = a.listRuns(context.Background(), api.RunsFilter{PerPage: a.cfg.RunsPerPage, Page: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Runs) != 2 || resp.TotalCount != 3 || pages != 1 {
+		t.Errorf("filtered listing = %d runs of %d in %d pages, want 2 of 3 in 1", len(resp.Runs), resp.TotalCount, pages)
+	}
+	for _, r := range resp.Runs {
+		if r.Repository.FullName != "octo/lib" {
+			t.Errorf("run %d from %s with repo filter octo/lib", r.ID, r.Repository.FullName)
+		}
+	}
+}
+
+func TestClientForUnknownRepoIsPrimary(t *testing.T) {
+	primary, lib := newRepoClients(t)
+	a := NewApp(config.Config{Owner: "octo", Repo: "app", Watch: []string{"octo/lib"}}, primary, nil, lib)
+	if c := a.clientFor(&model.Run{}); c != primary {
+		t.Errorf("clientFor(run without repository) = %s", c.RepoNWO())
+	}
+	if c := a.clientFor(&model.Run{Repository: model.Repository{FullName: "Octo/Lib"}}); c != lib {
+		t.Errorf("clientFor(Octo/Lib) = %s", c.RepoNWO())
+	}
+}
@@ -1,6 +1,7 @@
 package runnersview
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/altinukshini/gha-tui/internal/api"
 	"github.com/altinukshini/gha-tui/internal/model"
 	"github.com/altinukshini/gha-tui/internal/ui"
 )
@@ -17,12 +19,12 @@ type runnerItem struct {
 }
 
 func (r runnerItem) Title() string {
-	// Status icon: green ● for online, red ● for offline
+	// Status icon: green ● for online, red ○ for offline
 	var status string
 	if r.runner.Status == "online" {
 		status = ui.StyleSuccess.Render("●")
 	} else {
-		status = ui.StyleFailure.Render("●")
+		status = ui.StyleFailure.Render("○")
 	}
 
 	// Busy indicator
@@ -60,13 +62,13 @@ func (r runnerItem) FilterValue() string {
 
 // Model is the runners list view.
 type Model struct {
-	list      list.Model
-	runners   []model.Runner
-	width     int
-	height    int
-	loading   bool
-	err       error
-	orgFailed bool // org-level runners could not be fetched
+	list    list.Model
+	runners []model.Runner
+	width   int
+	height  int
+	loading bool
+	err     error
+	orgErr  error // org-level runners could not be fetched
 }
 
 // New creates a new runners view.
@@ -74,13 +76,15 @@ func New() Model {
 	delegate := list.NewDefaultDelegate()
 	delegate.SetHeight(2)
 	delegate.SetSpacing(0)
+	delegate.Styles = ui.ItemStyles()
 
 	l := list.New(nil, delegate, 0, 0)
+	l.KeyMap = ui.ListKeyMap()
+	l.Styles = ui.ListStyles()
 	l.SetShowTitle(false)
 	l.SetShowHelp(false)
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
-	l.KeyMap.Filter = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter"))
 	l.DisableQuitKeybindings()
 
 	return Model{list: l, loading: true}
@@ -99,7 +103,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 		m.runners = msg.Runners
-		m.orgFailed = msg.OrgFailed
+		m.orgErr = msg.OrgErr
 		items := make([]list.Item, len(msg.Runners))
 		for i, r := range msg.Runners {
 			items[i] = runnerItem{runner: r}
@@ -125,13 +129,12 @@ func (m Model) View() string {
 		return "\n  Loading runners..."
 	}
 	if m.err != nil {
-		return fmt.Sprintf("\n  Error: %v", m.err)
+		return ui.RenderError(m.err)
 	}
 	if len(m.runners) == 0 {
 		msg := "\n  No runners found.\n\n  GitHub-hosted runners are not listed by the API."
-		if m.orgFailed {
-			msg += "\n\n  " + ui.StyleWarning.Render("Org-shared runners require admin:org scope.") +
-				"\n  Run: gh auth refresh -s admin:org"
+		if m.orgErr != nil {
+			msg += "\n\n  " + orgRunnersHint(m.orgErr)
 		}
 		return msg
 	}
@@ -148,14 +151,27 @@ func (m Model) View() string {
 	}
 	header := fmt.Sprintf("  %d runners | %d online | %d busy | r: refresh  f: filter",
 		len(m.runners), online, busy)
-	if m.orgFailed {
+	if errors.Is(m.orgErr, api.ErrForbidden) {
 		header += "  |  " + ui.StyleWarning.Render("org runners: needs admin:org scope")
+	} else if m.orgErr != nil {
+		header += "  |  " + ui.StyleWarning.Render("org runners unavailable")
 	}
 	header = ui.StyleMuted.Render(header)
 
 	return header + "\n" + m.list.View()
 }
 
+// orgRunnersHint explains why org-shared runners are missing. A 403 from the
+// org endpoint almost always means the token lacks the admin:org scope.
+func orgRunnersHint(err error) string {
+	if errors.Is(err, api.ErrForbidden) {
+		return ui.StyleWarning.Render("Org-shared runners require admin:org scope.") +
+			"\n  Run: gh auth refresh -s admin:org"
+	}
+	return ui.StyleWarning.Render("Org-shared runners could not be loaded.") +
+		"\n  " + ui.FormatError(err)
+}
+
 // IsFiltering returns true when the filter input is active.
 func (m Model) IsFiltering() bool {
 	return m.list.FilterState() == list.Filtering
@@ -169,7 +185,7 @@ func (m Model) HasActiveFilter() bool {
 // ShortHelp returns key bindings for this view.
 func (m Model) ShortHelp() []key.Binding {
 	return []key.Binding{
-		key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
-		key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter")),
+		ui.Keys.Refresh,
+		ui.Keys.Filter,
 	}
 }
@@ -3,12 +3,12 @@ package runs
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 
 	"github.com/altinukshini/gha-tui/internal/model"
 	"github.com/altinukshini/gha-tui/internal/ui"
@@ -21,11 +21,34 @@ type NeedNextPageMsg struct{}
 
 type runDelegate struct {
 	selected *map[int64]bool // pointer to the model's selection map
+	marks    *compareMarks
+	repos    *repoColumn
 }
 
-func (d runDelegate) Height() int                                      { return 2 }
-func (d runDelegate) Spacing() int                                     { return 0 }
-func (d runDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd         { return nil }
+// repoColumn is the width of the repo column, zero while a single repo is
+// watched and the column is hidden.
+type repoColumn struct {
+	width int
+}
+
+// compareMarks holds the runs marked for comparison, oldest mark first.
+// It is shared by pointer so the marks survive page loads.
+type compareMarks struct {
+	runs []model.Run
+}
+
+func (c *compareMarks) has(id int64) bool {
+	for _, r := range c.runs {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (d runDelegate) Height() int                             { return 2 }
+func (d runDelegate) Spacing() int                            { return 0 }
+func (d runDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 
 func (d runDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
 	ri, ok := item.(runItem)
@@ -46,17 +69,26 @@ func (d runDelegate) Render(w io.Writer, m list.Model, index int, item list.Item
 	if sel[ri.run.ID] {
 		mark = ui.StyleWarning.Render("●")
 	}
+	if d.marks.has(ri.run.ID) {
+		mark = ui.StyleInfo.Render("◆")
+	}
 
 	ago := ui.StyleMuted.Render(formatDuration(time.Since(ri.run.CreatedAt).Truncate(time.Minute)) + " ago")
 	branch := ui.StyleInfo.Render(ri.run.HeadBranch)
 	wfName := ui.StyleMuted.Render(ri.run.Name)
 
-	line1 := fmt.Sprintf(" %s%s #%d %s  %s  %s", mark, icon, ri.run.RunNumber, branch, ago, wfName)
+	repo := ""
+	if d.repos.width > 0 {
+		name := ri.run.Repository.FullName
+		repo = ui.StyleWarning.Render(name) + strings.Repeat(" ", max(d.repos.width-len(name), 0)) + " "
+	}
+
+	line1 := fmt.Sprintf(" %s%s %s#%d %s  %s  %s", mark, icon, repo, ri.run.RunNumber, branch, ago, wfName)
 	line2 := fmt.Sprintf("    %s", ri.run.DisplayTitle)
 
 	isFocused := index == m.Index()
 	if isFocused {
-		hl := lipgloss.NewStyle().Background(lipgloss.Color("#1F2937")).Width(m.Width())
+		hl := ui.StyleSelected.Width(m.Width())
 		line1 = hl.Render(line1)
 		line2 = hl.Render(line2)
 	}
@@ -71,7 +103,7 @@ type runItem struct {
 }
 
 func (r runItem) FilterValue() string {
-	return r.run.Name + " " + r.run.DisplayTitle + " " + r.run.HeadBranch + " " + r.run.Actor.Login
+	return r.run.Repository.FullName + " " + r.run.Name + " " + r.run.DisplayTitle + " " + r.run.HeadBranch + " " + r.run.Actor.Login
 }
 
 func formatDuration(d time.Duration) string {
@@ -93,6 +125,8 @@ type Model struct {
 	list     list.Model
 	runs     []model.Run
 	selected map[int64]bool
+	marks    *compareMarks
+	repos    *repoColumn
 	width    int
 	height   int
 	loading  bool
@@ -101,27 +135,26 @@ type Model struct {
 
 func New() Model {
 	sel := make(map[int64]bool)
-	delegate := runDelegate{selected: &sel}
+	marks := &compareMarks{}
+	repos := &repoColumn{}
+	delegate := runDelegate{selected: &sel, marks: marks, repos: repos}
 
 	l := list.New(nil, delegate, 0, 0)
+	l.KeyMap = ui.ListKeyMap()
+	l.Styles = ui.ListStyles()
 	l.SetShowTitle(false)
 	l.SetShowFilter(true)
 	l.SetShowHelp(false)
 	l.SetShowStatusBar(true)
 	l.SetShowPagination(false)
 	l.SetFilteringEnabled(true)
-	l.KeyMap.Filter = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter"))
-	// Rebind internal page navigation to pgup/pgdown only.
-	// The app uses l/h/left/right for API-level page navigation;
-	// without this override the same keys also trigger the list's
-	// built-in NextPage/PrevPage, causing double-handling.
-	l.KeyMap.NextPage = key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdn", "next page"))
-	l.KeyMap.PrevPage = key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "prev page"))
 	l.DisableQuitKeybindings()
 
 	return Model{
 		list:     l,
 		selected: sel,
+		marks:    marks,
+		repos:    repos,
 		loading:  true,
 	}
 }
@@ -152,6 +185,48 @@ func (m *Model) ClearSelection() {
 	}
 }
 
+// ToggleCompare marks the focused run for comparison, or unmarks it. At
+// most two runs are marked; marking a third drops the oldest mark. It
+// returns the number of marked runs.
+func (m *Model) ToggleCompare() int {
+	item, ok := m.list.SelectedItem().(runItem)
+	if !ok {
+		return len(m.marks.runs)
+	}
+	for i, r := range m.marks.runs {
+		if r.ID == item.run.ID {
+			m.marks.runs = append(m.marks.runs[:i], m.marks.runs[i+1:]...)
+			return len(m.marks.runs)
+		}
+	}
+	m.marks.runs = append(m.marks.runs, item.run)
+	if len(m.marks.runs) > 2 {
+		m.marks.runs = m.marks.runs[1:]
+	}
+	return len(m.marks.runs)
+}
+
+// CompareRuns returns the runs marked for comparison, oldest mark first.
+func (m Model) CompareRuns() []model.Run {
+	return append([]model.Run(nil), m.marks.runs...)
+}
+
+func (m *Model) ClearCompare() {
+	m.marks.runs = nil
+}
+
+// SetRepos names the watched repositories. With more than one, each run
+// shows its repository in a column wide enough for the longest name.
+func (m *Model) SetRepos(repos []string) {
+	m.repos.width = 0
+	if len(repos) < 2 {
+		return
+	}
+	for _, r := range repos {
+		m.repos.width = max(m.repos.width, len(r))
+	}
+}
+
 // RunByID returns a pointer to the run with the given ID, or nil.
 func (m Model) RunByID(id int64) *model.Run {
 	for i := range m.runs {
@@ -224,20 +299,20 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		// Ensure the filter key binding is enabled whenever items exist.
 		// The list's updateKeybindings can disable it (e.g. after SetSize
 		// with zero items); re-enabling here guarantees 'f' always works.
-		if msg.String() == "f" && !m.IsFiltering() && len(m.list.Items()) > 0 {
+		if key.Matches(msg, ui.Keys.Filter) && !m.IsFiltering() && len(m.list.Items()) > 0 {
 			m.list.KeyMap.Filter.SetEnabled(true)
 		}
 
 		// Auto-advance: if at bottom and pressing down, signal for next page
 		if !m.IsFiltering() {
-			isDown := msg.String() == "j" || msg.Type == tea.KeyDown
+			isDown := key.Matches(msg, ui.Keys.Down)
 			if isDown && len(m.list.Items()) > 0 && m.list.Index() >= len(m.list.Items())-1 {
 				return m, func() tea.Msg { return NeedNextPageMsg{} }
 			}
 		}
 
 		// Toggle selection with space (stay on current row)
-		if msg.String() == " " && !m.IsFiltering() {
+		if key.Matches(msg, ui.Keys.Select) && !m.IsFiltering() {
 			if item, ok := m.list.SelectedItem().(runItem); ok {
 				id := item.run.ID
 				if m.selected[id] {
@@ -265,7 +340,7 @@ func (m Model) View() string {
 		return "\n  Loading runs..."
 	}
 	if m.err != nil {
-		return fmt.Sprintf("\n  Error: %v", m.err)
+		return ui.RenderError(m.err)
 	}
 	return m.list.View()
 }
@@ -170,3 +170,35 @@ func TestFilterEscCancels(t *testing.T) {
 		t.Error("should NOT be filtering after pressing esc")
 	}
 }
+
+func TestToggleCompareKeepsTwoMarks(t *testing.T) {
+	m := New()
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+	runs := []model.Run{{ID: 1, RunNumber: 1}, {ID: 2, RunNumber: 2}, {ID: 3, RunNumber: 3}}
+	m, _ = m.Update(ui.RunsLoadedMsg{Runs: runs, TotalCount: 3})
+
+	for i := range runs {
+		m.list.Select(i)
+		m.ToggleCompare()
+	}
+	got := m.CompareRuns()
+	if len(got) != 2 || got[0].ID != 2 || got[1].ID != 3 {
+		t.Fatalf("CompareRuns() = %v, want runs 2 and 3", got)
+	}
+
+	// Marks survive loading another page.
+	m, _ = m.Update(ui.RunsPageMsg{Runs: runs[:1]})
+	if len(m.CompareRuns()) != 2 {
+		t.Fatalf("marks lost on page load")
+	}
+
+	m.list.Select(0)
+	m.ToggleCompare()
+	if n := len(m.CompareRuns()); n != 2 {
+		t.Fatalf("marking a third run kept %d marks, want 2", n)
+	}
+	m.ClearCompare()
+	if n := len(m.CompareRuns()); n != 0 {
+		t.Fatalf("ClearCompare() left %d marks", n)
+	}
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"context"
+	"errors"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// requestScope groups in-flight requests that become stale together, such as
+// everything fetched for the selected run. Starting a new scope cancels the
+// previous one. App is copied on every Update, so scopes are held by pointer
+// and shared between copies; all methods run on the Update goroutine.
+type requestScope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newRequestScope() *requestScope {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &requestScope{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the context for requests started in the current scope.
+func (s *requestScope) Context() context.Context {
+	if s == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// Renew cancels the requests of the current scope and starts a new one.
+func (s *requestScope) Renew() context.Context {
+	if s == nil {
+		return context.Background()
+	}
+	s.cancel()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s.ctx
+}
+
+// Cancel cancels the requests of the current scope. Later requests get a
+// fresh context.
+func (s *requestScope) Cancel() {
+	s.Renew()
+}
+
+// isCancelled reports whether msg is the result of a request cancelled by a
+// scope. Such results are stale and dropped before reaching the views.
+func isCancelled(msg tea.Msg) bool {
+	var err error
+	switch msg := msg.(type) {
+	case ui.JobsLoadedMsg:
+		err = msg.Err
+	case ui.LogsLoadedMsg:
+		err = msg.Err
+	case ui.JobLogLoadedMsg:
+		err = msg.Err
+	case ui.JobTailStatusMsg:
+		err = msg.Err
+	case ui.RunLoadedMsg:
+		err = msg.Err
+	case ui.RunArtifactsLoadedMsg:
+		err = msg.Err
+	case ui.JobAnnotationsLoadedMsg:
+		err = msg.Err
+	case ui.LogDiffLoadedMsg:
+		err = msg.Err
+	case ui.DashboardDataMsg:
+		err = msg.Err
+	}
+	return errors.Is(err, context.Canceled)
+}
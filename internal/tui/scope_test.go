@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+func TestRequestScopeRenew(t *testing.T) {
+	s := newRequestScope()
+	first := s.Context()
+
+	second := s.Renew()
+	if !errors.Is(first.Err(), context.Canceled) {
+		t.Errorf("first context err = %v, want canceled", first.Err())
+	}
+	if second.Err() != nil {
+		t.Errorf("renewed context err = %v, want nil", second.Err())
+	}
+	if s.Context() != second {
+		t.Error("Context() should return the renewed context")
+	}
+
+	s.Cancel()
+	if !errors.Is(second.Err(), context.Canceled) {
+		t.Errorf("second context err = %v, want canceled", second.Err())
+	}
+	if s.Context().Err() != nil {
+		t.Error("a cancelled scope should hand out a fresh context")
+	}
+
+	var nilScope *requestScope
+	if nilScope.Context() == nil {
+		t.Error("nil scope should fall back to context.Background")
+	}
+}
+
+func TestIsCancelled(t *testing.T) {
+	wrapped := fmt.Errorf("list jobs for run 1: %w", context.Canceled)
+	tests := []struct {
+		name string
+		msg  any
+		want bool
+	}{
+		{"cancelled jobs", ui.JobsLoadedMsg{RunID: 1, Err: wrapped}, true},
+		{"cancelled dashboard", ui.DashboardDataMsg{Err: context.Canceled}, true},
+		{"failed jobs", ui.JobsLoadedMsg{RunID: 1, Err: errors.New("HTTP 502")}, false},
+		{"loaded jobs", ui.JobsLoadedMsg{RunID: 1}, false},
+		{"unscoped message", ui.RunsLoadedMsg{Err: context.Canceled}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCancelled(tt.msg); got != tt.want {
+				t.Errorf("isCancelled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
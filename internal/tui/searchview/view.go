@@ -38,6 +38,7 @@ func New() Model {
 	ti := textinput.New()
 	ti.Placeholder = "Search pattern (/ for regex)"
 	ti.CharLimit = 256
+	ui.StyleTextInput(&ti)
 
 	return Model{
 		input: ti,
@@ -104,13 +105,13 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if m.mode == ModeInput {
-			switch msg.String() {
-			case "enter":
+			switch {
+			case ui.MatchesWhileTyping(msg, ui.Keys.Enter):
 				if m.input.Value() != "" {
 					m.loading = true
 					return m, nil // parent handles dispatching search
 				}
-			case "esc":
+			case ui.MatchesWhileTyping(msg, ui.Keys.Back):
 				m.Deactivate()
 				return m, nil
 			}
@@ -148,6 +149,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.input.Width = msg.Width - 4
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width, msg.Height-4)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
 			m.ready = true
 		} else {
 			m.viewport.Width = msg.Width
@@ -169,13 +171,15 @@ func (m Model) renderResults() string {
 	}
 
 	bold := lipgloss.NewStyle().Bold(true)
-	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-	highlight := lipgloss.NewStyle().Background(lipgloss.Color("#1F2937"))
+	muted := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	highlight := ui.StyleSelected
 
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("  %d matches across %d jobs\n",
 		m.results.TotalCount, len(m.results.JobCounts)))
-	b.WriteString(muted.Render("  enter:view log  j/k:navigate  /:new search  esc:close") + "\n\n")
+	k := ui.Keys
+	b.WriteString(muted.Render("  "+ui.Hints(ui.Hint("view log", k.Enter), ui.Hint("navigate", k.Down, k.Up),
+		ui.Hint("new search", k.Search), ui.Hint("close", k.Back))) + "\n\n")
 
 	for name, count := range m.results.JobCounts {
 		b.WriteString(fmt.Sprintf("  %s: %d matches\n", name, count))
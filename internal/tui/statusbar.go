@@ -19,7 +19,7 @@ func RenderStatusBar(status, hints string, width int) string {
 	padding := lipgloss.NewStyle().Width(gap).Render("")
 
 	return lipgloss.NewStyle().
-		Background(lipgloss.Color("#111827")).
+		Background(ui.ColorBar).
 		Width(width).
 		Render(left + padding + help)
 }
@@ -0,0 +1,94 @@
+// Package timeline draws a Gantt chart of a run's jobs and steps on a shared
+// time axis, with queue time and the critical path highlighted.
+package timeline
+
+import (
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/tui/jobgraph"
+)
+
+// handoff is the slack allowed between an upstream job completing and its
+// dependent being queued.
+const handoff = 5 * time.Second
+
+// jobEnd is when a job finished, or now while it runs.
+func jobEnd(j model.Job, now time.Time) time.Time {
+	if !j.CompletedAt.IsZero() {
+		return j.CompletedAt
+	}
+	return now
+}
+
+// jobQueued is when a job entered the queue, falling back to its start.
+func jobQueued(j model.Job) time.Time {
+	if !j.CreatedAt.IsZero() {
+		return j.CreatedAt
+	}
+	return j.StartedAt
+}
+
+// CriticalPath returns the jobs that decided the run's duration: starting
+// from the job that finished last, it repeatedly steps to the upstream job
+// that finished last before the current one was queued. With a job graph
+// only needs: dependencies are considered upstream; without one, any job
+// that finished in time is.
+func CriticalPath(jobs []model.Job, g *jobgraph.Graph, now time.Time) map[int64]bool {
+	var ran []model.Job
+	for _, j := range jobs {
+		if !j.StartedAt.IsZero() {
+			ran = append(ran, j)
+		}
+	}
+	if len(ran) == 0 {
+		return nil
+	}
+
+	upstream := func(j model.Job) []model.Job { return ran }
+	if g != nil {
+		nodeOf := make(map[int64]*jobgraph.Node)
+		for _, n := range g.Nodes {
+			for _, nj := range n.Jobs {
+				nodeOf[nj.ID] = n
+			}
+		}
+		upstream = func(j model.Job) []model.Job {
+			n, ok := nodeOf[j.ID]
+			if !ok {
+				return ran
+			}
+			var deps []model.Job
+			for _, need := range n.Needs {
+				deps = append(deps, need.Jobs...)
+			}
+			return deps
+		}
+	}
+
+	cur := ran[0]
+	for _, j := range ran[1:] {
+		if jobEnd(j, now).After(jobEnd(cur, now)) {
+			cur = j
+		}
+	}
+	path := map[int64]bool{cur.ID: true}
+	for {
+		var prev *model.Job
+		deadline := jobQueued(cur).Add(handoff)
+		for _, u := range upstream(cur) {
+			if path[u.ID] || u.StartedAt.IsZero() || u.CompletedAt.IsZero() || u.CompletedAt.After(deadline) {
+				continue
+			}
+			if prev == nil || u.CompletedAt.After(prev.CompletedAt) {
+				u := u
+				prev = &u
+			}
+		}
+		if prev == nil {
+			return path
+		}
+		path[prev.ID] = true
+		cur = *prev
+	}
+}
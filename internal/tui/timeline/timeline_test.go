@@ -0,0 +1,126 @@
+package timeline
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/tui/jobgraph"
+)
+
+var t0 = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func at(min int) time.Time { return t0.Add(time.Duration(min) * time.Minute) }
+
+func job(id int64, name string, queued, started, completed int) model.Job {
+	j := model.Job{ID: id, Name: name, Status: model.RunStatusCompleted, Conclusion: model.ConclusionSuccess}
+	j.CreatedAt, j.StartedAt, j.CompletedAt = at(queued), at(started), at(completed)
+	return j
+}
+
+func ids(path map[int64]bool) []int64 {
+	var out []int64
+	for id := range path {
+		out = append(out, id)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func TestCriticalPath(t *testing.T) {
+	// build -> test (slow) -> deploy, with lint finishing late but not needed
+	// by deploy.
+	jobs := []model.Job{
+		job(1, "build", 0, 1, 5),
+		job(2, "test", 5, 5, 20),
+		job(3, "lint", 5, 6, 19),
+		job(4, "deploy", 20, 22, 25),
+	}
+	specs := []model.WorkflowJob{
+		{ID: "build"},
+		{ID: "test", Needs: []string{"build"}},
+		{ID: "lint", Needs: []string{"build"}},
+		{ID: "deploy", Needs: []string{"test"}},
+	}
+
+	tests := []struct {
+		name string
+		jobs []model.Job
+		g    *jobgraph.Graph
+		want []int64
+	}{
+		{"needs", jobs, jobgraph.Build(specs, jobs), []int64{1, 2, 4}},
+		{"timing only", jobs, nil, []int64{1, 2, 4}},
+		{
+			"needs rule out a later finisher",
+			append(jobs[:3:3], job(4, "deploy", 19, 22, 25)),
+			jobgraph.Build([]model.WorkflowJob{
+				{ID: "build"},
+				{ID: "test", Needs: []string{"build"}},
+				{ID: "lint", Needs: []string{"build"}},
+				{ID: "deploy", Needs: []string{"lint"}},
+			}, jobs),
+			[]int64{1, 3, 4},
+		},
+		{
+			"never started jobs are ignored",
+			[]model.Job{job(1, "build", 0, 1, 5), {ID: 2, Name: "skipped", Status: model.RunStatusCompleted}},
+			nil,
+			[]int64{1},
+		},
+		{"nothing ran", []model.Job{{ID: 1, Name: "queued", Status: model.RunStatusQueued}}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(CriticalPath(tt.jobs, tt.g, at(30)))
+			if len(got) != len(tt.want) {
+				t.Fatalf("CriticalPath() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("CriticalPath() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSpanBar(t *testing.T) {
+	s := span{start: at(0), end: at(10), width: 10}
+	tests := []struct {
+		name                      string
+		queued, started, finished time.Time
+		want                      string
+	}{
+		{"queue then run", at(2), at(4), at(7), "  ░░███   "},
+		{"no queue", at(0), at(0), at(10), "██████████"},
+		{"short run stays visible", at(5), at(5), at(5).Add(time.Second), "     █    "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.bar(tt.queued, tt.started, tt.finished, lipgloss.NewStyle())
+			if got != tt.want {
+				t.Errorf("bar() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarksCriticalPathAndSteps(t *testing.T) {
+	build := job(1, "build", 0, 1, 5)
+	build.Steps = []model.Step{{Name: "Compile", Status: model.RunStatusCompleted, Conclusion: model.ConclusionSuccess, StartedAt: at(1), CompletedAt: at(4)}}
+	m := Model{width: 100, now: func() time.Time { return at(30) }, expanded: map[int64]bool{1: true}}
+	m.jobs = []model.Job{build, job(2, "test", 5, 7, 20)}
+
+	lines, _ := m.render()
+	out := strings.Join(lines, "\n")
+	for _, want := range []string{"build *", "test *", "Compile", "13m00s", "+2m00s queued", "inferred from timing"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render() missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package timeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+	"github.com/altinukshini/gha-tui/internal/tui/jobgraph"
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+const (
+	labelWidth    = 28
+	durationWidth = 18
+)
+
+// Model is the full-screen timeline overlay for one run. Jobs are listed by
+// start time; a job can be expanded into its steps.
+type Model struct {
+	viewport viewport.Model
+	active   bool
+	loading  bool
+	err      error
+	run      model.Run
+	jobs     []model.Job
+	specs    []model.WorkflowJob
+	expanded map[int64]bool
+	cursor   int
+	width    int
+	height   int
+	ready    bool
+	now      func() time.Time // for tests; nil means time.Now
+}
+
+func New() Model {
+	return Model{}
+}
+
+// Open shows the overlay for run while its jobs load.
+func (m *Model) Open(run model.Run) {
+	m.active = true
+	m.loading = true
+	m.err = nil
+	m.run = run
+	m.jobs = nil
+	m.specs = nil
+	m.expanded = make(map[int64]bool)
+	m.cursor = 0
+	m.refresh()
+}
+
+// SetData supplies the run's jobs and, when the workflow file could be
+// read, its job declarations, which make the critical path exact.
+func (m *Model) SetData(jobs []model.Job, specs []model.WorkflowJob) {
+	m.loading = false
+	m.specs = specs
+	m.setJobs(jobs)
+}
+
+// SetJobs updates the jobs, e.g. from the auto-refresh of a running run.
+func (m *Model) SetJobs(jobs []model.Job) {
+	if m.loading || m.err != nil {
+		return
+	}
+	m.setJobs(jobs)
+}
+
+func (m *Model) setJobs(jobs []model.Job) {
+	sorted := append([]model.Job(nil), jobs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].StartedAt, sorted[j].StartedAt
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		return a.Before(b)
+	})
+	m.jobs = sorted
+	if m.cursor >= len(m.jobs) {
+		m.cursor = max(len(m.jobs)-1, 0)
+	}
+	m.refresh()
+}
+
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = err
+}
+
+func (m Model) RunID() int64   { return m.run.ID }
+func (m Model) IsActive() bool { return m.active }
+func (m Model) Init() tea.Cmd  { return nil }
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, ui.Keys.Close):
+			m.active = false
+			return m, nil
+		case key.Matches(msg, ui.Keys.Down):
+			if m.cursor < len(m.jobs)-1 {
+				m.cursor++
+				m.refresh()
+			}
+			return m, nil
+		case key.Matches(msg, ui.Keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+				m.refresh()
+			}
+			return m, nil
+		case key.Matches(msg, ui.Keys.Enter, ui.Keys.Select, ui.Keys.Right, ui.Keys.Left):
+			if m.cursor < len(m.jobs) {
+				id := m.jobs[m.cursor].ID
+				m.expanded[id] = !m.expanded[id]
+				m.refresh()
+			}
+			return m, nil
+		case key.Matches(msg, ui.Keys.ExpandAll):
+			expand := len(m.expanded) < len(m.jobs)
+			m.expanded = make(map[int64]bool)
+			if expand {
+				for _, j := range m.jobs {
+					m.expanded[j.ID] = true
+				}
+			}
+			m.refresh()
+			return m, nil
+		case key.Matches(msg, ui.Keys.Top):
+			m.cursor = 0
+			m.refresh()
+			return m, nil
+		case key.Matches(msg, ui.Keys.Bottom):
+			m.cursor = max(len(m.jobs)-1, 0)
+			m.refresh()
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-3)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 3
+		}
+		m.refresh()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// refresh re-renders the chart and keeps the cursor row in view.
+func (m *Model) refresh() {
+	if !m.ready {
+		return
+	}
+	lines, cursorLine := m.render()
+	m.viewport.SetContent(strings.Join(lines, "\n"))
+	if cursorLine < m.viewport.YOffset {
+		m.viewport.SetYOffset(cursorLine)
+	} else if bottom := m.viewport.YOffset + m.viewport.Height - 1; cursorLine > bottom {
+		m.viewport.SetYOffset(cursorLine - m.viewport.Height + 1)
+	}
+}
+
+// span is the time axis shared by all bars.
+type span struct {
+	start, end time.Time
+	width      int
+}
+
+func (s span) col(t time.Time) int {
+	total := s.end.Sub(s.start)
+	if total <= 0 {
+		return 0
+	}
+	c := int(float64(t.Sub(s.start)) / float64(total) * float64(s.width))
+	return min(max(c, 0), s.width)
+}
+
+// bar draws queue time as ░ and run time as █ in a line of s.width cells.
+func (s span) bar(queued, started, ended time.Time, runStyle lipgloss.Style) string {
+	q, st, en := s.col(queued), s.col(started), s.col(ended)
+	if en == st && !ended.Equal(started) && en < s.width {
+		en++ // keep short runs visible
+	}
+	return strings.Repeat(" ", q) +
+		ui.StyleWarning.Render(strings.Repeat("░", st-q)) +
+		runStyle.Render(strings.Repeat("█", en-st)) +
+		strings.Repeat(" ", s.width-en)
+}
+
+// render returns the chart lines and the line index of the cursor row.
+func (m Model) render() ([]string, int) {
+	if len(m.jobs) == 0 {
+		return []string{"  No jobs started yet"}, 0
+	}
+	now := time.Now()
+	if m.now != nil {
+		now = m.now()
+	}
+
+	var started []model.Job
+	for _, j := range m.jobs {
+		if !j.StartedAt.IsZero() {
+			started = append(started, j)
+		}
+	}
+	s := span{width: max(m.width-labelWidth-durationWidth-4, 10)}
+	for _, j := range started {
+		if q := jobQueued(j); s.start.IsZero() || q.Before(s.start) {
+			s.start = q
+		}
+		if e := jobEnd(j, now); e.After(s.end) {
+			s.end = e
+		}
+	}
+
+	var g *jobgraph.Graph
+	if len(m.specs) > 0 {
+		g = jobgraph.Build(m.specs, m.jobs)
+	}
+	critical := CriticalPath(m.jobs, g, now)
+
+	lines := []string{m.axis(s)}
+	cursorLine := 0
+	pathStyle := lipgloss.NewStyle().Bold(true).Foreground(ui.ColorPrimary)
+	for i, j := range m.jobs {
+		if i == m.cursor {
+			cursorLine = len(lines)
+		}
+		marker := "  "
+		if i == m.cursor {
+			marker = pathStyle.Render("> ")
+		}
+		label := truncate(j.Name, labelWidth-2)
+		if critical[j.ID] {
+			label = pathStyle.Render(fmt.Sprintf("%-*s", labelWidth-2, label+" *"))
+		} else {
+			label = fmt.Sprintf("%-*s", labelWidth-2, label)
+		}
+		toggle := "+"
+		if m.expanded[j.ID] {
+			toggle = "-"
+		}
+		if len(j.Steps) == 0 {
+			toggle = " "
+		}
+
+		var bar, dur string
+		if j.StartedAt.IsZero() {
+			bar = strings.Repeat(" ", s.width)
+			dur = ui.StyleMuted.Render(string(j.Status))
+		} else {
+			end := jobEnd(j, now)
+			bar = s.bar(jobQueued(j), j.StartedAt, end, statusStyle(jobStatus(j)))
+			dur = formatDuration(end.Sub(j.StartedAt))
+			if q := j.QueueTime(); q >= time.Second {
+				dur += ui.StyleWarning.Render(" +" + formatDuration(q) + " queued")
+			}
+		}
+		lines = append(lines, fmt.Sprintf("%s%s%s %s %s", marker, toggle, label, bar, dur))
+
+		if !m.expanded[j.ID] {
+			continue
+		}
+		for _, st := range j.Steps {
+			name := fmt.Sprintf("%-*s", labelWidth-4, truncate(st.Name, labelWidth-4))
+			stepBar := strings.Repeat(" ", s.width)
+			stepDur := ""
+			if !st.StartedAt.IsZero() {
+				end := st.CompletedAt
+				if end.IsZero() {
+					end = now
+				}
+				stepBar = s.bar(st.StartedAt, st.StartedAt, end, statusStyle(stepStatus(st)))
+				stepDur = formatDuration(end.Sub(st.StartedAt))
+			}
+			lines = append(lines, fmt.Sprintf("     %s %s %s", ui.StyleMuted.Render(name), stepBar, ui.StyleMuted.Render(stepDur)))
+		}
+	}
+
+	lines = append(lines, "", ui.StyleMuted.Render(fmt.Sprintf(
+		"  █ running  %s waiting for a runner  * critical path (%s)",
+		ui.StyleWarning.Render("░"), criticalSource(g))))
+	return lines, cursorLine
+}
+
+// axis renders tick labels roughly every 12 columns across the span.
+func (m Model) axis(s span) string {
+	total := s.end.Sub(s.start)
+	cells := []rune(strings.Repeat(" ", s.width+10))
+	ticks := max(s.width/12, 1)
+	for i := 0; i <= ticks; i++ {
+		col := i * s.width / ticks
+		label := "|" + formatDuration(total*time.Duration(i)/time.Duration(ticks))
+		if col+len(label) > len(cells) {
+			break
+		}
+		copy(cells[col:], []rune(label))
+	}
+	return strings.Repeat(" ", labelWidth+2) + ui.StyleMuted.Render(strings.TrimRight(string(cells), " "))
+}
+
+func criticalSource(g *jobgraph.Graph) string {
+	if g != nil {
+		return "from needs:"
+	}
+	return "inferred from timing"
+}
+
+func jobStatus(j model.Job) string {
+	if j.Status != model.RunStatusCompleted {
+		return string(j.Status)
+	}
+	return string(j.Conclusion)
+}
+
+func stepStatus(s model.Step) string {
+	if s.Status != model.RunStatusCompleted {
+		return string(s.Status)
+	}
+	return string(s.Conclusion)
+}
+
+func statusStyle(status string) lipgloss.Style {
+	if status == string(model.RunStatusInProgress) {
+		return ui.StyleInfo
+	}
+	return ui.ConclusionStyle(status)
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+func (m Model) View() string {
+	header := fmt.Sprintf(" Timeline  #%d %s", m.run.RunNumber, m.run.DisplayTitle)
+	k := ui.Keys
+	hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render("  " + ui.Hints(ui.Hint("job", k.Down, k.Up),
+		ui.Hint("steps", k.Enter), ui.Hint("expand all", k.ExpandAll), ui.Hint("back", k.Close)))
+	headerLine := lipgloss.NewStyle().Bold(true).
+		Foreground(ui.ColorText).
+		Render(header) + hints
+
+	switch {
+	case m.err != nil:
+		return headerLine + "\n" + ui.RenderError(m.err)
+	case m.loading:
+		return headerLine + "\n\n  Loading jobs..."
+	}
+	return headerLine + "\n\n" + m.viewport.View()
+}
@@ -72,13 +72,15 @@ func newModel(showStats bool) Model {
 	delegate := list.NewDefaultDelegate()
 	delegate.SetHeight(1)
 	delegate.SetSpacing(0)
+	delegate.Styles = ui.ItemStyles()
 
 	l := list.New(nil, delegate, 0, 0)
+	l.KeyMap = ui.ListKeyMap()
+	l.Styles = ui.ListStyles()
 	l.SetShowTitle(false)
 	l.SetShowHelp(false)
 	l.SetShowStatusBar(true)
 	l.SetFilteringEnabled(true)
-	l.KeyMap.Filter = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter"))
 	l.DisableQuitKeybindings()
 
 	return Model{list: l, showStats: showStats, loading: true}
@@ -146,7 +148,7 @@ func (m Model) View() string {
 		return "\n  Loading workflows..."
 	}
 	if m.err != nil {
-		return fmt.Sprintf("\n  Error: %v", m.err)
+		return ui.RenderError(m.err)
 	}
 	return m.list.View()
 }
@@ -161,8 +163,8 @@ func (m Model) HasActiveFilter() bool {
 
 func (m Model) ShortHelp() []key.Binding {
 	return []key.Binding{
-		key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "enable")),
-		key.NewBinding(key.WithKeys("D"), key.WithHelp("D", "disable")),
-		key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "bulk delete runs")),
+		ui.Keys.Enable,
+		ui.Keys.Disable,
+		ui.Keys.DeleteAll,
 	}
 }
@@ -0,0 +1,132 @@
+package yamlview
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// highlightStyles are the styles of the parts of a line.
+type highlightStyles struct {
+	key, str, expr, comment, punct lipgloss.Style
+}
+
+// currentStyles builds the styles from the active theme. They cannot be
+// built once at package initialisation, which runs before the theme is
+// applied.
+func currentStyles() highlightStyles {
+	return highlightStyles{
+		key:     lipgloss.NewStyle().Foreground(ui.ColorPrimary).Bold(true),
+		str:     lipgloss.NewStyle().Foreground(ui.ColorSuccess),
+		expr:    lipgloss.NewStyle().Foreground(ui.ColorInfo),
+		comment: lipgloss.NewStyle().Foreground(ui.ColorMuted).Italic(true),
+		punct:   lipgloss.NewStyle().Foreground(ui.ColorWarning),
+	}
+}
+
+// HighlightLine colours one line of a workflow file: mapping keys, list
+// dashes, quoted strings, ${{ }} expressions and comments. It works line by
+// line, so block scalars (run: |) are highlighted like plain values, which
+// suits workflow files well enough without a full YAML parser.
+func HighlightLine(line string) string {
+	st := currentStyles()
+	body, comment := splitComment(line)
+
+	indentLen := len(body) - len(strings.TrimLeft(body, " \t"))
+	var b strings.Builder
+	b.WriteString(body[:indentLen])
+	rest := body[indentLen:]
+
+	if strings.HasPrefix(rest, "- ") || rest == "-" {
+		b.WriteString(st.punct.Render("-"))
+		rest = rest[1:]
+		n := len(rest) - len(strings.TrimLeft(rest, " "))
+		b.WriteString(rest[:n])
+		rest = rest[n:]
+	}
+	if key, value, ok := splitKey(rest); ok {
+		b.WriteString(st.key.Render(key))
+		b.WriteString(st.punct.Render(":"))
+		rest = value
+	}
+	b.WriteString(highlightValue(rest, st))
+
+	if comment != "" {
+		b.WriteString(st.comment.Render(comment))
+	}
+	return b.String()
+}
+
+// splitComment separates a trailing # comment that is not inside quotes.
+// YAML only treats # as a comment at the start or after whitespace.
+func splitComment(line string) (body, comment string) {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i], line[i:]
+		}
+	}
+	return line, ""
+}
+
+// splitKey splits "key: value" or "key:" where key is a plain or quoted
+// scalar without spaces around the colon.
+func splitKey(s string) (key, value string, ok bool) {
+	i := strings.Index(s, ":")
+	if i <= 0 || (i+1 < len(s) && s[i+1] != ' ') {
+		return "", "", false
+	}
+	key = s[:i]
+	if strings.ContainsAny(key, "{}[]") || strings.HasPrefix(key, "${") {
+		return "", "", false
+	}
+	return key, s[i+1:], true
+}
+
+// highlightValue colours quoted strings and ${{ }} expressions in a value.
+func highlightValue(s string, st highlightStyles) string {
+	var b strings.Builder
+	for len(s) > 0 {
+		i := strings.IndexAny(s, `"'$`)
+		if i < 0 {
+			b.WriteString(s)
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i:]
+
+		if strings.HasPrefix(s, "${{") {
+			end := strings.Index(s, "}}")
+			if end < 0 {
+				b.WriteString(st.expr.Render(s))
+				break
+			}
+			b.WriteString(st.expr.Render(s[:end+2]))
+			s = s[end+2:]
+			continue
+		}
+		if s[0] == '$' {
+			b.WriteByte('$')
+			s = s[1:]
+			continue
+		}
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			b.WriteString(st.str.Render(s))
+			break
+		}
+		b.WriteString(st.str.Render(s[:end+2]))
+		s = s[end+2:]
+	}
+	return b.String()
+}
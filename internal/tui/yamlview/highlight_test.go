@@ -0,0 +1,63 @@
+package yamlview
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+func TestHighlightLine(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	defer lipgloss.SetColorProfile(termenv.Ascii)
+
+	st := currentStyles()
+	key := func(s string) string { return st.key.Render(s) + st.punct.Render(":") }
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"mapping key", "on:", key("on")},
+		{"key and value", "  runs-on: ubuntu-latest", "  " + key("runs-on") + " ubuntu-latest"},
+		{"list item", "  - uses: actions/checkout@v4", "  " + st.punct.Render("-") + " " + key("uses") + " actions/checkout@v4"},
+		{"quoted value", `name: "CI"`, key("name") + " " + st.str.Render(`"CI"`)},
+		{"expression", "if: ${{ failure() }}", key("if") + " " + st.expr.Render("${{ failure() }}")},
+		{"comment", "# nightly", st.comment.Render("# nightly")},
+		{"hash inside quotes", `run: echo "#1" # note`, key("run") + " echo " + st.str.Render(`"#1"`) + " " + st.comment.Render("# note")},
+		{"url is not a key", "  https://example.com", "  https://example.com"},
+		{"plain scalar in block", "    go test ./...", "    go test ./..."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HighlightLine(tt.line); got != tt.want {
+				t.Errorf("HighlightLine(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightLineNoColor(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		// NO_COLOR is unset again by now.
+		lipgloss.SetColorProfile(termenv.TrueColor)
+		ui.ApplyTheme(ui.DarkTheme())
+		lipgloss.SetColorProfile(prev)
+	})
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Setenv("NO_COLOR", "1")
+	ui.ApplyTheme(ui.DarkTheme())
+
+	// Bold and italic may stay; colours may not.
+	sgr := regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+	got := HighlightLine(`name: "x" # ${{ github.sha }}`)
+	for _, m := range sgr.FindAllStringSubmatch(got, -1) {
+		if !regexp.MustCompile(`^[013;]*$`).MatchString(m[1]) {
+			t.Fatalf("HighlightLine() = %q, has colour code %q", got, m[0])
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package yamlview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/altinukshini/gha-tui/internal/ui"
+)
+
+// Model is a full-screen viewer for a workflow file with line numbers,
+// YAML highlighting and in-file search, modelled on the log view.
+type Model struct {
+	viewport viewport.Model
+	active   bool
+	loading  bool
+	err      error
+	path     string
+	ref      string
+	lines    []string
+	width    int
+	height   int
+	ready    bool
+
+	searchInput textinput.Model
+	searching   bool
+	searchQuery string
+	matchLines  []int
+	matchIndex  int
+}
+
+func New() Model {
+	ti := textinput.New()
+	ti.Placeholder = "Search in file..."
+	ti.CharLimit = 256
+	ui.StyleTextInput(&ti)
+	return Model{searchInput: ti}
+}
+
+// Open shows the viewer in its loading state for path at ref. An empty ref
+// stands for the default branch.
+func (m *Model) Open(path, ref string) {
+	m.active = true
+	m.loading = true
+	m.err = nil
+	m.path = path
+	m.ref = ref
+	m.lines = nil
+	m.searching = false
+	m.searchQuery = ""
+	m.matchLines = nil
+	m.matchIndex = 0
+	m.refreshViewport()
+}
+
+// Matches reports whether the viewer is waiting for path at ref.
+func (m Model) Matches(path, ref string) bool {
+	return m.active && m.path == path && m.ref == ref
+}
+
+func (m *Model) SetContent(content string) {
+	m.loading = false
+	m.lines = strings.Split(strings.TrimRight(content, "\n"), "\n")
+	m.refreshViewport()
+	if m.ready {
+		m.viewport.GotoTop()
+	}
+}
+
+func (m *Model) SetError(err error) {
+	m.loading = false
+	m.err = err
+}
+
+func (m Model) IsActive() bool    { return m.active }
+func (m Model) IsSearching() bool { return m.searching }
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.searching {
+			switch {
+			case ui.MatchesWhileTyping(msg, ui.Keys.Enter):
+				m.searchQuery = m.searchInput.Value()
+				m.findMatches()
+				m.refreshViewport()
+				if len(m.matchLines) > 0 {
+					m.viewport.SetYOffset(m.matchLines[0])
+				}
+				m.searching = false
+				m.searchInput.Blur()
+				return m, nil
+			case ui.MatchesWhileTyping(msg, ui.Keys.Back):
+				m.searching = false
+				m.searchInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.searchInput, cmd = m.searchInput.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, ui.Keys.Close):
+			m.active = false
+			return m, nil
+		case key.Matches(msg, ui.Keys.Search):
+			m.searching = true
+			m.searchInput.SetValue("")
+			m.searchInput.Focus()
+			return m, textinput.Blink
+		case key.Matches(msg, ui.Keys.NextMatch):
+			if len(m.matchLines) > 0 {
+				m.matchIndex = (m.matchIndex + 1) % len(m.matchLines)
+				m.refreshViewport()
+				m.viewport.SetYOffset(m.matchLines[m.matchIndex])
+			}
+			return m, nil
+		case key.Matches(msg, ui.Keys.PrevMatch):
+			if len(m.matchLines) > 0 {
+				m.matchIndex = (m.matchIndex - 1 + len(m.matchLines)) % len(m.matchLines)
+				m.refreshViewport()
+				m.viewport.SetYOffset(m.matchLines[m.matchIndex])
+			}
+			return m, nil
+		case key.Matches(msg, ui.Keys.Top):
+			m.viewport.GotoTop()
+			return m, nil
+		case key.Matches(msg, ui.Keys.Bottom):
+			m.viewport.GotoBottom()
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-2)
+			m.viewport.KeyMap = ui.ViewportKeyMap()
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - 2
+		}
+		m.refreshViewport()
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) findMatches() {
+	m.matchLines = nil
+	m.matchIndex = 0
+	if m.searchQuery == "" {
+		return
+	}
+	query := strings.ToLower(m.searchQuery)
+	for i, line := range m.lines {
+		if strings.Contains(strings.ToLower(line), query) {
+			m.matchLines = append(m.matchLines, i)
+		}
+	}
+}
+
+// refreshViewport renders the numbered, highlighted lines. Lines matching
+// the search are shown unhighlighted on a background instead. Lines are not
+// wrapped so that viewport offsets equal source line numbers.
+func (m *Model) refreshViewport() {
+	if !m.ready {
+		return
+	}
+	matchSet := make(map[int]bool, len(m.matchLines))
+	for _, i := range m.matchLines {
+		matchSet[i] = true
+	}
+	current := -1
+	if m.matchIndex < len(m.matchLines) {
+		current = m.matchLines[m.matchIndex]
+	}
+
+	gutterWidth := len(fmt.Sprint(len(m.lines)))
+	gutter := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+	matchStyle := ui.StyleMatchLine
+	currentStyle := ui.StyleCurrentMatch
+
+	out := make([]string, len(m.lines))
+	for i, line := range m.lines {
+		var body string
+		switch {
+		case i == current:
+			body = currentStyle.Render(line)
+		case matchSet[i]:
+			body = matchStyle.Render(line)
+		default:
+			body = HighlightLine(line)
+		}
+		out[i] = gutter.Render(fmt.Sprintf("%*d ", gutterWidth, i+1)) + body
+	}
+	m.viewport.SetContent(strings.Join(out, "\n"))
+}
+
+func (m Model) View() string {
+	ref := m.ref
+	if ref == "" {
+		ref = "default branch"
+	}
+	header := fmt.Sprintf(" %s @ %s", m.path, ref)
+	if m.ready && !m.loading && m.err == nil {
+		header += fmt.Sprintf("  %3.f%%", m.viewport.ScrollPercent()*100)
+	}
+	if m.searchQuery != "" && len(m.matchLines) > 0 {
+		header += fmt.Sprintf("  [%d/%d matches]", m.matchIndex+1, len(m.matchLines))
+	} else if m.searchQuery != "" {
+		header += "  [no matches]"
+	}
+	k := ui.Keys
+	hints := lipgloss.NewStyle().Foreground(ui.ColorMuted).Render("  " + ui.Hints(ui.Hint("search", k.Search),
+		ui.Hint("match", k.NextMatch, k.PrevMatch), ui.Hint("top/bot", k.Top, k.Bottom), ui.Hint("back", k.Close)))
+	headerLine := lipgloss.NewStyle().Bold(true).
+		Foreground(ui.ColorText).
+		Render(header) + hints
+
+	switch {
+	case m.err != nil:
+		return headerLine + "\n" + ui.RenderError(m.err)
+	case m.loading:
+		return headerLine + "\n\n  Loading workflow file..."
+	}
+
+	second := ""
+	if m.searching {
+		second = "  /" + m.searchInput.View()
+	}
+	return headerLine + "\n" + second + "\n" + m.viewport.View()
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/altinukshini/gha-tui/internal/api"
+)
+
+// ErrorHint suggests how to resolve an API error. It returns "" for errors
+// without a specific remedy, such as network failures.
+func ErrorHint(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, api.ErrUnauthorized):
+		return "Token is missing or expired. Run: gh auth login"
+	case errors.Is(err, api.ErrRateLimited):
+		reset, _ := api.RateLimitReset(err)
+		wait := time.Until(reset).Round(time.Second)
+		if wait <= 0 {
+			return "API rate limit exceeded. Retry now."
+		}
+		return fmt.Sprintf("API rate limit exceeded. Requests resume at %s (in %s).", reset.Local().Format("15:04"), wait)
+	case errors.Is(err, api.ErrForbidden):
+		return "Token lacks permission for this. Check its scopes with: gh auth status"
+	case errors.Is(err, api.ErrNotFound):
+		return "Not found. Check the repository name and that your token can access it."
+	case errors.Is(err, api.ErrServer):
+		return "GitHub returned a server error. Try again in a moment."
+	}
+	return ""
+}
+
+// FormatError renders an error on one line for the status bar, followed by
+// its hint when there is one.
+func FormatError(err error) string {
+	if hint := ErrorHint(err); hint != "" {
+		return fmt.Sprintf("%v — %s", err, hint)
+	}
+	return err.Error()
+}
+
+// RenderError renders an error for a pane, with the hint on its own line.
+func RenderError(err error) string {
+	s := fmt.Sprintf("\n  Error: %v", err)
+	if hint := ErrorHint(err); hint != "" {
+		s += "\n\n  " + StyleWarning.Render(hint)
+	}
+	return s
+}
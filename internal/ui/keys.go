@@ -1,49 +1,684 @@
 package ui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyMap holds every key binding. Handlers match against Keys rather than
+// literal key strings, so that keys.yaml can rebind anything.
 type KeyMap struct {
-	Quit        key.Binding
-	Help        key.Binding
-	Tab         key.Binding
-	ShiftTab    key.Binding
-	Enter       key.Binding
-	Back        key.Binding
-	Refresh     key.Binding
-	Search      key.Binding
-	Delete      key.Binding
-	RerunAll    key.Binding
-	RerunFailed key.Binding
-	Cancel      key.Binding
-	ForceCancel key.Binding
-	Info        key.Binding
+	// Global
+	Quit         key.Binding
+	ForceQuit    key.Binding
+	Help         key.Binding
+	TabRuns      key.Binding
+	TabWorkflows key.Binding
+	TabMetrics   key.Binding
+	TabCache     key.Binding
+	TabRunners   key.Binding
+	TabArtifacts key.Binding
+
+	// Navigation
+	Tab      key.Binding
+	ShiftTab key.Binding
+	Enter    key.Binding
+	Back     key.Binding
+	Exit     key.Binding
+	Close    key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Left     key.Binding
+	Right    key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Top      key.Binding
+	Bottom   key.Binding
+
+	// Lists
+	Refresh      key.Binding
 	Filter       key.Binding
 	ServerFilter key.Binding
-	Up           key.Binding
-	Down        key.Binding
-	PageUp      key.Binding
-	PageDown    key.Binding
-}
-
-var Keys = KeyMap{
-	Quit:        key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
-	Help:        key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "help")),
-	Tab:         key.NewBinding(key.WithKeys("tab"), key.WithHelp("tab", "next pane")),
-	ShiftTab:    key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("S-tab", "prev pane")),
-	Enter:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "select")),
-	Back:        key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
-	Refresh:     key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "refresh")),
-	Search:      key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
-	Delete:      key.NewBinding(key.WithKeys("d"), key.WithHelp("d", "delete")),
-	RerunAll:    key.NewBinding(key.WithKeys("R"), key.WithHelp("R", "rerun all")),
-	RerunFailed: key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "rerun failed")),
-	Cancel:      key.NewBinding(key.WithKeys("C"), key.WithHelp("C", "cancel run")),
-	ForceCancel: key.NewBinding(key.WithKeys("X"), key.WithHelp("X", "force cancel")),
-	Info:        key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "run info")),
-	Filter:       key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter")),
-	ServerFilter: key.NewBinding(key.WithKeys("S"), key.WithHelp("S", "server filter")),
-	Up:          key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("k/up", "up")),
-	Down:        key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("j/down", "down")),
-	PageUp:      key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", "page up")),
-	PageDown:    key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdn", "page down")),
+	Select       key.Binding
+	Sort         key.Binding
+	Delete       key.Binding
+	DeleteAll    key.Binding
+
+	// Runs and jobs
+	Search       key.Binding
+	RerunAll     key.Binding
+	RerunFailed  key.Binding
+	Cancel       key.Binding
+	ForceCancel  key.Binding
+	Info         key.Binding
+	Attempt      key.Binding
+	WorkflowFile key.Binding
+	JobGraph     key.Binding
+	Timeline     key.Binding
+	Mark         key.Binding
+	Compare      key.Binding
+	Review       key.Binding
+
+	// Log viewer
+	NextMatch   key.Binding
+	PrevMatch   key.Binding
+	Annotations key.Binding
+	Wrap        key.Binding
+	LogDiff     key.Binding
+
+	// Workflows
+	Dispatch key.Binding
+	Enable   key.Binding
+	Disable  key.Binding
+
+	// Metrics and the organization overview
+	PrevWindow  key.Binding
+	NextWindow  key.Binding
+	CycleWindow key.Binding
+	Export      key.Binding
+
+	// Overlays
+	Apply       key.Binding
+	Clear       key.Binding
+	Comment     key.Binding
+	Reject      key.Binding
+	Yes         key.Binding
+	No          key.Binding
+	ExpandAll   key.Binding
+	ToggleSteps key.Binding
+	Open        key.Binding
+
+	index *keyIndex
+}
+
+// keyIndex caches the sections of a KeyMap and the bindings active in each
+// context. Its entries point into owner, so a copied KeyMap builds its own.
+type keyIndex struct {
+	owner    *KeyMap
+	sections []Section
+	active   map[Context][]*key.Binding
+}
+
+func binding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyName(keys[0]), desc))
+}
+
+// DefaultKeyMap returns the built-in bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit:         binding("quit", "q"),
+		ForceQuit:    binding("quit from anywhere", "ctrl+c"),
+		Help:         binding("help", "?"),
+		TabRuns:      binding("runs tab", "1"),
+		TabWorkflows: binding("workflows tab", "2"),
+		TabMetrics:   binding("metrics tab", "3"),
+		TabCache:     binding("cache tab", "4"),
+		TabRunners:   binding("runners tab", "5"),
+		TabArtifacts: binding("artifacts tab", "6"),
+
+		Tab:      binding("next pane", "tab"),
+		ShiftTab: binding("prev pane", "shift+tab"),
+		Enter:    binding("select", "enter"),
+		Back:     binding("back", "esc"),
+		Exit:     binding("back", "esc", "backspace", "delete"),
+		Close:    binding("close", "esc", "q", "backspace"),
+		Up:       binding("up", "k", "up"),
+		Down:     binding("down", "j", "down"),
+		Left:     binding("left", "h", "left"),
+		Right:    binding("right", "l", "right"),
+		PageUp:   binding("page up", "pgup", "ctrl+u"),
+		PageDown: binding("page down", "pgdown", "ctrl+d"),
+		Top:      binding("top", "g", "home"),
+		Bottom:   binding("bottom", "G", "end"),
+
+		Refresh:      binding("refresh", "r"),
+		Filter:       binding("filter", "f"),
+		ServerFilter: binding("server filter", "S"),
+		Select:       binding("select", " "),
+		Sort:         binding("sort", "s"),
+		Delete:       binding("delete", "d"),
+		DeleteAll:    binding("delete all", "x"),
+
+		Search:       binding("search", "/"),
+		RerunAll:     binding("rerun all", "R"),
+		RerunFailed:  binding("rerun failed", "F"),
+		Cancel:       binding("cancel run", "C"),
+		ForceCancel:  binding("force cancel", "X"),
+		Info:         binding("info", "i"),
+		Attempt:      binding("attempt", "a"),
+		WorkflowFile: binding("workflow file", "y"),
+		JobGraph:     binding("job graph", "n"),
+		Timeline:     binding("timeline", "t"),
+		Mark:         binding("mark", "m"),
+		Compare:      binding("compare", "c"),
+		Review:       binding("review deployment", "v"),
+
+		NextMatch:   binding("next match", "n"),
+		PrevMatch:   binding("prev match", "N"),
+		Annotations: binding("annotations", "e"),
+		Wrap:        binding("wrap", "w"),
+		LogDiff:     binding("diff", "D"),
+
+		Dispatch: binding("run workflow", "w"),
+		Enable:   binding("enable", "e"),
+		Disable:  binding("disable", "D"),
+
+		PrevWindow:  binding("prev window", "["),
+		NextWindow:  binding("next window", "]"),
+		CycleWindow: binding("window", "w"),
+		Export:      binding("export", "x"),
+
+		Apply:       binding("apply", "a"),
+		Clear:       binding("clear", "c"),
+		Comment:     binding("comment", "c"),
+		Reject:      binding("reject", "x"),
+		Yes:         binding("yes", "y", "Y"),
+		No:          binding("no", "n", "N", "esc"),
+		ExpandAll:   binding("expand all", "e"),
+		ToggleSteps: binding("toggle steps", "s"),
+		Open:        binding("open in browser", "o"),
+	}
+}
+
+// Keys are the active bindings. Overrides are applied at startup, before
+// any view is created.
+var Keys = DefaultKeyMap()
+
+type namedBinding struct {
+	name string
+	b    *key.Binding
+}
+
+// named lists every binding by the name used in keys.yaml.
+func (k *KeyMap) named() []namedBinding {
+	return []namedBinding{
+		{"quit", &k.Quit}, {"force-quit", &k.ForceQuit}, {"help", &k.Help},
+		{"tab-runs", &k.TabRuns}, {"tab-workflows", &k.TabWorkflows}, {"tab-metrics", &k.TabMetrics},
+		{"tab-cache", &k.TabCache}, {"tab-runners", &k.TabRunners}, {"tab-artifacts", &k.TabArtifacts},
+		{"next", &k.Tab}, {"prev", &k.ShiftTab}, {"enter", &k.Enter},
+		{"back", &k.Back}, {"exit", &k.Exit}, {"close", &k.Close},
+		{"up", &k.Up}, {"down", &k.Down}, {"left", &k.Left}, {"right", &k.Right},
+		{"page-up", &k.PageUp}, {"page-down", &k.PageDown}, {"top", &k.Top}, {"bottom", &k.Bottom},
+		{"refresh", &k.Refresh}, {"filter", &k.Filter}, {"server-filter", &k.ServerFilter},
+		{"select", &k.Select}, {"sort", &k.Sort}, {"delete", &k.Delete}, {"delete-all", &k.DeleteAll},
+		{"search", &k.Search}, {"rerun-all", &k.RerunAll}, {"rerun-failed", &k.RerunFailed},
+		{"cancel", &k.Cancel}, {"force-cancel", &k.ForceCancel}, {"info", &k.Info},
+		{"attempt", &k.Attempt}, {"workflow-file", &k.WorkflowFile}, {"job-graph", &k.JobGraph},
+		{"timeline", &k.Timeline}, {"mark", &k.Mark}, {"compare", &k.Compare}, {"review", &k.Review},
+		{"next-match", &k.NextMatch}, {"prev-match", &k.PrevMatch}, {"annotations", &k.Annotations},
+		{"wrap", &k.Wrap}, {"log-diff", &k.LogDiff},
+		{"dispatch", &k.Dispatch}, {"enable", &k.Enable}, {"disable", &k.Disable},
+		{"prev-window", &k.PrevWindow}, {"next-window", &k.NextWindow}, {"cycle-window", &k.CycleWindow},
+		{"export", &k.Export},
+		{"apply", &k.Apply}, {"clear", &k.Clear}, {"comment", &k.Comment}, {"reject", &k.Reject},
+		{"yes", &k.Yes}, {"no", &k.No}, {"expand-all", &k.ExpandAll},
+		{"toggle-steps", &k.ToggleSteps}, {"open", &k.Open},
+	}
+}
+
+// Override rebinds actions by name. An empty key list unbinds the action;
+// "space" stands for the space bar.
+func (k *KeyMap) Override(overrides map[string][]string) error {
+	byName := make(map[string]*key.Binding)
+	for _, nb := range k.named() {
+		byName[nb.name] = nb.b
+	}
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("unknown key action %q", name)
+		}
+		keys := overrides[name]
+		if len(keys) == 0 {
+			*b = key.NewBinding(key.WithDisabled())
+			continue
+		}
+		keys = slices.Clone(keys)
+		for i, k := range keys {
+			if k == "space" {
+				keys[i] = " "
+			}
+		}
+		*b = key.NewBinding(key.WithKeys(keys...), key.WithHelp(keyName(keys[0]), b.Help().Desc))
+	}
+	k.index = k.buildIndex()
+	return nil
+}
+
+// Context is a set of bindings that are active at the same time, such as
+// the runs pane or an overlay. Keys must be unique within a context.
+type Context int
+
+const (
+	ContextGlobal Context = iota
+	ContextRuns
+	ContextJobs
+	ContextLog
+	ContextAnnotations
+	ContextInfo
+	ContextSearch
+	ContextWorkflows
+	ContextMetrics
+	ContextCache
+	ContextRunners
+	ContextArtifacts
+	ContextFilter
+	ContextDispatch
+	ContextDeploy
+	ContextConfirm
+	ContextYAML
+	ContextGraph
+	ContextTimeline
+	ContextCompare
+	ContextInput
+	ContextOrg
+)
+
+// HelpEntry is one row of the help overlay: one or more bindings and what
+// they do in a context.
+type HelpEntry struct {
+	Bindings []*key.Binding
+	Desc     string
+}
+
+// Label renders the keys of the row, e.g. "C / X".
+func (e HelpEntry) Label() string {
+	var keys []string
+	for _, b := range e.Bindings {
+		if b.Enabled() && len(b.Keys()) > 0 {
+			keys = append(keys, keyName(b.Keys()[0]))
+		}
+	}
+	return strings.Join(keys, " / ")
+}
+
+// Section lists the bindings of a context. The global bindings also apply
+// in sections marked Global.
+type Section struct {
+	Context Context
+	Title   string
+	Global  bool
+	Entries []HelpEntry
+}
+
+// Sections describes every context. The help overlay is generated from it
+// and conflicts are checked against it, so a binding handled in a context
+// must be listed there.
+func (k *KeyMap) Sections() []Section {
+	return k.indexed().sections
+}
+
+// indexed returns the index of k, building it on first use and again when
+// k is a copy of the KeyMap it was built for.
+func (k *KeyMap) indexed() *keyIndex {
+	if k.index == nil || k.index.owner != k {
+		k.index = k.buildIndex()
+	}
+	return k.index
+}
+
+func (k *KeyMap) buildIndex() *keyIndex {
+	idx := &keyIndex{owner: k, sections: k.sections(), active: make(map[Context][]*key.Binding)}
+	var global []HelpEntry
+	for _, s := range idx.sections {
+		if s.Context == ContextGlobal {
+			global = s.Entries
+		}
+	}
+	for _, s := range idx.sections {
+		entries := s.Entries
+		if s.Global {
+			entries = append(slices.Clip(entries), global...)
+		}
+		var bindings []*key.Binding
+		for _, e := range entries {
+			bindings = append(bindings, e.Bindings...)
+		}
+		idx.active[s.Context] = bindings
+	}
+	return idx
+}
+
+func (k *KeyMap) sections() []Section {
+	e := func(desc string, bs ...*key.Binding) HelpEntry { return HelpEntry{bs, desc} }
+	return []Section{
+		{ContextGlobal, "Navigation", false, []HelpEntry{
+			e("Runs tab", &k.TabRuns), e("Workflows tab", &k.TabWorkflows), e("Metrics tab", &k.TabMetrics),
+			e("Cache tab", &k.TabCache), e("Runners tab", &k.TabRunners), e("Artifacts tab", &k.TabArtifacts),
+			e("Help", &k.Help), e("Quit", &k.Quit), e("Quit from anywhere", &k.ForceQuit),
+		}},
+		{ContextRuns, "Runs", true, []HelpEntry{
+			e("Move down / up", &k.Down, &k.Up),
+			e("Page down / up", &k.PageDown, &k.PageUp),
+			e("Next / prev page", &k.Right, &k.Left),
+			e("Load jobs", &k.Enter),
+			e("Jobs pane", &k.Tab, &k.ShiftTab),
+			e("Clear server-side filter", &k.Back),
+			e("Server-side filter", &k.ServerFilter),
+			e("Filter list", &k.Filter),
+			e("Search logs", &k.Search),
+			e("Toggle select run", &k.Select),
+			e("Delete run", &k.Delete),
+			e("Refresh", &k.Refresh),
+			e("Rerun all jobs", &k.RerunAll),
+			e("Rerun failed jobs", &k.RerunFailed),
+			e("Cancel / force cancel", &k.Cancel, &k.ForceCancel),
+			e("Run info", &k.Info),
+			e("Review pending deployments", &k.Review),
+			e("Workflow file at run's commit", &k.WorkflowFile),
+			e("Job graph (needs:)", &k.JobGraph),
+			e("Timeline of jobs and steps", &k.Timeline),
+			e("Mark run for compare", &k.Mark),
+			e("Compare marked runs", &k.Compare),
+		}},
+		{ContextJobs, "Jobs", true, []HelpEntry{
+			e("Move down / up", &k.Down, &k.Up),
+			e("View job log / download artifact", &k.Enter),
+			e("Runs pane", &k.Tab, &k.ShiftTab, &k.Back),
+			e("Delete artifact", &k.Delete),
+			e("Rerun job", &k.RerunAll),
+			e("Rerun failed jobs", &k.RerunFailed),
+			e("Cancel / force cancel", &k.Cancel, &k.ForceCancel),
+			e("Job info", &k.Info),
+			e("Cycle attempt", &k.Attempt),
+			e("Server-side filter", &k.ServerFilter),
+			e("Search logs", &k.Search),
+			e("Refresh runs", &k.Refresh),
+			e("Review pending deployments", &k.Review),
+			e("Workflow file at run's commit", &k.WorkflowFile),
+			e("Job graph (needs:)", &k.JobGraph),
+			e("Timeline of jobs and steps", &k.Timeline),
+			e("Compare marked runs", &k.Compare),
+		}},
+		{ContextLog, "Log Viewer", true, []HelpEntry{
+			e("Scroll down / up", &k.Down, &k.Up),
+			e("Page down / up", &k.PageDown, &k.PageUp),
+			e("Go to top / bottom", &k.Top, &k.Bottom),
+			e("Search in log", &k.Search),
+			e("Next / prev match", &k.NextMatch, &k.PrevMatch),
+			e("Cycle attempt", &k.Attempt),
+			e("Annotations jump list", &k.Annotations),
+			e("Diff vs previous attempt / marked run", &k.LogDiff),
+			e("Toggle word wrap", &k.Wrap),
+			e("Refresh runs", &k.Refresh),
+			e("Exit log view", &k.Exit),
+		}},
+		{ContextAnnotations, "Annotations", false, []HelpEntry{
+			e("Next / prev annotation", &k.Down, &k.Up),
+			e("Jump to line", &k.Enter),
+			e("Close", &k.Annotations, &k.Close),
+		}},
+		{ContextInfo, "Info", true, []HelpEntry{
+			e("Scroll down / up", &k.Down, &k.Up),
+			e("Page down / up", &k.PageDown, &k.PageUp),
+			e("Refresh runs", &k.Refresh),
+			e("Close", &k.Exit),
+		}},
+		{ContextSearch, "Search Results", false, []HelpEntry{
+			e("Next / prev match", &k.Down, &k.Up),
+			e("View log at match", &k.Enter),
+			e("New search", &k.Search),
+			e("Close", &k.Back),
+		}},
+		{ContextWorkflows, "Workflows", true, []HelpEntry{
+			e("Move down / up", &k.Down, &k.Up),
+			e("View runs", &k.Enter),
+			e("Run workflow (dispatch)", &k.Dispatch),
+			e("View workflow file", &k.WorkflowFile),
+			e("Enable / disable", &k.Enable, &k.Disable),
+			e("Bulk delete runs", &k.Delete, &k.DeleteAll),
+			e("Filter list", &k.Filter),
+			e("Refresh", &k.Refresh),
+		}},
+		{ContextMetrics, "Metrics", true, []HelpEntry{
+			e("Cycle time window", &k.PrevWindow, &k.NextWindow),
+			e("Export report (Markdown, JSON, CSV)", &k.Export),
+			e("Scroll down / up", &k.Down, &k.Up),
+		}},
+		{ContextCache, "Cache", true, []HelpEntry{
+			e("Move down / up", &k.Down, &k.Up),
+			e("Toggle select", &k.Select),
+			e("Delete selected", &k.Delete),
+			e("Clear all", &k.DeleteAll),
+			e("Cycle sort mode", &k.Sort),
+			e("Filter list", &k.Filter),
+			e("Refresh", &k.Refresh),
+		}},
+		{ContextRunners, "Runners", true, []HelpEntry{
+			e("Move down / up", &k.Down, &k.Up),
+			e("Filter list", &k.Filter),
+			e("Refresh", &k.Refresh),
+		}},
+		{ContextArtifacts, "Artifacts", true, []HelpEntry{
+			e("Move down / up", &k.Down, &k.Up),
+			e("Download", &k.Enter),
+			e("Toggle select", &k.Select),
+			e("Delete selected", &k.Delete),
+			e("Cycle sort mode", &k.Sort),
+			e("Filter list", &k.Filter),
+			e("Refresh", &k.Refresh),
+		}},
+		{ContextFilter, "Filter", false, []HelpEntry{
+			e("Next / prev field", &k.Down, &k.Up, &k.Tab, &k.ShiftTab),
+			e("Next value / edit", &k.Enter, &k.Right),
+			e("Previous value", &k.Left),
+			e("Apply", &k.Apply),
+			e("Clear all fields", &k.Clear),
+			e("Cancel", &k.Back),
+		}},
+		{ContextDispatch, "Run Workflow", false, []HelpEntry{
+			e("Next / prev field", &k.Down, &k.Up, &k.Tab, &k.ShiftTab),
+			e("Next value / edit", &k.Enter, &k.Right, &k.Select),
+			e("Previous value", &k.Left),
+			e("Run workflow", &k.Apply),
+			e("Reset to defaults", &k.Clear),
+			e("Cancel", &k.Close),
+		}},
+		{ContextDeploy, "Deployment Review", false, []HelpEntry{
+			e("Next / prev environment", &k.Down, &k.Up),
+			e("Toggle environment", &k.Select),
+			e("Edit comment", &k.Comment, &k.Enter, &k.Tab),
+			e("Approve", &k.Apply),
+			e("Reject", &k.Reject),
+			e("Cancel", &k.Close),
+		}},
+		{ContextConfirm, "Confirm", false, []HelpEntry{
+			e("Confirm / cancel", &k.Yes, &k.No),
+			e("Choose the selected button", &k.Enter),
+			e("Switch button", &k.Tab, &k.Left, &k.Right),
+		}},
+		{ContextYAML, "Workflow File", false, []HelpEntry{
+			e("Scroll down / up", &k.Down, &k.Up),
+			e("Page down / up", &k.PageDown, &k.PageUp),
+			e("Go to top / bottom", &k.Top, &k.Bottom),
+			e("Search", &k.Search),
+			e("Next / prev match", &k.NextMatch, &k.PrevMatch),
+			e("Close", &k.Close),
+		}},
+		{ContextGraph, "Job Graph", false, []HelpEntry{
+			e("Scroll down / up", &k.Down, &k.Up),
+			e("Page down / up", &k.PageDown, &k.PageUp),
+			e("Go to top / bottom", &k.Top, &k.Bottom),
+			e("Close", &k.Close),
+		}},
+		{ContextTimeline, "Timeline", false, []HelpEntry{
+			e("Next / prev job", &k.Down, &k.Up),
+			e("Toggle steps", &k.Enter, &k.Select, &k.Right, &k.Left),
+			e("Expand / collapse all", &k.ExpandAll),
+			e("First / last job", &k.Top, &k.Bottom),
+			e("Close", &k.Close),
+		}},
+		{ContextCompare, "Run Comparison", false, []HelpEntry{
+			e("Scroll down / up", &k.Down, &k.Up),
+			e("Page down / up", &k.PageDown, &k.PageUp),
+			e("Go to top / bottom", &k.Top, &k.Bottom),
+			e("Toggle steps", &k.ToggleSteps),
+			e("Open commit comparison", &k.Open),
+			e("Close", &k.Close),
+		}},
+		{ContextInput, "Text Input", false, []HelpEntry{
+			e("Confirm", &k.Enter),
+			e("Cancel", &k.Back),
+			e("Next / prev field", &k.Tab, &k.ShiftTab),
+		}},
+		{ContextOrg, "Organization", false, []HelpEntry{
+			e("Move down / up", &k.Down, &k.Up),
+			e("Page down / up", &k.PageDown, &k.PageUp),
+			e("First / last repository", &k.Top, &k.Bottom),
+			e("Open repository", &k.Enter),
+			e("Cycle sort order", &k.Sort),
+			e("Cycle failure-rate window", &k.CycleWindow),
+			e("Reload repositories", &k.Refresh),
+			e("Quit", &k.Quit, &k.ForceQuit),
+		}},
+	}
+}
+
+// Section returns the section of ctx.
+func (k *KeyMap) Section(ctx Context) Section {
+	for _, s := range k.Sections() {
+		if s.Context == ctx {
+			return s
+		}
+	}
+	return Section{Context: ctx}
+}
+
+// active returns the bindings of ctx, with the global ones where they apply.
+func (k *KeyMap) active(ctx Context) []*key.Binding {
+	return k.indexed().active[ctx]
+}
+
+// Lookup returns the binding of ctx that msg triggers, or nil. The result
+// points into k, so it can be compared with &k.Field.
+func (k *KeyMap) Lookup(ctx Context, msg tea.KeyMsg) *key.Binding {
+	for _, b := range k.active(ctx) {
+		if key.Matches(msg, *b) {
+			return b
+		}
+	}
+	return nil
+}
+
+// Conflicts reports keys bound to two actions in the same context.
+func (k *KeyMap) Conflicts() []string {
+	names := make(map[*key.Binding]string)
+	for _, nb := range k.named() {
+		names[nb.b] = nb.name
+	}
+	var conflicts []string
+	for _, s := range k.Sections() {
+		owner := make(map[string]*key.Binding)
+		for _, b := range k.active(s.Context) {
+			if !b.Enabled() {
+				continue
+			}
+			for _, kk := range b.Keys() {
+				if prev, ok := owner[kk]; ok && prev != b {
+					conflicts = append(conflicts, fmt.Sprintf("%s: %q is bound to both %s and %s",
+						s.Title, keyName(kk), names[prev], names[b]))
+					continue
+				}
+				owner[kk] = b
+			}
+		}
+	}
+	return conflicts
+}
+
+// keyName is how a key is shown in help and hints.
+func keyName(k string) string {
+	switch k {
+	case " ":
+		return "space"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	}
+	return k
+}
+
+// KeyLabel lists every key of a binding, e.g. "k/up".
+func KeyLabel(b key.Binding) string {
+	names := make([]string, len(b.Keys()))
+	for i, k := range b.Keys() {
+		names[i] = keyName(k)
+	}
+	return strings.Join(names, "/")
+}
+
+// Hint renders "keys:desc" for the footer, joining the first key of each
+// binding with "/". Unbound bindings are left out; with none left the
+// hint is empty.
+func Hint(desc string, bindings ...key.Binding) string {
+	var keys []string
+	for _, b := range bindings {
+		if b.Enabled() && len(b.Keys()) > 0 {
+			keys = append(keys, keyName(b.Keys()[0]))
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	return strings.Join(keys, "/") + ":" + desc
+}
+
+// Hints joins footer hints, skipping empty ones.
+func Hints(hints ...string) string {
+	var parts []string
+	for _, h := range hints {
+		if h != "" {
+			parts = append(parts, h)
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// MatchesWhileTyping is key.Matches for text inputs: printable keys are
+// typed rather than treated as bindings.
+func MatchesWhileTyping(msg tea.KeyMsg, b ...key.Binding) bool {
+	return msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace && key.Matches(msg, b...)
+}
+
+// ListKeyMap is the list key map with the navigation bindings of Keys.
+func ListKeyMap() list.KeyMap {
+	km := list.DefaultKeyMap()
+	km.CursorUp = Keys.Up
+	km.CursorDown = Keys.Down
+	km.PrevPage = Keys.PageUp
+	km.NextPage = Keys.PageDown
+	km.GoToStart = Keys.Top
+	km.GoToEnd = Keys.Bottom
+	km.Filter = Keys.Filter
+	km.ClearFilter = Keys.Back
+	km.CancelWhileFiltering = Keys.Back
+	return km
+}
+
+// ViewportKeyMap is the viewport key map with the navigation bindings of
+// Keys.
+func ViewportKeyMap() viewport.KeyMap {
+	return viewport.KeyMap{
+		Up:           Keys.Up,
+		Down:         Keys.Down,
+		Left:         Keys.Left,
+		Right:        Keys.Right,
+		PageUp:       Keys.PageUp,
+		PageDown:     Keys.PageDown,
+		HalfPageUp:   key.NewBinding(key.WithDisabled()),
+		HalfPageDown: key.NewBinding(key.WithDisabled()),
+	}
 }
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func keyPress(s string) tea.KeyMsg {
+	switch s {
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "ctrl+x":
+		return tea.KeyMsg{Type: tea.KeyCtrlX}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestDefaultKeyMapHasNoConflicts(t *testing.T) {
+	k := DefaultKeyMap()
+	if conflicts := k.Conflicts(); len(conflicts) > 0 {
+		t.Errorf("default bindings conflict:\n%s", strings.Join(conflicts, "\n"))
+	}
+}
+
+func TestEveryBindingIsNamed(t *testing.T) {
+	k := DefaultKeyMap()
+	named := make(map[*key.Binding]bool)
+	for _, nb := range k.named() {
+		named[nb.b] = true
+	}
+	for _, s := range k.Sections() {
+		for _, e := range s.Entries {
+			for _, b := range e.Bindings {
+				if !named[b] {
+					t.Errorf("%s: binding %q (%s) has no name", s.Title, KeyLabel(*b), e.Desc)
+				}
+			}
+		}
+	}
+}
+
+func TestLookupByContext(t *testing.T) {
+	k := DefaultKeyMap()
+	tests := []struct {
+		ctx  Context
+		key  string
+		want *key.Binding
+	}{
+		{ContextLog, "D", &k.LogDiff},
+		{ContextWorkflows, "D", &k.Disable},
+		{ContextRuns, "D", nil},
+		{ContextLog, "n", &k.NextMatch},
+		{ContextRuns, "n", &k.JobGraph},
+		{ContextWorkflows, "w", &k.Dispatch},
+		{ContextLog, "w", &k.Wrap},
+		{ContextCache, "q", &k.Quit},
+		{ContextSearch, "q", nil},
+		{ContextJobs, "esc", &k.Back},
+	}
+	for _, tt := range tests {
+		if got := k.Lookup(tt.ctx, keyPress(tt.key)); got != tt.want {
+			t.Errorf("Lookup(%d, %q) = %v, want %v", tt.ctx, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestOverride(t *testing.T) {
+	tests := []struct {
+		name      string
+		overrides map[string][]string
+		wantErr   string
+		conflict  string
+		check     func(t *testing.T, k *KeyMap)
+	}{
+		{
+			name:      "rebind",
+			overrides: map[string][]string{"disable": {"ctrl+x"}},
+			check: func(t *testing.T, k *KeyMap) {
+				if got := k.Lookup(ContextWorkflows, keyPress("ctrl+x")); got != &k.Disable {
+					t.Errorf("ctrl+x = %v, want disable", got)
+				}
+				if got := k.Lookup(ContextWorkflows, keyPress("D")); got != nil {
+					t.Errorf("D = %v, want nothing", got)
+				}
+				if got := k.Disable.Help(); got.Key != "ctrl+x" || got.Desc != "disable" {
+					t.Errorf("help = %+v", got)
+				}
+			},
+		},
+		{
+			name:      "unbind",
+			overrides: map[string][]string{"delete-all": nil},
+			check: func(t *testing.T, k *KeyMap) {
+				if got := k.Lookup(ContextCache, keyPress("x")); got != nil {
+					t.Errorf("x = %v, want nothing", got)
+				}
+				if got := Hint("clear all", k.DeleteAll); got != "" {
+					t.Errorf("hint of unbound action = %q", got)
+				}
+			},
+		},
+		{
+			name:      "space",
+			overrides: map[string][]string{"select": {"space"}},
+			check: func(t *testing.T, k *KeyMap) {
+				if got := k.Lookup(ContextCache, keyPress(" ")); got != &k.Select {
+					t.Errorf("space = %v, want select", got)
+				}
+			},
+		},
+		{
+			name:      "conflict in one context",
+			overrides: map[string][]string{"disable": {"d"}},
+			conflict:  `Workflows: "d" is bound to both disable and delete`,
+		},
+		{
+			name:      "conflict with a global binding",
+			overrides: map[string][]string{"sort": {"q"}},
+			conflict:  `Cache: "q" is bound to both sort and quit`,
+		},
+		{
+			name:      "same key in different contexts",
+			overrides: map[string][]string{"log-diff": {"x"}},
+		},
+		{
+			name:      "unknown action",
+			overrides: map[string][]string{"disable-workflow": {"D"}},
+			wantErr:   `unknown key action "disable-workflow"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := DefaultKeyMap()
+			err := k.Override(tt.overrides)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Override() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Override() error = %v", err)
+			}
+			conflicts := strings.Join(k.Conflicts(), "\n")
+			if tt.conflict == "" && conflicts != "" {
+				t.Errorf("unexpected conflicts:\n%s", conflicts)
+			}
+			if tt.conflict != "" && !strings.Contains(conflicts, tt.conflict) {
+				t.Errorf("conflicts = %q, want %q", conflicts, tt.conflict)
+			}
+			if tt.check != nil {
+				tt.check(t, &k)
+			}
+		})
+	}
+}
+
+func TestHints(t *testing.T) {
+	k := DefaultKeyMap()
+	got := Hints(Hint("scroll", k.Down, k.Up), Hint("page", k.PageUp, k.PageDown), Hint("select", k.Select), "")
+	if want := "j/k:scroll  PgUp/PgDn:page  space:select"; got != want {
+		t.Errorf("Hints() = %q, want %q", got, want)
+	}
+}
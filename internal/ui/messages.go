@@ -5,15 +5,19 @@ import (
 )
 
 // Data fetched messages
+// Pages in the runs messages is the page count when the runs of several
+// repositories are merged; zero for a single repository.
 type RunsLoadedMsg struct {
 	Runs       []model.Run
 	TotalCount int
+	Pages      int
 	Err        error
 }
 
 type RunsPageMsg struct {
 	Runs       []model.Run
 	TotalCount int
+	Pages      int
 	Page       int
 	Err        error
 }
@@ -31,6 +35,16 @@ type LogsLoadedMsg struct {
 	Err     error
 }
 
+// LogDiffLoadedMsg carries one job's log from two attempts or runs to diff.
+type LogDiffLoadedMsg struct {
+	RunID   int64
+	JobName string
+	Title   string
+	Base    string
+	Head    string
+	Err     error
+}
+
 type SearchDoneMsg struct {
 	Results *model.SearchResults
 	Err     error
@@ -43,8 +57,9 @@ type WorkflowStats struct {
 }
 
 type WorkflowsLoadedMsg struct {
-	Workflows []model.Workflow
-	Err       error
+	Workflows  []model.Workflow
+	TotalCount int
+	Err        error
 }
 
 type WorkflowStatsMsg struct {
@@ -56,6 +71,8 @@ type ActionResultMsg struct {
 	Action  string
 	Success bool
 	Err     error
+	// Remaining lists run IDs a bulk delete failed on, so it can be resumed.
+	Remaining []int64
 }
 
 type BulkDeleteProgressMsg struct {
@@ -65,10 +82,15 @@ type BulkDeleteProgressMsg struct {
 }
 
 type DashboardDataMsg struct {
-	Runs       []model.Run
-	Jobs       []model.Job
-	TotalCount int
-	Err        error
+	Runs        []model.Run
+	Jobs        []model.Job
+	MissingJobs int
+	Err         error
+}
+
+type MetricsExportedMsg struct {
+	Paths []string
+	Err   error
 }
 
 type RunsTickMsg struct{}
@@ -76,6 +98,7 @@ type RunsTickMsg struct{}
 type RunsRefreshedMsg struct {
 	Runs       []model.Run
 	TotalCount int
+	Pages      int
 	Err        error
 }
 
@@ -129,11 +152,125 @@ type ActionsCachesLoadedMsg struct {
 type ActionsCacheDeletedMsg struct {
 	CacheID int64
 	Err     error
+	// Remaining lists cache IDs a bulk delete failed on, so it can be resumed.
+	Remaining []int64
 }
 
 // Runners messages
 type RunnersLoadedMsg struct {
-	Runners   []model.Runner
+	Runners []model.Runner
+	Err     error
+	OrgErr  error // set if org-level runners could not be fetched
+	// Truncated is set when a runner list stopped at the item limit.
+	Truncated bool
+}
+
+// Artifacts messages
+type ArtifactsLoadedMsg struct {
+	Artifacts  []model.Artifact
+	TotalCount int
+	Err        error
+}
+
+type RunArtifactsLoadedMsg struct {
+	RunID     int64
+	Artifacts []model.Artifact
 	Err       error
-	OrgFailed bool // true if org-level runners could not be fetched (permissions)
+}
+
+type ArtifactDeletedMsg struct {
+	ArtifactID int64
+	Err        error
+	// Remaining lists artifact IDs a bulk delete failed on, so it can be
+	// resumed.
+	Remaining []int64
+}
+
+type ArtifactDownloadedMsg struct {
+	Name    string
+	Path    string
+	Renamed bool // <name>.zip existed, so Path has a number added
+	Err     error
+}
+
+// Workflow dispatch messages
+type DispatchSpecLoadedMsg struct {
+	Workflow     model.Workflow
+	Ref          string
+	Inputs       []model.DispatchInput
+	Dispatchable bool
+	Environments []string
+	Err          error
+}
+
+// Deployment review messages
+type PendingDeploymentsLoadedMsg struct {
+	RunID       int64
+	Deployments []model.PendingDeployment
+	Err         error
+}
+
+type JobAnnotationsLoadedMsg struct {
+	JobID       int64
+	Annotations []model.Annotation
+	Err         error
+}
+
+// WorkflowFileLoadedMsg carries a workflow file read for the YAML viewer.
+// An empty Ref means the default branch.
+type WorkflowFileLoadedMsg struct {
+	Path    string
+	Ref     string
+	Content string
+	Err     error
+}
+
+// JobGraphLoadedMsg carries what the job graph of a run is drawn from: the
+// jobs declared in its workflow file and the jobs the run created.
+type JobGraphLoadedMsg struct {
+	RunID int64
+	Specs []model.WorkflowJob
+	Jobs  []model.Job
+	Err   error
+}
+
+// TimelineLoadedMsg carries the jobs of a run's timeline. Specs are the
+// jobs declared in its workflow file, nil when the file could not be read.
+type TimelineLoadedMsg struct {
+	RunID int64
+	Jobs  []model.Job
+	Specs []model.WorkflowJob
+	Err   error
+}
+
+// CompareJobsLoadedMsg carries the jobs of two runs being compared.
+type CompareJobsLoadedMsg struct {
+	BaseRunID int64
+	HeadRunID int64
+	Base      []model.Job
+	Head      []model.Job
+	Err       error
+}
+
+// OrgReposLoadedMsg carries the repositories of the organization overview
+// and their Actions cache usage by full name. CacheErr is set when cache
+// usage could not be read; the overview still loads without it.
+type OrgReposLoadedMsg struct {
+	Repos    []model.Repository
+	Cache    map[string]model.RepoCacheUsage
+	CacheErr error
+	// Truncated is set when the repository list stopped at the item limit,
+	// CacheTruncated when the cache usage list did.
+	Truncated      bool
+	CacheTruncated bool
+	Err            error
+}
+
+// OrgRepoSummaryMsg carries the summary of one repository in the
+// organization overview, computed over a window of Days days.
+type OrgRepoSummaryMsg struct {
+	Repo    string
+	Days    int
+	Summary model.RepoSummary
+	Err     error
 }
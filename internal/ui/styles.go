@@ -2,40 +2,39 @@ package ui
 
 import "github.com/charmbracelet/lipgloss"
 
+// The palette and styles of the current theme, set by ApplyTheme.
 var (
-	ColorPrimary   = lipgloss.Color("#7C3AED")
-	ColorSuccess   = lipgloss.Color("#10B981")
-	ColorFailure   = lipgloss.Color("#EF4444")
-	ColorWarning   = lipgloss.Color("#F59E0B")
-	ColorInfo      = lipgloss.Color("#3B82F6")
-	ColorMuted     = lipgloss.Color("#6B7280")
-	ColorBorder    = lipgloss.Color("#374151")
-	ColorHighlight = lipgloss.Color("#1F2937")
+	ColorPrimary   lipgloss.TerminalColor
+	ColorSuccess   lipgloss.TerminalColor
+	ColorFailure   lipgloss.TerminalColor
+	ColorWarning   lipgloss.TerminalColor
+	ColorInfo      lipgloss.TerminalColor
+	ColorMuted     lipgloss.TerminalColor
+	ColorBorder    lipgloss.TerminalColor
+	ColorHighlight lipgloss.TerminalColor
+	ColorText      lipgloss.TerminalColor
+	ColorSubtle    lipgloss.TerminalColor
+	ColorInverse   lipgloss.TerminalColor
+	ColorBar       lipgloss.TerminalColor
 
-	StylePane = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorBorder)
+	StylePane        lipgloss.Style
+	StylePaneFocused lipgloss.Style
+	StyleHeader      lipgloss.Style
 
-	StylePaneFocused = lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(ColorPrimary)
+	StyleSuccess lipgloss.Style
+	StyleFailure lipgloss.Style
+	StyleWarning lipgloss.Style
+	StyleInfo    lipgloss.Style
+	StyleMuted   lipgloss.Style
+	StyleText    lipgloss.Style
 
-	StyleHeader = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#F9FAFB")).
-			Background(ColorPrimary).
-			Padding(0, 1)
-
-	StyleSuccess = lipgloss.NewStyle().Foreground(ColorSuccess)
-	StyleFailure = lipgloss.NewStyle().Foreground(ColorFailure)
-	StyleWarning = lipgloss.NewStyle().Foreground(ColorWarning)
-	StyleInfo    = lipgloss.NewStyle().Foreground(ColorInfo)
-	StyleMuted   = lipgloss.NewStyle().Foreground(ColorMuted)
-
-	StyleMatch = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#FCD34D")).
-			Background(lipgloss.Color("#78350F"))
+	// StyleMatch highlights matched text within a line.
+	StyleMatch lipgloss.Style
+	// StyleMatchLine and StyleCurrentMatch mark the lines matching a search.
+	StyleMatchLine    lipgloss.Style
+	StyleCurrentMatch lipgloss.Style
+	// StyleSelected marks the selected row of a table.
+	StyleSelected lipgloss.Style
 )
 
 func ConclusionStyle(conclusion string) lipgloss.Style {
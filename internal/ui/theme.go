@@ -0,0 +1,344 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Theme is a colour palette. Colours are applied through ApplyTheme, which
+// sets the Color* and Style* variables.
+type Theme struct {
+	Name string
+
+	Primary      lipgloss.TerminalColor // focused borders, cursors, active tabs
+	Success      lipgloss.TerminalColor
+	Failure      lipgloss.TerminalColor
+	Warning      lipgloss.TerminalColor
+	Info         lipgloss.TerminalColor
+	Muted        lipgloss.TerminalColor // secondary text and hints
+	Border       lipgloss.TerminalColor // unfocused borders
+	Highlight    lipgloss.TerminalColor // background of the selected row and the header bar
+	Text         lipgloss.TerminalColor // titles and values
+	Subtle       lipgloss.TerminalColor // descriptions in the help
+	Inverse      lipgloss.TerminalColor // text on a Primary, Success or Failure background
+	Bar          lipgloss.TerminalColor // background of the status bar
+	Match        lipgloss.TerminalColor // background of lines matching a search
+	CurrentMatch lipgloss.TerminalColor // background of the current match
+	MatchText    lipgloss.TerminalColor // text of a highlighted match
+
+	// Reverse marks the selection and the current match with reverse video
+	// rather than a background colour.
+	Reverse bool
+}
+
+// color is a colour with a fallback for 16-colour terminals, which would
+// otherwise get the nearest ANSI colour, often the same for several shades.
+func color(hex, ansi string) lipgloss.TerminalColor {
+	return lipgloss.CompleteColor{TrueColor: hex, ANSI256: hex, ANSI: ansi}
+}
+
+// DarkTheme is the default palette, for dark terminal backgrounds.
+func DarkTheme() Theme {
+	return Theme{
+		Name:         "dark",
+		Primary:      color("#7C3AED", "13"),
+		Success:      color("#10B981", "10"),
+		Failure:      color("#EF4444", "9"),
+		Warning:      color("#F59E0B", "11"),
+		Info:         color("#3B82F6", "12"),
+		Muted:        color("#6B7280", "8"),
+		Border:       color("#374151", "8"),
+		Highlight:    color("#1F2937", "8"),
+		Text:         color("#F9FAFB", "15"),
+		Subtle:       color("#D1D5DB", "7"),
+		Inverse:      color("#F9FAFB", "15"),
+		Bar:          color("#111827", "0"),
+		Match:        color("#374151", "8"),
+		CurrentMatch: color("#92400E", "3"),
+		MatchText:    color("#FCD34D", "11"),
+	}
+}
+
+// LightTheme is for light terminal backgrounds: the dark palette's muted
+// grey and selection background vanish on white.
+func LightTheme() Theme {
+	return Theme{
+		Name:         "light",
+		Primary:      color("#6D28D9", "5"),
+		Success:      color("#047857", "2"),
+		Failure:      color("#B91C1C", "1"),
+		Warning:      color("#B45309", "3"),
+		Info:         color("#1D4ED8", "4"),
+		Muted:        color("#4B5563", "8"),
+		Border:       color("#9CA3AF", "8"),
+		Highlight:    color("#E5E7EB", "7"),
+		Text:         color("#111827", "0"),
+		Subtle:       color("#374151", "0"),
+		Inverse:      color("#FFFFFF", "15"),
+		Bar:          color("#F3F4F6", "7"),
+		Match:        color("#FDE68A", "11"),
+		CurrentMatch: color("#F59E0B", "3"),
+		MatchText:    color("#111827", "0"),
+	}
+}
+
+// HighContrastTheme uses saturated colours on a dark background and marks
+// the selection with reverse video.
+func HighContrastTheme() Theme {
+	return Theme{
+		Name:         "high-contrast",
+		Primary:      color("#FF55FF", "13"),
+		Success:      color("#55FF55", "10"),
+		Failure:      color("#FF5555", "9"),
+		Warning:      color("#FFFF55", "11"),
+		Info:         color("#55FFFF", "14"),
+		Muted:        color("#C0C0C0", "7"),
+		Border:       color("#FFFFFF", "15"),
+		Highlight:    color("#FFFFFF", "15"),
+		Text:         color("#FFFFFF", "15"),
+		Subtle:       color("#FFFFFF", "15"),
+		Inverse:      color("#000000", "0"),
+		Bar:          color("#000000", "0"),
+		Match:        color("#0000AA", "4"),
+		CurrentMatch: color("#FFFF55", "11"),
+		MatchText:    color("#FFFFFF", "15"),
+		Reverse:      true,
+	}
+}
+
+// ThemeNames lists the built-in themes, plus auto.
+var ThemeNames = []string{"auto", "dark", "light", "high-contrast"}
+
+// BuiltinTheme returns a built-in theme by name. auto picks dark or light
+// from the terminal background.
+func BuiltinTheme(name string) (Theme, bool) {
+	switch name {
+	case "", "auto":
+		if lipgloss.HasDarkBackground() {
+			return DarkTheme(), true
+		}
+		return LightTheme(), true
+	case "dark":
+		return DarkTheme(), true
+	case "light":
+		return LightTheme(), true
+	case "high-contrast":
+		return HighContrastTheme(), true
+	}
+	return Theme{}, false
+}
+
+func (t *Theme) colors() map[string]*lipgloss.TerminalColor {
+	return map[string]*lipgloss.TerminalColor{
+		"primary":       &t.Primary,
+		"success":       &t.Success,
+		"failure":       &t.Failure,
+		"warning":       &t.Warning,
+		"info":          &t.Info,
+		"muted":         &t.Muted,
+		"border":        &t.Border,
+		"highlight":     &t.Highlight,
+		"text":          &t.Text,
+		"subtle":        &t.Subtle,
+		"inverse":       &t.Inverse,
+		"bar":           &t.Bar,
+		"match":         &t.Match,
+		"current-match": &t.CurrentMatch,
+		"match-text":    &t.MatchText,
+	}
+}
+
+// ColorNames lists the colours a theme file can set.
+func ColorNames() []string {
+	var t Theme
+	var names []string
+	for name := range t.colors() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Override sets colours by name. A colour is a hex value such as #1F2937 or
+// an ANSI colour number from 0 to 255.
+func (t *Theme) Override(colors map[string]string) error {
+	fields := t.colors()
+	names := make([]string, 0, len(colors))
+	for name := range colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		field, ok := fields[name]
+		if !ok {
+			return fmt.Errorf("unknown colour %q, want one of %s", name, strings.Join(ColorNames(), ", "))
+		}
+		v := colors[name]
+		if n, err := strconv.Atoi(v); err == nil {
+			if n < 0 || n > 255 {
+				return fmt.Errorf("%s: ANSI colour %d is out of range", name, n)
+			}
+		} else if !hexColor.MatchString(v) {
+			return fmt.Errorf("%s: %q is neither a hex colour nor an ANSI colour number", name, v)
+		}
+		*field = lipgloss.Color(v)
+	}
+	return nil
+}
+
+// monochrome strips every colour, for NO_COLOR. The selection and matches
+// are then shown with reverse video and underlines.
+func (t Theme) monochrome() Theme {
+	for _, c := range t.colors() {
+		*c = lipgloss.NoColor{}
+	}
+	t.Reverse = true
+	return t
+}
+
+var (
+	mono     bool
+	reversed bool
+)
+
+func init() {
+	setTheme(DarkTheme())
+}
+
+// ApplyTheme makes t the palette of every style. With NO_COLOR set colours
+// are dropped, but bold, underline and reverse video are kept so that the
+// selection stays visible. On 16-colour terminals the selection and the
+// current match use reverse video, as few background colours are readable.
+func ApplyTheme(t Theme) {
+	profile := lipgloss.ColorProfile()
+	noColor := os.Getenv("NO_COLOR") != ""
+	mono = noColor || profile == termenv.Ascii
+	if mono {
+		t = t.monochrome()
+		if noColor {
+			lipgloss.SetColorProfile(termenv.ANSI)
+		}
+	}
+	if profile == termenv.ANSI {
+		t.Reverse = true
+	}
+	setTheme(t)
+}
+
+func setTheme(t Theme) {
+	reversed = t.Reverse
+
+	ColorPrimary = t.Primary
+	ColorSuccess = t.Success
+	ColorFailure = t.Failure
+	ColorWarning = t.Warning
+	ColorInfo = t.Info
+	ColorMuted = t.Muted
+	ColorBorder = t.Border
+	ColorHighlight = t.Highlight
+	ColorText = t.Text
+	ColorSubtle = t.Subtle
+	ColorInverse = t.Inverse
+	ColorBar = t.Bar
+
+	focusBorder := lipgloss.RoundedBorder()
+	if mono {
+		// Without colour, a heavier border is all that marks the focus.
+		focusBorder = lipgloss.ThickBorder()
+	}
+	StylePane = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorBorder)
+	StylePaneFocused = lipgloss.NewStyle().
+		Border(focusBorder).
+		BorderForeground(ColorPrimary)
+
+	StyleHeader = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorInverse).
+		Background(ColorPrimary).
+		Padding(0, 1)
+
+	StyleSuccess = lipgloss.NewStyle().Foreground(ColorSuccess)
+	StyleFailure = lipgloss.NewStyle().Foreground(ColorFailure)
+	StyleWarning = lipgloss.NewStyle().Foreground(ColorWarning)
+	StyleInfo = lipgloss.NewStyle().Foreground(ColorInfo)
+	StyleMuted = lipgloss.NewStyle().Foreground(ColorMuted)
+	StyleText = lipgloss.NewStyle().Foreground(ColorText)
+
+	StyleMatch = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.MatchText).
+		Background(t.CurrentMatch)
+	StyleMatchLine = lipgloss.NewStyle().Background(t.Match)
+	StyleCurrentMatch = lipgloss.NewStyle().Background(t.CurrentMatch).Bold(true)
+	StyleSelected = lipgloss.NewStyle().Background(ColorHighlight)
+	if reversed {
+		StyleMatch = lipgloss.NewStyle().Bold(true).Reverse(true)
+		StyleMatchLine = lipgloss.NewStyle().Underline(true)
+		StyleCurrentMatch = lipgloss.NewStyle().Bold(true).Reverse(true)
+		StyleSelected = lipgloss.NewStyle().Reverse(true)
+	}
+}
+
+// Badge styles a label set off by a background colour, such as the chosen
+// button of a dialog. Without colour it is shown in reverse video.
+func Badge(bg lipgloss.TerminalColor) lipgloss.Style {
+	if mono {
+		return lipgloss.NewStyle().Bold(true).Reverse(true)
+	}
+	return lipgloss.NewStyle().Bold(true).Background(bg).Foreground(ColorInverse)
+}
+
+// ListStyles returns the styles of a bubbles list in the current theme.
+func ListStyles() list.Styles {
+	s := list.DefaultStyles()
+	s.Title = s.Title.Foreground(ColorInverse).Background(ColorPrimary)
+	s.Spinner = s.Spinner.Foreground(ColorMuted)
+	s.FilterPrompt = s.FilterPrompt.Foreground(ColorPrimary)
+	s.FilterCursor = s.FilterCursor.Foreground(ColorPrimary)
+	s.StatusBar = s.StatusBar.Foreground(ColorMuted)
+	s.StatusEmpty = s.StatusEmpty.Foreground(ColorMuted)
+	s.StatusBarActiveFilter = s.StatusBarActiveFilter.Foreground(ColorText)
+	s.StatusBarFilterCount = s.StatusBarFilterCount.Foreground(ColorMuted)
+	s.NoItems = s.NoItems.Foreground(ColorMuted)
+	s.ArabicPagination = s.ArabicPagination.Foreground(ColorMuted)
+	s.ActivePaginationDot = s.ActivePaginationDot.Foreground(ColorText)
+	s.InactivePaginationDot = s.InactivePaginationDot.Foreground(ColorMuted)
+	s.DividerDot = s.DividerDot.Foreground(ColorMuted)
+	return s
+}
+
+// ItemStyles returns the styles of list.DefaultDelegate in the current
+// theme. The selected item keeps its left border, so it stands out without
+// colour too.
+func ItemStyles() list.DefaultItemStyles {
+	s := list.NewDefaultItemStyles()
+	s.NormalTitle = s.NormalTitle.Foreground(ColorText)
+	s.NormalDesc = s.NormalDesc.Foreground(ColorMuted)
+	s.SelectedTitle = s.SelectedTitle.Foreground(ColorPrimary).BorderForeground(ColorPrimary).Bold(mono)
+	s.SelectedDesc = s.SelectedDesc.Foreground(ColorPrimary).BorderForeground(ColorPrimary)
+	s.DimmedTitle = s.DimmedTitle.Foreground(ColorMuted)
+	s.DimmedDesc = s.DimmedDesc.Foreground(ColorMuted)
+	return s
+}
+
+// StyleTextInput applies the current theme to a text input.
+func StyleTextInput(ti *textinput.Model) {
+	ti.PromptStyle = ti.PromptStyle.Foreground(ColorPrimary)
+	ti.TextStyle = ti.TextStyle.Foreground(ColorText)
+	ti.PlaceholderStyle = StyleMuted
+	ti.CompletionStyle = StyleMuted
+	ti.Cursor.Style = ti.Cursor.Style.Foreground(ColorPrimary)
+}
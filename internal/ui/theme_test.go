@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestThemeOverride(t *testing.T) {
+	tests := []struct {
+		name    string
+		colors  map[string]string
+		wantErr string
+	}{
+		{"hex", map[string]string{"muted": "#555555", "current-match": "#FA0"}, ""},
+		{"ansi", map[string]string{"highlight": "254", "text": "0"}, ""},
+		{"unknown colour", map[string]string{"muted-text": "#555555"}, `unknown colour "muted-text"`},
+		{"bad value", map[string]string{"muted": "grey"}, `muted: "grey" is neither`},
+		{"out of range", map[string]string{"muted": "256"}, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := LightTheme()
+			err := th.Override(tt.colors)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Override() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Override() error = %v", err)
+			}
+			fields := th.colors()
+			for name, v := range tt.colors {
+				if got := *fields[name]; got != lipgloss.Color(v) {
+					t.Errorf("%s = %v, want %s", name, got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestColorNamesCoverTheme(t *testing.T) {
+	th := DarkTheme()
+	for name, c := range th.colors() {
+		if *c == nil {
+			t.Errorf("dark theme has no %s colour", name)
+		}
+	}
+	if got := len(ColorNames()); got != 15 {
+		t.Errorf("len(ColorNames()) = %d, want 15", got)
+	}
+}
+
+func TestNoColor(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(prev)
+		mono = false
+		setTheme(DarkTheme())
+	})
+	t.Setenv("NO_COLOR", "1")
+	ApplyTheme(DarkTheme())
+
+	if got := StyleSuccess.Render("ok"); got != "ok" {
+		t.Errorf("success = %q, want no colour", got)
+	}
+	// The selection must stay visible without colour.
+	if got := StyleSelected.Render("row"); !strings.Contains(got, "\x1b[7m") {
+		t.Errorf("selection = %q, want reverse video", got)
+	}
+	if got := Badge(ColorSuccess).Render("Yes"); !strings.Contains(got, "\x1b[7m") && !strings.Contains(got, ";7m") {
+		t.Errorf("badge = %q, want reverse video", got)
+	}
+
+	// Status icons differ by glyph, not only by colour.
+	seen := make(map[string]string)
+	for _, c := range []string{"success", "failure", "cancelled", "skipped", "in_progress", "queued", ""} {
+		icon := StatusIcon(c)
+		if other, ok := seen[icon]; ok {
+			t.Errorf("%s and %s share the icon %q", c, other, icon)
+		}
+		seen[icon] = c
+	}
+}
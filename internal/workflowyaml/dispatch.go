@@ -0,0 +1,103 @@
+// Package workflowyaml parses the parts of GitHub Actions workflow files
+// that the TUI needs.
+package workflowyaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+type rawInput struct {
+	Description string    `yaml:"description"`
+	Required    bool      `yaml:"required"`
+	Default     yaml.Node `yaml:"default"`
+	Type        string    `yaml:"type"`
+	Options     []string  `yaml:"options"`
+}
+
+// ParseDispatchInputs extracts on.workflow_dispatch.inputs from a workflow
+// file. The boolean result reports whether the workflow can be dispatched at
+// all. Inputs are returned in declaration order.
+func ParseDispatchInputs(data []byte) ([]model.DispatchInput, bool, error) {
+	var doc struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, false, fmt.Errorf("parse workflow: %w", err)
+	}
+
+	trigger, ok := findTrigger(&doc.On, "workflow_dispatch")
+	if !ok {
+		return nil, false, nil
+	}
+	if trigger == nil || trigger.Kind != yaml.MappingNode {
+		return nil, true, nil
+	}
+
+	var inputsNode *yaml.Node
+	for i := 0; i+1 < len(trigger.Content); i += 2 {
+		if trigger.Content[i].Value == "inputs" {
+			inputsNode = trigger.Content[i+1]
+			break
+		}
+	}
+	if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+		return nil, true, nil
+	}
+
+	var inputs []model.DispatchInput
+	for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+		name := inputsNode.Content[i].Value
+		var raw rawInput
+		if err := inputsNode.Content[i+1].Decode(&raw); err != nil {
+			return nil, true, fmt.Errorf("input %q: %w", name, err)
+		}
+		in := model.DispatchInput{
+			Name:        name,
+			Description: raw.Description,
+			Required:    raw.Required,
+			Type:        model.DispatchInputType(raw.Type),
+			Options:     raw.Options,
+		}
+		if raw.Default.Kind == yaml.ScalarNode && raw.Default.Tag != "!!null" {
+			in.Default = raw.Default.Value
+		}
+		switch in.Type {
+		case model.DispatchInputChoice, model.DispatchInputBoolean,
+			model.DispatchInputEnvironment, model.DispatchInputNumber:
+		default:
+			in.Type = model.DispatchInputString
+		}
+		if in.Type == model.DispatchInputBoolean && in.Default == "" {
+			in.Default = "false"
+		}
+		inputs = append(inputs, in)
+	}
+	return inputs, true, nil
+}
+
+// findTrigger looks up an event under the "on" key, which may be a single
+// event name, a list of names, or a map of event configurations. The
+// returned node is the event's configuration (nil when it has none).
+func findTrigger(on *yaml.Node, event string) (*yaml.Node, bool) {
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return nil, on.Value == event
+	case yaml.SequenceNode:
+		for _, n := range on.Content {
+			if n.Value == event {
+				return nil, true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			if on.Content[i].Value == event {
+				return on.Content[i+1], true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,92 @@
+package workflowyaml
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func TestParseDispatchInputs(t *testing.T) {
+	tests := []struct {
+		name         string
+		yaml         string
+		want         []model.DispatchInput
+		dispatchable bool
+	}{
+		{
+			name:         "scalar trigger",
+			yaml:         "on: workflow_dispatch\n",
+			dispatchable: true,
+		},
+		{
+			name:         "list trigger",
+			yaml:         "on: [push, workflow_dispatch]\n",
+			dispatchable: true,
+		},
+		{
+			name:         "not dispatchable",
+			yaml:         "on:\n  push:\n    branches: [main]\n",
+			dispatchable: false,
+		},
+		{
+			name:         "dispatch without inputs",
+			yaml:         "on:\n  workflow_dispatch:\n  push:\n",
+			dispatchable: true,
+		},
+		{
+			name: "typed inputs in declaration order",
+			yaml: `on:
+  workflow_dispatch:
+    inputs:
+      target:
+        description: Deploy target
+        required: true
+        type: choice
+        options: [staging, production]
+        default: staging
+      dry_run:
+        type: boolean
+        default: true
+      env:
+        type: environment
+      note:
+        description: Free text
+      attempts:
+        type: number
+        default: 3
+      verbose:
+        type: boolean
+`,
+			dispatchable: true,
+			want: []model.DispatchInput{
+				{Name: "target", Description: "Deploy target", Required: true, Type: model.DispatchInputChoice, Default: "staging", Options: []string{"staging", "production"}},
+				{Name: "dry_run", Type: model.DispatchInputBoolean, Default: "true"},
+				{Name: "env", Type: model.DispatchInputEnvironment},
+				{Name: "note", Description: "Free text", Type: model.DispatchInputString},
+				{Name: "attempts", Type: model.DispatchInputNumber, Default: "3"},
+				{Name: "verbose", Type: model.DispatchInputBoolean, Default: "false"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, dispatchable, err := ParseDispatchInputs([]byte(tt.yaml))
+			if err != nil {
+				t.Fatalf("ParseDispatchInputs() error = %v", err)
+			}
+			if dispatchable != tt.dispatchable {
+				t.Errorf("dispatchable = %v, want %v", dispatchable, tt.dispatchable)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("inputs = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDispatchInputsInvalidYAML(t *testing.T) {
+	if _, _, err := ParseDispatchInputs([]byte("on: [unclosed")); err == nil {
+		t.Error("expected error for invalid YAML")
+	}
+}
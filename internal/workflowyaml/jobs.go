@@ -0,0 +1,48 @@
+package workflowyaml
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+// ParseJobs extracts the jobs of a workflow file with their needs:
+// dependencies, in declaration order. needs: may be a single job ID or a
+// list of them.
+func ParseJobs(data []byte) ([]model.WorkflowJob, error) {
+	var doc struct {
+		Jobs yaml.Node `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse workflow: %w", err)
+	}
+	if doc.Jobs.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse workflow: no jobs")
+	}
+
+	var jobs []model.WorkflowJob
+	for i := 0; i+1 < len(doc.Jobs.Content); i += 2 {
+		id := doc.Jobs.Content[i].Value
+		var raw struct {
+			Name  string    `yaml:"name"`
+			Needs yaml.Node `yaml:"needs"`
+			Uses  string    `yaml:"uses"`
+		}
+		if err := doc.Jobs.Content[i+1].Decode(&raw); err != nil {
+			return nil, fmt.Errorf("job %q: %w", id, err)
+		}
+		job := model.WorkflowJob{ID: id, Name: raw.Name, Uses: raw.Uses}
+		switch raw.Needs.Kind {
+		case yaml.ScalarNode:
+			job.Needs = []string{raw.Needs.Value}
+		case yaml.SequenceNode:
+			for _, n := range raw.Needs.Content {
+				job.Needs = append(job.Needs, n.Value)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
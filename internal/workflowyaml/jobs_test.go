@@ -0,0 +1,42 @@
+package workflowyaml
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/altinukshini/gha-tui/internal/model"
+)
+
+func TestParseJobs(t *testing.T) {
+	data := []byte(`on: push
+jobs:
+  lint:
+    runs-on: ubuntu-latest
+  build:
+    name: Build ${{ matrix.os }}
+    needs: lint
+  test:
+    needs: [lint, build]
+  release:
+    needs:
+      - test
+    uses: ./.github/workflows/release.yml
+`)
+	got, err := ParseJobs(data)
+	if err != nil {
+		t.Fatalf("ParseJobs() error = %v", err)
+	}
+	want := []model.WorkflowJob{
+		{ID: "lint"},
+		{ID: "build", Name: "Build ${{ matrix.os }}", Needs: []string{"lint"}},
+		{ID: "test", Needs: []string{"lint", "build"}},
+		{ID: "release", Needs: []string{"test"}, Uses: "./.github/workflows/release.yml"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseJobs() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if _, err := ParseJobs([]byte("on: push\n")); err == nil {
+		t.Error("ParseJobs() without jobs should fail")
+	}
+}